@@ -84,7 +84,7 @@ func TakeDMG[T types.Entity](dmg types.ActionDamage, entity T) []types.Damage {
 			continue
 		}
 
-		if resistMapPercent[dmg.Type] > 0 {
+		if resistMapPercent[dmg.Type] != 0 {
 			dmg.Value = utils.PercentOf(dmg.Value, 100-resistMapPercent[dmg.Type])
 		}
 
@@ -245,6 +245,7 @@ func DefaultAction[T types.Entity](f types.FightInstance, entity T) []types.Acti
 				Event:  types.ACTION_ATTACK,
 				Source: entity.GetUUID(),
 				Target: tauntEffect.Meta.(uuid.UUID),
+				Meta:   AdaptiveDamage(entity),
 			},
 		}
 	}
@@ -254,6 +255,40 @@ func DefaultAction[T types.Entity](f types.FightInstance, entity T) []types.Acti
 			Event:  types.ACTION_ATTACK,
 			Source: entity.GetUUID(),
 			Target: utils.RandomElement(enemies).GetUUID(),
+			Meta:   AdaptiveDamage(entity),
 		},
 	}
 }
+
+func GetAdaptiveAttackType[T types.Entity](entity T) types.AdaptiveAttackType {
+	if adaptiveEntity, ok := any(entity).(interface {
+		GetAdaptiveAttackType() types.AdaptiveAttackType
+	}); ok {
+		return adaptiveEntity.GetAdaptiveAttackType()
+	}
+
+	if entity.GetStat(types.STAT_AP) > entity.GetStat(types.STAT_AD) {
+		return types.ADAPTIVE_AP
+	}
+
+	return types.ADAPTIVE_ATK
+}
+
+// Basic attack damage, AP users hit with magic instead of physical damage
+func AdaptiveDamage[T types.Entity](entity T) types.ActionDamage {
+	damage := types.Damage{
+		Value:    entity.GetStat(types.STAT_AD),
+		Type:     types.DMG_PHYSICAL,
+		CanDodge: true,
+	}
+
+	if GetAdaptiveAttackType(entity) == types.ADAPTIVE_AP {
+		damage.Value = entity.GetStat(types.STAT_AP)
+		damage.Type = types.DMG_MAGICAL
+	}
+
+	return types.ActionDamage{
+		Damage:   []types.Damage{damage},
+		CanDodge: true,
+	}
+}
@@ -28,6 +28,36 @@ type EventHandler struct {
 type FightMeta struct {
 	ThreadId   string
 	Tournament *TournamentData
+	Event      *EventData
+	Dungeon    *DungeonData
+	Duel       *DuelData
+	TeamBattle *TeamBattleData
+}
+
+type EventData struct {
+	Event uuid.UUID
+}
+
+type DungeonData struct {
+	Run uuid.UUID
+}
+
+type DuelData struct {
+	Challenge uuid.UUID
+}
+
+type TeamBattleData struct {
+	Challenge uuid.UUID
+}
+
+// Tracked per player, summons count towards their owner
+type Contribution struct {
+	Damage  int
+	Healing int
+}
+
+func (c Contribution) Total() int {
+	return c.Damage + c.Healing
 }
 
 type TournamentData struct {
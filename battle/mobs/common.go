@@ -4,19 +4,22 @@ import (
 	"sao/base"
 	"sao/battle"
 	"sao/types"
+	"sao/utils"
 
 	"github.com/google/uuid"
 )
 
 type MobEntity struct {
 	//ID as mob type
-	Id           string
-	HP           int
-	Effects      []types.ActionEffect
-	UUID         uuid.UUID
-	Stats        map[types.Stat]int
-	Name         string
-	Props        map[string]interface{}
+	Id        string
+	HP        int
+	Effects   []types.ActionEffect
+	UUID      uuid.UUID
+	Stats     map[types.Stat]int
+	Name      string
+	Props     map[string]interface{}
+	LootTable []types.LootEntry
+	//Rolled on first access, so every reader sees the same drops
 	Loot         []types.Loot
 	TempSkill    []*types.WithExpire[types.PlayerSkill]
 	OnDefeatFunc func(types.PlayerEntity)
@@ -84,11 +87,19 @@ func (m *MobEntity) GetUUID() uuid.UUID {
 }
 
 func (m *MobEntity) GetLoot() []types.Loot {
+	if m.Loot == nil {
+		m.Loot = RollLoot(m.LootTable, false)
+	}
+
 	return m.Loot
 }
 
+func (m *MobEntity) GetFirstKillLoot() []types.Loot {
+	return RollLoot(m.LootTable, true)
+}
+
 func (m *MobEntity) CanDodge() bool {
-	return false
+	return m.GetStat(types.STAT_AGL) > 0 && m.GetEffectByType(types.EFFECT_STUN) == nil
 }
 
 func (m *MobEntity) ApplyEffect(e types.ActionEffect) {
@@ -177,6 +188,22 @@ func (m *MobEntity) GetStat(stat types.Stat) int {
 		return 0
 	}
 
+	statValue := m.GetRawStat(stat)
+
+	if stat == types.STAT_AD || stat == types.STAT_AP {
+		adaptiveType := m.GetAdaptiveAttackType()
+
+		if (adaptiveType == types.ADAPTIVE_ATK && stat == types.STAT_AD) || (adaptiveType == types.ADAPTIVE_AP && stat == types.STAT_AP) {
+			statValue += m.GetRawStat(types.STAT_ADAPTIVE)
+			statValue += utils.PercentOf(statValue, m.GetRawStat(types.STAT_ADAPTIVE_PERCENT))
+		}
+	}
+
+	return statValue
+}
+
+func (m *MobEntity) GetRawStat(stat types.Stat) int {
+
 	statValue := 0
 	percentValue := 0
 
@@ -220,6 +247,14 @@ func (m *MobEntity) GetStat(stat types.Stat) int {
 	return tempValue + (tempValue * percentValue / 100)
 }
 
+func (m *MobEntity) GetAdaptiveAttackType() types.AdaptiveAttackType {
+	if m.GetRawStat(types.STAT_AP) > m.GetRawStat(types.STAT_AD) {
+		return types.ADAPTIVE_AP
+	}
+
+	return types.ADAPTIVE_ATK
+}
+
 func (m *MobEntity) AppendTempSkill(skill types.WithExpire[types.PlayerSkill]) {
 	m.TempSkill = append(m.TempSkill, &skill)
 }
@@ -246,6 +281,23 @@ func Spawn(id string) *MobEntity {
 	}
 
 	temp.UUID = uuid.New()
+	temp.Loot = nil
+	temp.Effects = make([]types.ActionEffect, len(temp.Effects))
+	temp.Stats = make(map[types.Stat]int, len(temp.Stats))
+	temp.Props = make(map[string]interface{})
+	temp.TempSkill = make([]*types.WithExpire[types.PlayerSkill], 0)
+
+	copy(temp.Effects, Mobs[id].Effects)
+
+	for idx := range temp.Effects {
+		temp.Effects[idx].Uuid = uuid.New()
+		temp.Effects[idx].Caster = temp.UUID
+		temp.Effects[idx].Target = temp.UUID
+	}
+
+	for stat, value := range Mobs[id].Stats {
+		temp.Stats[stat] = value
+	}
 
 	return &temp
 }
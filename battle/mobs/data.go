@@ -51,7 +51,105 @@ func GetMobs() map[string]MobEntity {
 		MobATK := utils.GetLuaInt(state, "ATK")
 		MobSPD := utils.GetLuaInt(state, "SPD")
 
-		loot := make([]types.Loot, 0)
+		mobStats := map[types.Stat]int{
+			types.STAT_AD:  MobATK,
+			types.STAT_SPD: MobSPD,
+			types.STAT_HP:  MobHP,
+		}
+
+		state.Global("Stats")
+
+		if state.IsTable(-1) {
+			statsMap, err := utils.GetTableAsMap(state)
+
+			if err != nil {
+				panic(err)
+			}
+
+			for key, value := range statsMap {
+				stat, ok := utils.StringToStat[key]
+
+				if !ok {
+					panic("Unknown stat " + key + " in mob " + MobId)
+				}
+
+				mobStats[stat] = int(value.(float64))
+			}
+		} else {
+			state.Pop(1)
+		}
+
+		mobEffects := make([]types.ActionEffect, 0)
+
+		state.Global("Affinities")
+
+		if state.IsTable(-1) {
+			affinityMap, err := utils.GetTableAsMap(state)
+
+			if err != nil {
+				panic(err)
+			}
+
+			for key, value := range affinityMap {
+				dmgType, ok := saoLua.StringToDamageType[key]
+
+				if !ok {
+					panic("Unknown damage type " + key + " in mob " + MobId)
+				}
+
+				mobEffects = append(mobEffects, innateResist(types.ActionEffectResist{
+					Value:     int(value.(float64)),
+					IsPercent: true,
+					DmgType:   int(dmgType),
+				}))
+			}
+		} else {
+			state.Pop(1)
+		}
+
+		state.Global("Resist")
+
+		if state.IsTable(-1) {
+			resistList, err := utils.GetTableAsArray(state)
+
+			if err != nil {
+				panic(err)
+			}
+
+			for _, rawResist := range resistList {
+				resist := rawResist.(map[string]interface{})
+
+				meta := types.ActionEffectResist{DmgType: int(types.DMG_ALL)}
+
+				if value, ok := resist["Value"].(float64); ok {
+					meta.Value = int(value)
+				}
+
+				if value, ok := resist["IsPercent"].(bool); ok {
+					meta.IsPercent = value
+				}
+
+				if value, ok := resist["All"].(bool); ok {
+					meta.All = value
+				}
+
+				if value, ok := resist["DmgType"].(string); ok {
+					dmgType, ok := saoLua.StringToDamageType[value]
+
+					if !ok {
+						panic("Unknown damage type " + value + " in mob " + MobId)
+					}
+
+					meta.DmgType = int(dmgType)
+				}
+
+				mobEffects = append(mobEffects, innateResist(meta))
+			}
+		} else {
+			state.Pop(1)
+		}
+
+		loot := make([]types.LootEntry, 0)
 
 		state.Global("Loot")
 
@@ -62,12 +160,7 @@ func GetMobs() map[string]MobEntity {
 		}
 
 		for _, lootItem := range tab {
-			lootItem := lootItem.(map[string]interface{})
-
-			loot = append(loot, types.Loot{
-				Type:  types.LootType(lootItem["Type"].(float64)),
-				Count: int(lootItem["Count"].(float64)),
-			})
+			loot = append(loot, ParseLootEntry(MobId, lootItem.(map[string]interface{})))
 		}
 
 		var onDefeat func(types.PlayerEntity)
@@ -126,21 +219,29 @@ func GetMobs() map[string]MobEntity {
 		mobs[MobId] = MobEntity{
 			Id:           MobId,
 			HP:           MobHP,
-			Effects:      make([]types.ActionEffect, 0),
+			Effects:      mobEffects,
 			UUID:         uuid.New(),
 			Name:         MobName,
 			Props:        make(map[string]interface{}),
-			Loot:         loot,
+			LootTable:    loot,
 			TempSkill:    make([]*types.WithExpire[types.PlayerSkill], 0),
 			OnDefeatFunc: onDefeat,
 			ActionFunc:   onAction,
-			Stats: map[types.Stat]int{
-				types.STAT_AD:  MobATK,
-				types.STAT_SPD: MobSPD,
-				types.STAT_HP:  MobHP,
-			},
+			Stats:        mobStats,
 		}
 	}
 
 	return mobs
 }
+
+// Innate resists are added when the mob is loaded and last the whole fight
+func innateResist(meta types.ActionEffectResist) types.ActionEffect {
+	return types.ActionEffect{
+		Effect:   types.EFFECT_RESIST,
+		Value:    meta.Value,
+		Duration: -1,
+		Uuid:     uuid.New(),
+		Meta:     meta,
+		Source:   types.SOURCE_ND,
+	}
+}
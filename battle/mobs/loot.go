@@ -0,0 +1,163 @@
+package mobs
+
+import (
+	"sao/types"
+	"sao/utils"
+
+	"github.com/google/uuid"
+)
+
+func ParseLootEntry(mobId string, rawEntry map[string]interface{}) types.LootEntry {
+	entry := types.LootEntry{
+		Min:    1,
+		Max:    1,
+		Chance: 100,
+		Rolls:  1,
+	}
+
+	if value, ok := rawEntry["Type"].(float64); ok {
+		entry.Loot.Type = types.LootType(value)
+	}
+
+	if value, ok := rawEntry["Count"].(float64); ok {
+		entry.Min = int(value)
+		entry.Max = int(value)
+	}
+
+	if value, ok := rawEntry["Min"].(float64); ok {
+		entry.Min = int(value)
+	}
+
+	if value, ok := rawEntry["Max"].(float64); ok {
+		entry.Max = int(value)
+	}
+
+	if entry.Max < entry.Min {
+		entry.Max = entry.Min
+	}
+
+	if value, ok := rawEntry["Chance"].(float64); ok {
+		entry.Chance = int(value)
+	}
+
+	if value, ok := rawEntry["Weight"].(float64); ok {
+		entry.Weight = int(value)
+	}
+
+	if value, ok := rawEntry["Rolls"].(float64); ok {
+		entry.Rolls = int(value)
+	}
+
+	if value, ok := rawEntry["FirstKill"].(bool); ok {
+		entry.FirstKill = value
+	}
+
+	if entry.Loot.Type == types.LOOT_ITEM {
+		meta := types.LootMeta{}
+
+		if value, ok := rawEntry["Item"].(string); ok {
+			meta.Type = types.ITEM_OTHER
+			meta.Uuid = uuid.MustParse(value)
+		} else if value, ok := rawEntry["Ingredient"].(string); ok {
+			meta.Type = types.ITEM_MATERIAL
+			meta.Uuid = uuid.MustParse(value)
+		} else if _, ok := rawEntry["Pool"]; !ok {
+			panic("Item loot without Item or Ingredient in mob " + mobId)
+		}
+
+		if value, ok := rawEntry["Rarity"].(string); ok {
+			rarity, ok := utils.StringToRarity[value]
+
+			if !ok {
+				panic("Unknown rarity " + value + " in mob " + mobId)
+			}
+
+			meta.Rarity = rarity
+		}
+
+		entry.Loot.Meta = &meta
+	}
+
+	if entry.Loot.Type == types.LOOT_FURY {
+		value, ok := rawEntry["Fury"].(string)
+
+		if !ok {
+			panic("Fury loot without Fury in mob " + mobId)
+		}
+
+		entry.Loot.Meta = &types.LootMeta{Uuid: uuid.MustParse(value)}
+	}
+
+	if rawPool, ok := rawEntry["Pool"].([]interface{}); ok {
+		entry.Pool = make([]types.LootEntry, 0)
+
+		for _, rawPoolEntry := range rawPool {
+			poolEntry := ParseLootEntry(mobId, rawPoolEntry.(map[string]interface{}))
+
+			if poolEntry.Weight <= 0 {
+				poolEntry.Weight = 1
+			}
+
+			entry.Pool = append(entry.Pool, poolEntry)
+		}
+	}
+
+	return entry
+}
+
+// Rolls table, only entries with matching FirstKill flag are considered
+func RollLoot(table []types.LootEntry, firstKill bool) []types.Loot {
+	loot := make([]types.Loot, 0)
+
+	for _, entry := range table {
+		if entry.FirstKill != firstKill {
+			continue
+		}
+
+		loot = append(loot, rollEntry(entry)...)
+	}
+
+	return loot
+}
+
+func rollEntry(entry types.LootEntry) []types.Loot {
+	if entry.Chance < 100 && utils.RandomNumber(1, 100) > entry.Chance {
+		return []types.Loot{}
+	}
+
+	if len(entry.Pool) == 0 {
+		count := utils.RandomNumber(entry.Min, entry.Max)
+
+		if count <= 0 {
+			return []types.Loot{}
+		}
+
+		loot := entry.Loot
+		loot.Count = count
+
+		return []types.Loot{loot}
+	}
+
+	loot := make([]types.Loot, 0)
+
+	weightSum := 0
+
+	for _, poolEntry := range entry.Pool {
+		weightSum += poolEntry.Weight
+	}
+
+	for i := 0; i < entry.Rolls; i++ {
+		roll := utils.RandomNumber(1, weightSum)
+
+		for _, poolEntry := range entry.Pool {
+			roll -= poolEntry.Weight
+
+			if roll <= 0 {
+				loot = append(loot, rollEntry(poolEntry)...)
+				break
+			}
+		}
+	}
+
+	return loot
+}
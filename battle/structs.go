@@ -5,6 +5,7 @@ import (
 	"sao/types"
 	"sao/utils"
 	"sao/world/location"
+	"slices"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/google/uuid"
@@ -29,6 +30,13 @@ type Fight struct {
 	TurnCounter     map[uuid.UUID]int
 	PlayerActions   chan types.Action
 	EventHandlers   map[uuid.UUID]EventHandler
+	Contribution    map[uuid.UUID]*Contribution
+	//Set only for open fights, closing it stops new entities from joining
+	Joins chan EntityEntry
+	//Waves spawned on side 1 once the previous one is defeated
+	Reinforcements [][]EntityEntry
+	joinsClosed    bool
+	ended          bool
 }
 
 func (f *Fight) DiscordSend(msg types.DiscordMessageStruct) {
@@ -158,9 +166,149 @@ func (f *Fight) AddAdditionalLoot(loot types.Loot, source uuid.UUID, teamWide bo
 }
 
 func (f *Fight) IsFinished() bool {
+	//Open fights can be picked up again, they end only with FightEndMsg
+	if f.IsOpen() && !f.ended {
+		return false
+	}
+
 	return len(f.SidesLeft()) <= 1
 }
 
+func (f *Fight) IsOpen() bool {
+	return f.Joins != nil
+}
+
+func (f *Fight) AddContribution(source types.Entity, damage, healing int) {
+	sourceUuid := source.GetUUID()
+
+	if summon, ok := f.SummonMap[sourceUuid]; ok {
+		sourceUuid = summon.Owner
+	}
+
+	entry, ok := f.Entities[sourceUuid]
+
+	if !ok || entry.Entity.GetFlags()&types.ENTITY_AUTO != 0 {
+		return
+	}
+
+	if _, exists := f.Contribution[sourceUuid]; !exists {
+		f.Contribution[sourceUuid] = &Contribution{}
+	}
+
+	f.Contribution[sourceUuid].Damage += damage
+	f.Contribution[sourceUuid].Healing += healing
+}
+
+func (f *Fight) addEntity(entry EntityEntry) {
+	entityUuid := entry.Entity.GetUUID()
+
+	f.Entities[entityUuid] = entry
+	f.SpeedMap[entityUuid] = entry.Entity.GetStat(types.STAT_SPD)
+	f.TurnCounter[entityUuid] = 0
+}
+
+func (f *Fight) drainJoins() {
+	for !f.joinsClosed {
+		select {
+		case entry, ok := <-f.Joins:
+			if !ok {
+				f.joinsClosed = true
+				continue
+			}
+
+			f.addEntity(entry)
+		default:
+			return
+		}
+	}
+}
+
+// Dead players leave open fights right away so they can respawn
+func (f *Fight) removeDeadPlayers() {
+	for entityUuid, entry := range f.Entities {
+		if entry.Entity.GetFlags()&types.ENTITY_AUTO != 0 || entry.Entity.GetCurrentHP() > 0 {
+			continue
+		}
+
+		entry.Entity.(types.PlayerEntity).ClearFight()
+
+		delete(f.Entities, entityUuid)
+		delete(f.SpeedMap, entityUuid)
+
+		f.DiscordChannel <- types.DiscordMessageStruct{
+			ChannelID: f.GetChannelId(),
+			MessageContent: discord.MessageCreate{
+				Embeds: []discord.Embed{{
+					Title:       "Poległ!",
+					Description: fmt.Sprintf("%s pada i opuszcza walkę", entry.Entity.GetName()),
+					Color:       0xff0000,
+				}},
+			},
+		}
+	}
+}
+
+func (f *Fight) spawnWave() {
+	wave := f.Reinforcements[0]
+	f.Reinforcements = f.Reinforcements[1:]
+
+	waveText := ""
+
+	for _, entry := range wave {
+		f.addEntity(entry)
+
+		waveText += entry.Entity.GetName() + "\n"
+	}
+
+	f.DiscordChannel <- types.DiscordMessageStruct{
+		ChannelID: f.GetChannelId(),
+		MessageContent: discord.MessageCreate{
+			Embeds: []discord.Embed{{
+				Title:       "Kolejna fala!",
+				Description: "Nadciągają:\n" + waveText,
+				Color:       0xff0000,
+			}},
+		},
+	}
+}
+
+// Returns false once the fight is over, open fights wait here for new players
+func (f *Fight) nextRound() bool {
+	if !f.IsOpen() {
+		return len(f.SidesLeft()) > 1
+	}
+
+	for {
+		f.drainJoins()
+		f.removeDeadPlayers()
+
+		enemiesLeft := slices.Contains(f.SidesLeft(), 1)
+
+		if !enemiesLeft && len(f.Reinforcements) > 0 {
+			f.spawnWave()
+
+			enemiesLeft = true
+		}
+
+		if len(f.SidesLeft()) > 1 {
+			return true
+		}
+
+		if !enemiesLeft || f.joinsClosed {
+			return false
+		}
+
+		entry, ok := <-f.Joins
+
+		if !ok {
+			f.joinsClosed = true
+			continue
+		}
+
+		f.addEntity(entry)
+	}
+}
+
 func (f *Fight) GetEnemiesFor(uuid uuid.UUID) []types.Entity {
 	entitySide := f.Entities[uuid].Side
 
@@ -308,6 +456,8 @@ func (f *Fight) HandleActionEffect(act types.Action) {
 				f.TriggerEvent(f.Entities[act.Source].Entity, f.Entities[act.Source].Entity, types.TRIGGER_HEAL_SELF, types.ActionEffectHeal{Value: healMeta.Value})
 			}
 
+			f.AddContribution(f.Entities[act.Source].Entity, 0, healMeta.Value)
+
 			f.Entities[act.Target].Entity.Heal(healMeta.Value)
 			return
 		}
@@ -320,6 +470,8 @@ func (f *Fight) HandleActionEffect(act types.Action) {
 		meta.Value = utils.PercentOf(meta.Value, 100+f.Entities[act.Source].Entity.GetStat(types.STAT_HEAL_POWER))
 
 		f.TriggerEvent(f.Entities[act.Source].Entity, f.Entities[act.Target].Entity, types.TRIGGER_HEAL_OTHER, types.ActionEffectHeal{Value: meta.Value})
+
+		f.AddContribution(f.Entities[act.Source].Entity, 0, meta.Value)
 	}
 
 	if meta.Effect == types.EFFECT_TAUNT {
@@ -364,7 +516,22 @@ func (f *Fight) HandleActionSkill(act types.Action) {
 		skillUpgrades = f.Entities[ownerUuid].Entity.(types.PlayerEntity).GetUpgrades(skillUsageMeta.Lvl)
 	}
 
-	skill := sourceEntity.(types.PlayerEntity).GetLvlSkill(skillUsageMeta.Lvl)
+	var skill types.PlayerSkill
+
+	if skillUsageMeta.IsForLevel {
+		skill = sourceEntity.(types.PlayerEntity).GetLvlSkill(skillUsageMeta.Lvl)
+	} else {
+		skill = sourceEntity.(types.PlayerEntity).GetSkill(skillUsageMeta.SkillUuid)
+	}
+
+	if skill == nil {
+		f.DiscordChannel <- types.DiscordMessageStruct{
+			ChannelID:      f.GetChannelId(),
+			MessageContent: discord.NewMessageCreateBuilder().SetContent("Nie można użyć tej umiejętności").Build(),
+		}
+
+		return
+	}
 
 	trigger := skill.GetTrigger()
 	skillCost := skill.GetCost()
@@ -395,9 +562,14 @@ func (f *Fight) HandleActionSkill(act types.Action) {
 	}
 
 	sourceEntity.RestoreMana(-skillCost)
-	sourceEntity.(types.PlayerEntity).SetLvlCD(skillUsageMeta.Lvl, cooldown)
 
-	if skillUsageMeta.Lvl%10 == 0 {
+	if skillUsageMeta.IsForLevel {
+		sourceEntity.(types.PlayerEntity).SetLvlCD(skillUsageMeta.Lvl, cooldown)
+	} else {
+		sourceEntity.(types.PlayerEntity).SetSkillCD(skill.GetUUID(), cooldown)
+	}
+
+	if skillUsageMeta.IsForLevel && skillUsageMeta.Lvl%10 == 0 {
 		sourceEntity.TriggerEvent(types.TRIGGER_CAST_ULT, types.EventData{
 			Source: sourceEntity,
 			Target: f.Entities[act.Target].Entity,
@@ -559,6 +731,7 @@ func (f *Fight) TriggerAttackEffect(dodged bool, damage []types.Damage, rawDamag
 		}
 
 		f.TriggerVampEvent(meta.Source, vampType, dmgSum, tempEmbed)
+		f.AddContribution(meta.Source, dmgSum, 0)
 
 		tempEmbed.
 			SetFooterTextf(embedMeta.TextIfHit+"%s ma teraz %d HP", meta.Source.GetName(), meta.Target.GetName(), meta.Target.GetName(), meta.Target.GetCurrentHP()).
@@ -574,6 +747,9 @@ func (f *Fight) TriggerAttackEffect(dodged bool, damage []types.Damage, rawDamag
 		}
 
 		f.TriggerCounter(meta.Source, meta.Target)
+
+		f.TriggerDurabilityLoss(meta.Source, true, tempEmbed)
+		f.TriggerDurabilityLoss(meta.Target, false, tempEmbed)
 	} else {
 		if meta.EventMissAfterSource != types.TRIGGER_NONE {
 			f.TriggerEvent(meta.Source, meta.Target, meta.EventMissAfterSource, nil)
@@ -638,6 +814,16 @@ func (f *Fight) TriggerVampEvent(source types.Entity, vampType types.Stat, dmg i
 	}
 }
 
+func (f *Fight) TriggerDurabilityLoss(entity types.Entity, attacking bool, embed *discord.EmbedBuilder) {
+	if types.HasFlag(entity.GetFlags(), types.ENTITY_AUTO) {
+		return
+	}
+
+	for _, item := range entity.(types.PlayerEntity).WearEquipment(attacking) {
+		embed.AddField("Zepsuty przedmiot!", fmt.Sprintf("%s: %s przestaje działać", entity.GetName(), item.Name), false)
+	}
+}
+
 // Target as in target of the damage
 func (f *Fight) TriggerCounter(source, target types.Entity) {
 	if types.HasFlag(target.GetFlags(), types.ENTITY_AUTO) {
@@ -753,6 +939,7 @@ func (f *Fight) HandleActionRun(act types.Action) {
 	f.Entities[act.Source].Entity.(types.PlayerEntity).ClearFight()
 
 	delete(f.Entities, act.Source)
+	delete(f.SpeedMap, act.Source)
 
 	entities := f.FromSide(side)
 
@@ -775,7 +962,7 @@ func (f *Fight) HandleActionRun(act types.Action) {
 		},
 	}
 
-	if count == 0 {
+	if count == 0 && !f.IsOpen() {
 		f.ExternalChannel <- FightEndMsg{RunAway: true}
 	}
 }
@@ -844,12 +1031,13 @@ func (f *Fight) Init() {
 	f.ExpireMap = make(map[uuid.UUID]int)
 	f.SummonMap = make(map[uuid.UUID]SummonEntityMeta)
 	f.EventHandlers = make(map[uuid.UUID]EventHandler)
+	f.Contribution = make(map[uuid.UUID]*Contribution)
 }
 
 func (f *Fight) Run() {
 	f.ExternalChannel <- FightStartMsg{}
 
-	for len(f.SidesLeft()) > 1 {
+	for f.nextRound() {
 		for entity, exp := range f.ExpireMap {
 			f.ExpireMap[entity] = exp - 1
 
@@ -897,7 +1085,7 @@ func (f *Fight) Run() {
 				entity = temp.Entity
 			}
 
-			if f.IsFinished() {
+			if len(f.SidesLeft()) <= 1 {
 				continue
 			}
 
@@ -952,5 +1140,7 @@ func (f *Fight) Run() {
 		}
 	}
 
+	f.ended = true
+
 	f.ExternalChannel <- FightEndMsg{}
 }
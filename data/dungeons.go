@@ -0,0 +1,149 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"sao/config"
+	"sao/types"
+	"sao/world/dungeon"
+	"strings"
+)
+
+var Dungeons = GetDungeons()
+
+func GetDungeons() map[string]dungeon.Definition {
+	dirData, err := os.ReadDir(config.Config.GameDataLocation + "/dungeons")
+
+	if err != nil {
+		panic(err)
+	}
+
+	var rawDungeons = make([]map[string]interface{}, 0)
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		println("Loading dungeon: " + file.Name())
+
+		rawData, err := os.ReadFile(config.Config.GameDataLocation + "/dungeons/" + file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		var parsedJson interface{}
+
+		err = json.Unmarshal(rawData, &parsedJson)
+
+		if err != nil {
+			panic(err)
+		}
+
+		if data, ok := parsedJson.(map[string]interface{}); ok {
+			rawDungeons = append(rawDungeons, data)
+		} else {
+			for _, rawDungeon := range parsedJson.([]interface{}) {
+				rawDungeons = append(rawDungeons, rawDungeon.(map[string]interface{}))
+			}
+		}
+	}
+
+	var dungeons = make(map[string]dungeon.Definition)
+
+	for _, rawDungeon := range rawDungeons {
+		Id := rawDungeon["Id"].(string)
+		Name := rawDungeon["Name"].(string)
+		Description, _ := rawDungeon["Description"].(string)
+
+		rawLocation := strings.Split(rawDungeon["Location"].(string), ",")
+
+		MinRooms := int(rawDungeon["MinRooms"].(float64))
+		MaxRooms := int(rawDungeon["MaxRooms"].(float64))
+
+		if MinRooms < 1 || MaxRooms < MinRooms {
+			panic("Dungeon " + Id + " has invalid room count")
+		}
+
+		Pool := make([]dungeon.PoolEnemy, 0)
+
+		if rawPool, ok := rawDungeon["Pool"].([]interface{}); ok {
+			for _, value := range rawPool {
+				rawEnemy := value.(map[string]interface{})
+
+				Pool = append(Pool, dungeon.PoolEnemy{
+					Enemy:  rawEnemy["Enemy"].(string),
+					MinNum: int(rawEnemy["MinNum"].(float64)),
+					MaxNum: int(rawEnemy["MaxNum"].(float64)),
+				})
+			}
+		}
+
+		Boss := make([]dungeon.BossEnemy, 0)
+
+		for _, value := range rawDungeon["Boss"].([]interface{}) {
+			rawEnemy := value.(map[string]interface{})
+
+			enemy := dungeon.BossEnemy{Enemy: rawEnemy["Enemy"].(string), Count: 1, HPPercent: 100}
+
+			if count, ok := rawEnemy["Count"].(float64); ok {
+				enemy.Count = int(count)
+			}
+
+			if hpPercent, ok := rawEnemy["HPPercent"].(float64); ok {
+				enemy.HPPercent = int(hpPercent)
+			}
+
+			Boss = append(Boss, enemy)
+		}
+
+		if len(Boss) == 0 {
+			panic("Dungeon " + Id + " has no boss")
+		}
+
+		Treasure := make([]types.Loot, 0)
+
+		if rawTreasure, ok := rawDungeon["Treasure"].(map[string]interface{}); ok {
+			Treasure = ParseLoot(rawTreasure)
+		}
+
+		Rewards := make([]types.Loot, 0)
+
+		if rawRewards, ok := rawDungeon["Rewards"].(map[string]interface{}); ok {
+			Rewards = ParseLoot(rawRewards)
+		}
+
+		RestPercent := 30
+
+		if rawRest, ok := rawDungeon["RestPercent"].(float64); ok {
+			RestPercent = int(rawRest)
+		}
+
+		Lockout := 24
+
+		if rawLockout, ok := rawDungeon["Lockout"].(float64); ok {
+			Lockout = int(rawLockout)
+		}
+
+		dungeons[Id] = dungeon.Definition{
+			Id:          Id,
+			Name:        Name,
+			Description: Description,
+			Location: types.EntityLocation{
+				Floor:    rawLocation[0],
+				Location: rawLocation[1],
+			},
+			MinRooms:    MinRooms,
+			MaxRooms:    MaxRooms,
+			Pool:        Pool,
+			Boss:        Boss,
+			Treasure:    Treasure,
+			RestPercent: RestPercent,
+			Lockout:     Lockout,
+			Rewards:     Rewards,
+		}
+	}
+
+	return dungeons
+}
@@ -0,0 +1,154 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"sao/config"
+	"sao/types"
+	"sao/world/event"
+	"sort"
+	"strings"
+)
+
+var Events = GetEvents()
+
+func GetEvents() map[string]event.Definition {
+	dirData, err := os.ReadDir(config.Config.GameDataLocation + "/events")
+
+	if err != nil {
+		panic(err)
+	}
+
+	var rawEvents = make([]map[string]interface{}, 0)
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		println("Loading event: " + file.Name())
+
+		rawData, err := os.ReadFile(config.Config.GameDataLocation + "/events/" + file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		var parsedJson interface{}
+
+		err = json.Unmarshal(rawData, &parsedJson)
+
+		if err != nil {
+			panic(err)
+		}
+
+		if data, ok := parsedJson.(map[string]interface{}); ok {
+			rawEvents = append(rawEvents, data)
+		} else {
+			for _, rawEvent := range parsedJson.([]interface{}) {
+				rawEvents = append(rawEvents, rawEvent.(map[string]interface{}))
+			}
+		}
+	}
+
+	var events = make(map[string]event.Definition)
+
+	for _, rawEvent := range rawEvents {
+		Id := rawEvent["Id"].(string)
+		Name := rawEvent["Name"].(string)
+		Description, _ := rawEvent["Description"].(string)
+
+		eventType, ok := event.StringToEventType[rawEvent["Type"].(string)]
+
+		if !ok {
+			panic("Unknown event type " + rawEvent["Type"].(string) + " in event " + Id)
+		}
+
+		rawLocation := strings.Split(rawEvent["Location"].(string), ",")
+
+		Waves := make([][]event.WaveEnemy, 0)
+
+		for _, rawWave := range rawEvent["Waves"].([]interface{}) {
+			wave := make([]event.WaveEnemy, 0)
+
+			for _, value := range rawWave.([]interface{}) {
+				rawEnemy := value.(map[string]interface{})
+
+				enemy := event.WaveEnemy{Enemy: rawEnemy["Enemy"].(string), Count: 1, HPPercent: 100}
+
+				if count, ok := rawEnemy["Count"].(float64); ok {
+					enemy.Count = int(count)
+				}
+
+				if hpPercent, ok := rawEnemy["HPPercent"].(float64); ok {
+					enemy.HPPercent = int(hpPercent)
+				}
+
+				wave = append(wave, enemy)
+			}
+
+			Waves = append(Waves, wave)
+		}
+
+		if len(Waves) == 0 {
+			panic("Event " + Id + " has no waves")
+		}
+
+		var Trigger *event.Trigger
+
+		if rawTrigger, ok := rawEvent["Trigger"].(map[string]interface{}); ok {
+			Trigger = &event.Trigger{
+				EveryDays: int(rawTrigger["EveryDays"].(float64)),
+				Hour:      int(rawTrigger["Hour"].(float64)),
+			}
+		}
+
+		Duration := 4
+
+		if rawDuration, ok := rawEvent["Duration"].(float64); ok {
+			Duration = int(rawDuration)
+		}
+
+		Rewards := make([]event.RankReward, 0)
+
+		if rawRewards, ok := rawEvent["Rewards"].([]interface{}); ok {
+			for _, value := range rawRewards {
+				rawReward := value.(map[string]interface{})
+
+				maxRank := 0
+
+				if rank, ok := rawReward["MaxRank"].(float64); ok {
+					maxRank = int(rank)
+				}
+
+				Rewards = append(Rewards, event.RankReward{MaxRank: maxRank, Loot: ParseLoot(rawReward)})
+			}
+		}
+
+		//Participation reward (MaxRank 0) has to be checked last
+		sort.SliceStable(Rewards, func(i, j int) bool {
+			if Rewards[i].MaxRank == 0 || Rewards[j].MaxRank == 0 {
+				return Rewards[j].MaxRank == 0 && Rewards[i].MaxRank != 0
+			}
+
+			return Rewards[i].MaxRank < Rewards[j].MaxRank
+		})
+
+		events[Id] = event.Definition{
+			Id:          Id,
+			Name:        Name,
+			Description: Description,
+			Type:        eventType,
+			Location: types.EntityLocation{
+				Floor:    rawLocation[0],
+				Location: rawLocation[1],
+			},
+			Waves:    Waves,
+			Trigger:  Trigger,
+			Duration: Duration,
+			Rewards:  Rewards,
+		}
+	}
+
+	return events
+}
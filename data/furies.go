@@ -0,0 +1,234 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"sao/config"
+	saoLua "sao/lua"
+	"sao/types"
+	"sao/utils"
+	"sao/world/fury"
+
+	"github.com/Shopify/go-lua"
+	"github.com/google/uuid"
+)
+
+var Furies = GetFuries()
+
+func GetFuries() map[uuid.UUID]fury.Fury {
+	dirData, err := os.ReadDir(config.Config.GameDataLocation + "/furies")
+
+	if err != nil {
+		panic(err)
+	}
+
+	furies := map[uuid.UUID]fury.Fury{}
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		state := newScriptState()
+
+		println("Loading fury: " + file.Name())
+
+		err := lua.DoFile(state, config.Config.GameDataLocation+"/furies/"+file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		furyData := fury.Fury{
+			UUID:        uuid.MustParse(utils.GetLuaString(state, "UUID")),
+			Name:        utils.GetLuaString(state, "Name"),
+			Description: utils.GetLuaString(state, "Description"),
+			Tiers:       []fury.FuryTier{},
+			LvlStats:    map[types.Stat]int{},
+		}
+
+		state.Global("LvlStats")
+
+		tempStats, err := utils.GetTableAsMap(state)
+
+		if err != nil {
+			panic(err)
+		}
+
+		furyData.LvlStats = parseFuryStats(tempStats)
+
+		state.Global("Tiers")
+
+		tempTiers, err := utils.GetTableAsArray(state)
+
+		if err != nil {
+			panic(err)
+		}
+
+		for _, rawTier := range tempTiers {
+			tierData := rawTier.(map[string]interface{})
+
+			tier := fury.FuryTier{
+				Stats:       map[types.Stat]int{},
+				Skills:      []types.PlayerSkill{},
+				Ingredients: []types.Ingredient{},
+			}
+
+			if rawStats, ok := tierData["Stats"].(map[string]interface{}); ok {
+				tier.Stats = parseFuryStats(rawStats)
+			}
+
+			if rawIngredients, ok := tierData["Ingredients"].([]interface{}); ok {
+				for _, rawIngredient := range rawIngredients {
+					ingredientData := rawIngredient.(map[string]interface{})
+
+					ingredient, exists := Ingredients[uuid.MustParse(ingredientData["UUID"].(string))]
+
+					if !exists {
+						panic(fmt.Sprintf("Unknown ingredient in fury %s", furyData.Name))
+					}
+
+					ingredient.Count = int(ingredientData["Count"].(float64))
+
+					tier.Ingredients = append(tier.Ingredients, ingredient)
+				}
+			}
+
+			if rawSkills, ok := tierData["Skills"].([]interface{}); ok {
+				for _, rawSkill := range rawSkills {
+					tier.Skills = append(tier.Skills, parseFurySkill(state, rawSkill.(map[string]interface{})))
+				}
+			}
+
+			furyData.Tiers = append(furyData.Tiers, tier)
+		}
+
+		furies[furyData.UUID] = furyData
+	}
+
+	return furies
+}
+
+func GetFuryName(furyUuid uuid.UUID) string {
+	if furyData, ok := Furies[furyUuid]; ok {
+		return furyData.Name
+	}
+
+	return "Nieznana furia"
+}
+
+func parseFuryStats(rawStats map[string]interface{}) map[types.Stat]int {
+	stats := map[types.Stat]int{}
+
+	for key, value := range rawStats {
+		stats[utils.StringToStat[key]] = int(value.(float64))
+	}
+
+	return stats
+}
+
+func parseFurySkill(state *lua.State, skillData map[string]interface{}) FurySkill {
+	skill := FurySkill{
+		State:       state,
+		UUID:        uuid.MustParse(skillData["UUID"].(string)),
+		Name:        skillData["Name"].(string),
+		Description: skillData["Description"].(string),
+		SkillData:   skillData,
+	}
+
+	if cd, exists := skillData["CD"].(float64); exists {
+		skill.CD = int(cd)
+	}
+
+	if cost, exists := skillData["Cost"].(float64); exists {
+		skill.Cost = int(cost)
+	}
+
+	if trigger, exists := skillData["Trigger"].(map[string]interface{}); exists {
+		skill.Trigger = saoLua.ReadMapAsTrigger(trigger)
+	} else {
+		panic(fmt.Sprintf("Trigger of %s is not defined", skill.Name))
+	}
+
+	return skill
+}
+
+type FurySkill struct {
+	State       *lua.State
+	UUID        uuid.UUID
+	Name        string
+	Description string
+	CD          int
+	Cost        int
+	Trigger     types.Trigger
+	SkillData   map[string]interface{}
+}
+
+func (fs FurySkill) Execute(owner types.PlayerEntity, target types.Entity, fightInstance types.FightInstance, meta interface{}) interface{} {
+	execute, exists := fs.SkillData["Execute"]
+
+	if !exists {
+		return nil
+	}
+
+	val, ok := execute.(utils.LuaFunctionRef)
+
+	if !ok {
+		panic(fmt.Sprintf("Execute of %s is not a function", fs.Name))
+	}
+
+	fs.State.Global(val.FunctionName)
+
+	fs.State.PushUserData(owner)
+	fs.State.PushUserData(target)
+	fs.State.PushUserData(fightInstance)
+	fs.State.PushUserData(meta)
+
+	fs.State.Call(4, 1)
+
+	if fs.State.IsTable(-1) {
+		rValue, err := utils.GetTableAsMap(fs.State)
+
+		if err != nil {
+			panic(err)
+		}
+
+		return saoLua.ParseReturnMeta(rValue, fs.Trigger)
+	}
+
+	fs.State.Pop(1)
+
+	return nil
+}
+
+func (fs FurySkill) GetEvents() map[types.CustomTrigger]func(owner types.PlayerEntity) {
+	return nil
+}
+
+func (fs FurySkill) GetUUID() uuid.UUID {
+	return fs.UUID
+}
+
+func (fs FurySkill) GetName() string {
+	return fs.Name
+}
+
+func (fs FurySkill) GetDescription() string {
+	return fs.Description
+}
+
+func (fs FurySkill) GetCD() int {
+	return fs.CD
+}
+
+func (fs FurySkill) GetCost() int {
+	return fs.Cost
+}
+
+func (fs FurySkill) GetTrigger() types.Trigger {
+	return fs.Trigger
+}
+
+func (fs FurySkill) IsLevelSkill() bool {
+	return false
+}
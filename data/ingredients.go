@@ -12,6 +12,12 @@ import (
 
 var Ingredients = GetIngredients()
 
+// Consumed when enhancing items
+var EnhancementStone = uuid.MustParse("00000000-0000-0002-0000-000000000001")
+
+// Pays for skill reset instead of gold
+var RespecScroll = uuid.MustParse("00000000-0000-0002-0000-000000000002")
+
 func GetIngredients() map[uuid.UUID]types.Ingredient {
 	dirData, err := os.ReadDir(config.Config.GameDataLocation + "/ingredients")
 
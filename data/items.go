@@ -28,37 +28,7 @@ func GetItems() map[uuid.UUID]types.PlayerItem {
 			continue
 		}
 
-		state := lua.NewState()
-
-		lua.OpenLibraries(state)
-
-		state.NewTable()
-
-		state.PushGoFunction(func(state *lua.State) int {
-			value := lua.CheckInteger(state, 1)
-			percent := lua.CheckInteger(state, 2)
-
-			state.PushInteger(utils.PercentOf(value, percent))
-
-			return 1
-		})
-
-		state.SetField(-2, "PercentOf")
-
-		state.PushGoFunction(func(state *lua.State) int {
-			state.PushString(uuid.New().String())
-
-			return 1
-		})
-
-		state.SetField(-2, "GenerateUUID")
-
-		state.SetGlobal("utils")
-
-		saoLua.AddStatTypes(state)
-		saoLua.AddPlayerFunctions(state)
-		saoLua.AddEntityFunctions(state)
-		saoLua.AddFightFunctions(state)
+		state := newScriptState()
 
 		println("Loading item: " + file.Name())
 
@@ -82,6 +52,26 @@ func GetItems() map[uuid.UUID]types.PlayerItem {
 			Effects:     []types.PlayerSkill{},
 		}
 
+		//Items taking slot without explicit one are accessories
+		if slot, ok := utils.GetNullableString(state, "Slot"); ok {
+			item.Slot = utils.StringToItemSlot[slot]
+		} else if item.TakesSlot {
+			item.Slot = types.SLOT_ACCESSORY
+		}
+
+		if rarity, ok := utils.GetNullableString(state, "Rarity"); ok {
+			item.Rarity = utils.StringToRarity[rarity]
+		}
+
+		if durability, ok := utils.GetNullableInt(state, "Durability"); ok {
+			item.MaxDurability = durability
+			item.Durability = durability
+		}
+
+		if furyUuid, ok := utils.GetNullableString(state, "Fury"); ok {
+			item.Fury = uuid.MustParse(furyUuid)
+		}
+
 		state.Global("Stats")
 
 		tempStats, err := utils.GetTableAsMap(state)
@@ -94,6 +84,8 @@ func GetItems() map[uuid.UUID]types.PlayerItem {
 			item.Stats[utils.StringToStat[key]] = int(value.(float64))
 		}
 
+		item.BaseStats = item.Stats
+
 		state.Global("Effects")
 
 		if state.IsNil(-1) {
@@ -122,6 +114,43 @@ func GetItems() map[uuid.UUID]types.PlayerItem {
 	return items
 }
 
+// Lua state with helpers available to item and fury scripts
+func newScriptState() *lua.State {
+	state := lua.NewState()
+
+	lua.OpenLibraries(state)
+
+	state.NewTable()
+
+	state.PushGoFunction(func(state *lua.State) int {
+		value := lua.CheckInteger(state, 1)
+		percent := lua.CheckInteger(state, 2)
+
+		state.PushInteger(utils.PercentOf(value, percent))
+
+		return 1
+	})
+
+	state.SetField(-2, "PercentOf")
+
+	state.PushGoFunction(func(state *lua.State) int {
+		state.PushString(uuid.New().String())
+
+		return 1
+	})
+
+	state.SetField(-2, "GenerateUUID")
+
+	state.SetGlobal("utils")
+
+	saoLua.AddStatTypes(state)
+	saoLua.AddPlayerFunctions(state)
+	saoLua.AddEntityFunctions(state)
+	saoLua.AddFightFunctions(state)
+
+	return state
+}
+
 type ItemEffect struct {
 	State      *lua.State
 	Idx        int
@@ -265,3 +294,15 @@ func (s SimplePlayerSkill) GetTrigger() types.Trigger {
 func (s SimplePlayerSkill) IsLevelSkill() bool {
 	return false
 }
+
+func GetItemName(itemType types.ItemType, itemUuid uuid.UUID) string {
+	if itemType == types.ITEM_MATERIAL {
+		if ingredient, ok := Ingredients[itemUuid]; ok {
+			return ingredient.Name
+		}
+	} else if item, ok := Items[itemUuid]; ok {
+		return item.Name
+	}
+
+	return "Nieznany przedmiot"
+}
@@ -0,0 +1,189 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"sao/config"
+	"sao/types"
+	"sao/world/quest"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var Quests = GetQuests()
+
+func GetQuests() map[uuid.UUID]quest.Quest {
+	dirData, err := os.ReadDir(config.Config.GameDataLocation + "/quests")
+
+	if err != nil {
+		panic(err)
+	}
+
+	var rawQuests = make([]map[string]interface{}, 0)
+
+	for _, file := range dirData {
+		if file.IsDir() {
+			continue
+		}
+
+		println("Loading quest: " + file.Name())
+
+		rawData, err := os.ReadFile(config.Config.GameDataLocation + "/quests/" + file.Name())
+
+		if err != nil {
+			panic(err)
+		}
+
+		var parsedJson interface{}
+
+		err = json.Unmarshal(rawData, &parsedJson)
+
+		if err != nil {
+			panic(err)
+		}
+
+		if data, ok := parsedJson.(map[string]interface{}); ok {
+			rawQuests = append(rawQuests, data)
+		} else {
+			for _, rawQuest := range parsedJson.([]interface{}) {
+				rawQuests = append(rawQuests, rawQuest.(map[string]interface{}))
+			}
+		}
+	}
+
+	var quests = make(map[uuid.UUID]quest.Quest)
+
+	for _, rawQuest := range rawQuests {
+		UUID := uuid.MustParse(rawQuest["UUID"].(string))
+		Name := rawQuest["Name"].(string)
+
+		rawGiver := rawQuest["Giver"].(map[string]interface{})
+		rawGiverLocation := strings.Split(rawGiver["Location"].(string), ",")
+
+		Giver := quest.Giver{
+			Name: rawGiver["Name"].(string),
+			Location: types.EntityLocation{
+				Floor:    rawGiverLocation[0],
+				Location: rawGiverLocation[1],
+			},
+		}
+
+		Description, _ := rawQuest["Description"].(string)
+
+		MinLevel := 1
+
+		if rawLevel, ok := rawQuest["MinLevel"].(float64); ok {
+			MinLevel = int(rawLevel)
+		}
+
+		Prerequisites := make([]uuid.UUID, 0)
+
+		if rawPrerequisites, ok := rawQuest["Prerequisites"].([]interface{}); ok {
+			for _, value := range rawPrerequisites {
+				Prerequisites = append(Prerequisites, uuid.MustParse(value.(string)))
+			}
+		}
+
+		Objectives := make([]quest.Objective, 0)
+
+		for _, value := range rawQuest["Objectives"].([]interface{}) {
+			rawObjective := value.(map[string]interface{})
+
+			objectiveType, ok := quest.StringToObjective[rawObjective["Type"].(string)]
+
+			if !ok {
+				panic("Unknown objective type " + rawObjective["Type"].(string) + " in quest " + Name)
+			}
+
+			target, _ := rawObjective["Target"].(string)
+
+			//Ingredient and recipe targets are compared as uuid strings
+			if objectiveType == quest.OBJECTIVE_COLLECT || objectiveType == quest.OBJECTIVE_CRAFT {
+				target = uuid.MustParse(target).String()
+			}
+
+			count := 1
+
+			if rawCount, ok := rawObjective["Count"].(float64); ok {
+				count = int(rawCount)
+			}
+
+			Objectives = append(Objectives, quest.Objective{Type: objectiveType, Target: target, Count: count})
+		}
+
+		Rewards := make([]types.Loot, 0)
+		UnlockFloors := make([]string, 0)
+
+		if rawRewards, ok := rawQuest["Rewards"].(map[string]interface{}); ok {
+			Rewards = ParseLoot(rawRewards)
+
+			if rawFloors, ok := rawRewards["Floors"].([]interface{}); ok {
+				for _, value := range rawFloors {
+					UnlockFloors = append(UnlockFloors, value.(string))
+				}
+			}
+		}
+
+		quests[UUID] = quest.Quest{
+			UUID:          UUID,
+			Name:          Name,
+			Description:   Description,
+			Giver:         Giver,
+			MinLevel:      MinLevel,
+			Prerequisites: Prerequisites,
+			Objectives:    Objectives,
+			Rewards:       Rewards,
+			UnlockFloors:  UnlockFloors,
+		}
+	}
+
+	return quests
+}
+
+// Shared reward format of quests and events: Exp, Gold, Items, Ingredients and Fury
+func ParseLoot(rawRewards map[string]interface{}) []types.Loot {
+	Rewards := make([]types.Loot, 0)
+
+	if value, ok := rawRewards["Exp"].(float64); ok {
+		Rewards = append(Rewards, types.Loot{Type: types.LOOT_EXP, Count: int(value)})
+	}
+
+	if value, ok := rawRewards["Gold"].(float64); ok {
+		Rewards = append(Rewards, types.Loot{Type: types.LOOT_GOLD, Count: int(value)})
+	}
+
+	for _, key := range []string{"Items", "Ingredients"} {
+		rawItems, ok := rawRewards[key].([]interface{})
+
+		itemType := types.ITEM_OTHER
+
+		if key == "Ingredients" {
+			itemType = types.ITEM_MATERIAL
+		}
+
+		if !ok {
+			continue
+		}
+
+		for _, value := range rawItems {
+			rawItem := value.(map[string]interface{})
+
+			Rewards = append(Rewards, types.Loot{
+				Type:  types.LOOT_ITEM,
+				Count: int(rawItem["Count"].(float64)),
+				Meta:  &types.LootMeta{Type: itemType, Uuid: uuid.MustParse(rawItem["UUID"].(string))},
+			})
+		}
+	}
+
+	if value, ok := rawRewards["Fury"].(string); ok {
+		Rewards = append(Rewards, types.Loot{
+			Type:  types.LOOT_FURY,
+			Count: 1,
+			Meta:  &types.LootMeta{Uuid: uuid.MustParse(value)},
+		})
+	}
+
+	return Rewards
+}
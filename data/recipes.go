@@ -5,6 +5,7 @@ import (
 	"os"
 	"sao/config"
 	"sao/types"
+	"strings"
 
 	"github.com/google/uuid"
 )
@@ -75,12 +76,46 @@ func GetRecipes() map[uuid.UUID]types.Recipe {
 			Count: int(recipe["Product"].(map[string]interface{})["Count"].(float64)),
 		}
 
+		var Station types.EntityLocation
+
+		if rawStation, ok := recipe["Station"].(string); ok {
+			Station = types.EntityLocation{
+				Floor:    strings.Split(rawStation, ",")[0],
+				Location: strings.Split(rawStation, ",")[1],
+			}
+		}
+
+		Hidden, _ := recipe["Hidden"].(bool)
+
+		Level := 1
+
+		if rawLevel, ok := recipe["Level"].(float64); ok {
+			Level = int(rawLevel)
+		}
+
+		Chance := 100
+
+		if rawChance, ok := recipe["Chance"].(float64); ok {
+			Chance = int(rawChance)
+		}
+
+		Exp := 10
+
+		if rawExp, ok := recipe["Exp"].(float64); ok {
+			Exp = int(rawExp)
+		}
+
 		recipes[UUID] = types.Recipe{
 			UUID:        UUID,
 			Name:        Name,
 			Ingredients: Ingredients,
 			Cost:        Cost,
 			Product:     Product,
+			Station:     Station,
+			Hidden:      Hidden,
+			Level:       Level,
+			Chance:      Chance,
+			Exp:         Exp,
 		}
 	}
 
@@ -61,14 +61,40 @@ func GetShops() map[uuid.UUID]*types.NPCStore {
 
 			Item := types.ItemType(stock["Item"].(float64))
 			Price := int(stock["Price"].(float64))
+			Quantity := -1
+
+			if rawQuantity, ok := stock["Quantity"]; ok {
+				Quantity = int(rawQuantity.(float64))
+			}
 
 			stocks = append(stocks, &types.Stock{
-				ItemType: Item,
-				ItemUUID: uuid.MustParse(stock["iuuid"].(string)),
-				Price:    Price,
+				ItemType:    Item,
+				ItemUUID:    uuid.MustParse(stock["iuuid"].(string)),
+				Price:       Price,
+				BasePrice:   Price,
+				Quantity:    Quantity,
+				MaxQuantity: Quantity,
 			})
 		}
 
+		restockDays := 0
+
+		if rawRestock, ok := shop["RestockDays"]; ok {
+			restockDays = int(rawRestock.(float64))
+		}
+
+		repairPrice := 0
+
+		if rawRepair, ok := shop["RepairPrice"]; ok {
+			repairPrice = int(rawRepair.(float64))
+		}
+
+		respecPrice := 0
+
+		if rawRespec, ok := shop["RespecPrice"]; ok {
+			respecPrice = int(rawRespec.(float64))
+		}
+
 		shops[shopUUID] = &types.NPCStore{
 			Uuid: shopUUID,
 			Name: name,
@@ -76,7 +102,11 @@ func GetShops() map[uuid.UUID]*types.NPCStore {
 				Location: strings.Split(location, ",")[1],
 				Floor:    strings.Split(location, ",")[0],
 			},
-			Stock: stocks,
+			Stock:        stocks,
+			RestockDays:  restockDays,
+			LastDelivery: -1,
+			RepairPrice:  repairPrice,
+			RespecPrice:  respecPrice,
 		}
 	}
 
@@ -1,33 +1,44 @@
 package discord
 
 import (
+	"fmt"
+	"sao/battle/mobs"
 	"sao/data"
+	"sao/types"
+	"sao/world/quest"
 	"sao/world/tournament"
+	"slices"
 	"strings"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
+	"github.com/google/uuid"
 )
 
 func AutocompleteHandler(event *events.AutocompleteInteractionCreate) {
 	switch event.Data.CommandName {
-	case "ruch":
+	case "ruch", "party":
 		locationOption := event.Data.String("nazwa")
 
+		if event.Data.CommandName == "party" {
+			locationOption = event.Data.String("lokacja")
+		}
+
 		userSnowflake := event.Member().User.ID
 
-		var floorName string
+		playerChar := World.GetPlayer(userSnowflake.String())
 
-		for _, pl := range World.Players {
-			if pl.Meta.UserID == userSnowflake.String() {
-				floorName = pl.Meta.Location.Floor
-			}
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		if playerChar == nil {
+			event.AutocompleteResult(choices)
+			return
 		}
 
-		choices := make([]discord.AutocompleteChoice, 0)
+		floorName := playerChar.Meta.Location.Floor
 
 		for _, location := range World.Floors[floorName].Locations {
-			if !location.Unlocked {
+			if !World.CanEnter(playerChar, floorName, location) {
 				continue
 			}
 
@@ -41,6 +52,33 @@ func AutocompleteHandler(event *events.AutocompleteInteractionCreate) {
 
 		event.AutocompleteResult(choices)
 
+	case "zbieraj":
+		nodeOption := event.Data.String("węzeł")
+
+		playerChar := World.GetPlayer(event.Member().User.ID.String())
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		if playerChar == nil {
+			event.AutocompleteResult(choices)
+			return
+		}
+
+		currentLocation := World.Floors[playerChar.Meta.Location.Floor].FindLocation(playerChar.Meta.Location.Location)
+
+		if currentLocation != nil {
+			for _, node := range currentLocation.Nodes {
+				if strings.HasPrefix(node.Id, nodeOption) {
+					choices = append(choices, discord.AutocompleteChoiceString{
+						Name:  node.Id,
+						Value: node.Id,
+					})
+				}
+			}
+		}
+
+		event.AutocompleteResult(choices)
+
 	case "tp":
 		locationOption := event.Data.String("nazwa")
 
@@ -74,10 +112,17 @@ func AutocompleteHandler(event *events.AutocompleteInteractionCreate) {
 	case "stwórz":
 		itemOption := event.Data.String("nazwa")
 
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
 		choices := make([]discord.AutocompleteChoice, 0)
 
 		for _, item := range data.Recipes {
-			if strings.HasPrefix(item.Name, itemOption) {
+			if pl.Meta.Crafting.Knows(item) && strings.HasPrefix(item.Name, itemOption) {
 				choices = append(choices, discord.AutocompleteChoiceString{
 					Name:  item.Name,
 					Value: item.UUID.String(),
@@ -105,5 +150,451 @@ func AutocompleteHandler(event *events.AutocompleteInteractionCreate) {
 		}
 
 		event.AutocompleteResult(choices)
+	case "sklep":
+		itemOption := event.Data.String("przedmiot")
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		if *event.Data.SubCommandName == "napraw" || *event.Data.SubCommandName == "ulepsz" {
+			for idx, item := range pl.Inventory.Items {
+				if !strings.HasPrefix(item.Name, itemOption) {
+					continue
+				}
+
+				var choiceName string
+
+				if *event.Data.SubCommandName == "napraw" {
+					if item.MaxDurability == 0 || item.Durability == item.MaxDurability {
+						continue
+					}
+
+					choiceName = fmt.Sprintf("%s (%d/%d)", ItemName(item), item.Durability, item.MaxDurability)
+				} else {
+					if item.Slot == types.SLOT_NONE || item.Enhancement >= types.MAX_ENHANCEMENT {
+						continue
+					}
+
+					gold, stones := item.EnhancementCost()
+					choiceName = fmt.Sprintf("%s (%d golda, %d kamieni, %d%%)", ItemName(item), gold, stones, item.EnhancementChance())
+				}
+
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  choiceName,
+					Value: fmt.Sprint(idx),
+				})
+			}
+
+			if len(choices) > 25 {
+				event.AutocompleteResult(choices[:25])
+			} else {
+				event.AutocompleteResult(choices)
+			}
+
+			return
+		}
+		stocks := make(map[uuid.UUID]*types.Stock)
+
+		for _, store := range World.Stores {
+			if store.Location != pl.Meta.Location {
+				continue
+			}
+
+			for _, stock := range store.Stock {
+				if best, exists := stocks[stock.ItemUUID]; !exists || stock.SellPrice() > best.SellPrice() {
+					stocks[stock.ItemUUID] = stock
+				}
+			}
+		}
+
+		//Items are picked by index, each instance has its own price
+		for idx, item := range pl.Inventory.Items {
+			stock, exists := stocks[item.UUID]
+
+			if !exists || stock.ItemType == types.ITEM_MATERIAL || item.Equipped || !strings.HasPrefix(item.Name, itemOption) {
+				continue
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s x%d (%d golda/szt.)", ItemName(item), item.Count, stock.ItemSellPrice(item)),
+				Value: fmt.Sprint(idx),
+			})
+		}
+
+		for _, ingredient := range pl.Inventory.Ingredients {
+			stock, exists := stocks[ingredient.UUID]
+
+			if !exists || stock.ItemType != types.ITEM_MATERIAL || ingredient.Count == 0 || !strings.HasPrefix(ingredient.Name, itemOption) {
+				continue
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s x%d (%d golda/szt.)", ingredient.Name, ingredient.Count, stock.SellPrice()),
+				Value: ingredient.UUID.String(),
+			})
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "aukcje":
+		itemOption := event.Data.String("przedmiot")
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+		added := make(map[uuid.UUID]bool)
+
+		//Items are picked by index so the exact instance is listed
+		for idx, item := range pl.Inventory.Items {
+			if item.Equipped || !strings.HasPrefix(item.Name, itemOption) {
+				continue
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s x%d", ItemName(item), item.Count),
+				Value: fmt.Sprint(idx),
+			})
+		}
+
+		for _, ingredient := range pl.Inventory.Ingredients {
+			if added[ingredient.UUID] || !strings.HasPrefix(ingredient.Name, itemOption) {
+				continue
+			}
+
+			added[ingredient.UUID] = true
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s x%d", ingredient.Name, ingredient.Count),
+				Value: ingredient.UUID.String(),
+			})
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "gildia":
+		itemOption := event.Data.String("przedmiot")
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		if *event.Data.SubCommandName == "wypłać" {
+			if guildObj := World.PlayerGuild(pl.GetUUID()); guildObj != nil {
+				for _, item := range guildObj.Items {
+					if !strings.HasPrefix(data.GetItemName(item.ItemType, item.Item), itemOption) {
+						continue
+					}
+
+					choices = append(choices, discord.AutocompleteChoiceString{
+						Name:  GuildItemName(item),
+						Value: item.Uuid.String(),
+					})
+				}
+			}
+		} else {
+			added := make(map[uuid.UUID]bool)
+
+			//Items are picked by index so the exact instance is deposited
+			for idx, item := range pl.Inventory.Items {
+				if item.Equipped || !strings.HasPrefix(item.Name, itemOption) {
+					continue
+				}
+
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  fmt.Sprintf("%s x%d", ItemName(item), item.Count),
+					Value: fmt.Sprint(idx),
+				})
+			}
+
+			for _, ingredient := range pl.Inventory.Ingredients {
+				if added[ingredient.UUID] || !strings.HasPrefix(ingredient.Name, itemOption) {
+					continue
+				}
+
+				added[ingredient.UUID] = true
+
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  fmt.Sprintf("%s x%d", ingredient.Name, ingredient.Count),
+					Value: ingredient.UUID.String(),
+				})
+			}
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "plecak":
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		switch *event.Data.SubCommandName {
+		case "załóż", "zdejmij":
+			itemOption := event.Data.String("przedmiot")
+			equipped := *event.Data.SubCommandName == "zdejmij"
+
+			for idx, item := range pl.Inventory.Items {
+				if item.Slot == types.SLOT_NONE || item.Equipped != equipped || !strings.HasPrefix(item.Name, itemOption) {
+					continue
+				}
+
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  fmt.Sprintf("%s (%s)", ItemName(item), types.ItemSlotToString[item.Slot]),
+					Value: fmt.Sprint(idx),
+				})
+			}
+		case "wczytaj", "usuń":
+			nameOption := event.Data.String("nazwa")
+
+			for name := range pl.Inventory.Loadouts {
+				if strings.HasPrefix(name, nameOption) {
+					choices = append(choices, discord.AutocompleteChoiceString{
+						Name:  name,
+						Value: name,
+					})
+				}
+			}
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "furia":
+		itemOption := event.Data.String("przedmiot")
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		for idx, item := range pl.Inventory.Items {
+			if item.Fury == uuid.Nil || !strings.HasPrefix(item.Name, itemOption) {
+				continue
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s (%s)", ItemName(item), data.GetFuryName(item.Fury)),
+				Value: fmt.Sprint(idx),
+			})
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "questy":
+		questOption := event.Data.String("zadanie")
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		quests := make([]quest.Quest, 0)
+
+		if *event.Data.SubCommandName == "przyjmij" {
+			quests = World.AvailableQuests(pl.GetUUID())
+		} else {
+			for questUuid := range pl.Meta.Quests.Active {
+				if questData, exists := data.Quests[questUuid]; exists {
+					quests = append(quests, questData)
+				}
+			}
+		}
+
+		for _, questData := range quests {
+			if !strings.HasPrefix(questData.Name, questOption) {
+				continue
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s (%s)", questData.Name, questData.Giver.Name),
+				Value: questData.UUID.String(),
+			})
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "zlecenia":
+		bountyOption := strings.ToLower(event.Data.String("zlecenie"))
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		for _, board := range World.BountyBoards() {
+			for _, bountyData := range board.All() {
+				_, active := pl.Meta.Bounties.Active[bountyData.Uuid]
+
+				if active != (*event.Data.SubCommandName == "odbierz") || slices.Contains(pl.Meta.Bounties.Claimed, bountyData.Uuid) {
+					continue
+				}
+
+				name := fmt.Sprintf("%s (%s)", BountyText(bountyData), board.Floor)
+
+				if !strings.Contains(strings.ToLower(name), bountyOption) {
+					continue
+				}
+
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  name,
+					Value: bountyData.Uuid.String(),
+				})
+			}
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "loch":
+		dungeonOption := strings.ToLower(event.Data.String("loch"))
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		for dungeonId, definition := range data.Dungeons {
+			if !strings.Contains(strings.ToLower(definition.Name), dungeonOption) {
+				continue
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s (%s)", definition.Name, definition.Location.Location),
+				Value: dungeonId,
+			})
+		}
+
+		event.AutocompleteResult(choices)
+	case "wydarzenie":
+		eventOption := strings.ToLower(event.Data.String("wydarzenie"))
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		if *event.Data.SubCommandName == "start" {
+			for eventId, definition := range data.Events {
+				if !strings.Contains(strings.ToLower(definition.Name), eventOption) {
+					continue
+				}
+
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  definition.Name,
+					Value: eventId,
+				})
+			}
+		} else {
+			for _, eventObj := range World.ActiveEvents() {
+				definition := data.Events[eventObj.Definition]
+
+				if !eventObj.Open || !strings.Contains(strings.ToLower(definition.Name), eventOption) {
+					continue
+				}
+
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  fmt.Sprintf("%s (%s)", definition.Name, definition.Location.Location),
+					Value: eventObj.Uuid.String(),
+				})
+			}
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "podnieś":
+		itemOption := event.Data.String("przedmiot")
+
+		pl := World.GetPlayer(event.User().ID.String())
+
+		if pl == nil {
+			event.AutocompleteResult(nil)
+			return
+		}
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		for _, dropped := range World.DroppedItemsAt(pl.Meta.Location) {
+			if !strings.HasPrefix(dropped.Item.Name, itemOption) {
+				continue
+			}
+
+			choices = append(choices, discord.AutocompleteChoiceString{
+				Name:  fmt.Sprintf("%s x%d", ItemName(dropped.Item), dropped.Item.Count),
+				Value: dropped.Uuid.String(),
+			})
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
+	case "bestiariusz":
+		mobOption := event.Data.String("nazwa")
+
+		choices := make([]discord.AutocompleteChoice, 0)
+
+		for id, mob := range mobs.Mobs {
+			if strings.HasPrefix(mob.GetName(), mobOption) {
+				choices = append(choices, discord.AutocompleteChoiceString{
+					Name:  mob.GetName(),
+					Value: id,
+				})
+			}
+		}
+
+		if len(choices) > 25 {
+			event.AutocompleteResult(choices[:25])
+		} else {
+			event.AutocompleteResult(choices)
+		}
 	}
 }
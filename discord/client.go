@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"sao/battle/mobs"
 	"sao/config"
 	"sao/data"
 	"sao/player"
@@ -11,10 +12,16 @@ import (
 	"sao/types"
 	"sao/utils"
 	"sao/world"
+	"sao/world/auction"
+	"sao/world/calendar"
+	"sao/world/guild"
 	"sao/world/location"
 	"sao/world/party"
 	"sao/world/tournament"
 	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/disgoorg/disgo"
 	"github.com/disgoorg/disgo/bot"
@@ -136,7 +143,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 		}
 	}
 
-	if interactionData.CommandName() != "create" && interactionData.CommandName() != "turniej" && playerChar == nil {
+	if interactionData.CommandName() != "create" && interactionData.CommandName() != "turniej" && interactionData.CommandName() != "ranking" && interactionData.CommandName() != "czas" && interactionData.CommandName() != "wydarzenie" && playerChar == nil {
 		event.CreateMessage(noCharMessage)
 		return
 	}
@@ -158,6 +165,10 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 		newPlayer := player.NewPlayer(charName, charUser.ID.String())
 
+		if archetype, exists := interactionData.OptString("klasa"); exists {
+			newPlayer.ChooseArchetype(archetype)
+		}
+
 		World.Players[newPlayer.GetUUID()] = &newPlayer
 
 		err := event.CreateMessage(MessageContent("Zarejestrowano postać "+charName, false))
@@ -171,14 +182,65 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 	case "ruch":
 		locationName := interactionData.String("nazwa")
 
-		err := World.MovePlayer(playerChar.GetUUID(), playerChar.Meta.Location.Floor, locationName, "")
+		ticks, err := World.Travel(playerChar.GetUUID(), playerChar.Meta.Location.Floor, locationName)
 
-		if err == nil {
+		if err != nil {
+			event.CreateMessage(MessageContent(travelErrorText(err), true))
+			return
+		}
+
+		if ticks == 0 {
 			event.CreateMessage(MessageContent("Przeszedłeś do "+locationName, false))
 		} else {
-			event.CreateMessage(MessageContent("Nie możesz przejść do "+locationName, true))
+			event.CreateMessage(MessageContent(fmt.Sprintf("Wyruszyłeś do %s, dotrzesz <t:%d:R>", locationName, time.Now().Add(time.Duration(ticks)*time.Minute).Unix()), false))
 		}
 
+		return
+	case "zbieraj":
+		nodeId, hasNode := interactionData.OptString("węzeł")
+
+		if !hasNode {
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					AddEmbeds(GatheringEmbed(playerChar)).
+					SetEphemeral(true).
+					Build(),
+			)
+			return
+		}
+
+		result, err := World.Gather(playerChar.GetUUID(), nodeId)
+
+		if err != nil {
+			event.CreateMessage(MessageContent(gatherErrorText(err), true))
+			return
+		}
+
+		resultText := fmt.Sprintf("Zebrano %dx %s (+%d XP profesji)", result.Count, data.Ingredients[result.Ingredient].Name, result.Exp)
+
+		if result.LevelUp {
+			node := World.Floors[playerChar.Meta.Location.Floor].FindLocation(playerChar.Meta.Location.Location).FindNode(nodeId)
+
+			resultText += fmt.Sprintf("\n%s wzrasta do poziomu %d!", ProfessionToString[node.Type], playerChar.Meta.Professions.Get(node.Type).Level)
+		}
+
+		if result.Depleted {
+			resultText += "\nTo miejsce zostało wyczerpane"
+		}
+
+		event.CreateMessage(MessageContent(resultText, false))
+
+		return
+	case "eksploruj":
+		err := World.Explore(playerChar.GetUUID())
+
+		if err != nil {
+			event.CreateMessage(MessageContent(travelErrorText(err), true))
+			return
+		}
+
+		event.CreateMessage(MessageContent(fmt.Sprintf("Eksplorujesz lokację %s, skończysz <t:%d:R>", playerChar.Meta.Location.Location, time.Now().Add(player.ExploreTicks*time.Minute).Unix()), true))
+
 		return
 	case "tp":
 		floorName := interactionData.String("nazwa")
@@ -237,6 +299,12 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 		inFightText := utils.BoolToText(playerChar.Meta.FightInstance != nil, "Tak", "Nie")
 		inPartyText := utils.BoolToText(playerChar.Meta.Party != nil, "Tak", "Nie")
 
+		guildText := "Brak"
+
+		if guildObj := World.PlayerGuild(playerChar.GetUUID()); guildObj != nil {
+			guildText = fmt.Sprintf("%s (poz. %d)", guildObj.Name, guildObj.Level)
+		}
+
 		lvlText := fmt.Sprint(playerChar.XP.Level)
 
 		if playerChar.XP.Level >= World.GetUnlockedFloorCount()*5 {
@@ -272,9 +340,12 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 					AddField("AP", fmt.Sprintf("%d", playerChar.GetStat(types.STAT_AP)), true).
 					AddField("DEF/RES", fmt.Sprintf("%d/%d", playerChar.GetStat(types.STAT_DEF), playerChar.GetStat(types.STAT_MR)), true).
 					AddField("Lvl", lvlText, true).
+					AddField("Klasa", utils.BoolToText(playerChar.Attributes.Archetype != "", playerChar.Attributes.Archetype, "Brak"), true).
 					AddField("SPD/AGL", fmt.Sprintf("%d/%d", playerChar.GetStat(types.STAT_SPD), playerChar.GetStat(types.STAT_AGL)), true).
 					AddField("W walce?", inFightText, true).
 					AddField("W party?", inPartyText, true).
+					AddField("Gildia", guildText, true).
+					AddField("PvP", fmt.Sprintf("%d (%d/%d)", playerChar.Meta.PvP.Rating, playerChar.Meta.PvP.Wins, playerChar.Meta.PvP.Losses), true).
 					AddField("Dynamiczne statystyki", derivedStatsText, true).
 					Build(),
 			)
@@ -284,6 +355,594 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 		}
 
 		event.CreateMessage(messageBuilder.Build())
+	case "podnieś":
+		dropUuid, err := uuid.Parse(interactionData.String("przedmiot"))
+
+		if err != nil {
+			event.CreateMessage(MessageContent("Nie znaleziono przedmiotu", true))
+			return
+		}
+
+		item, err := World.PickUpItem(playerChar.GetUUID(), dropUuid)
+
+		if err != nil {
+			event.CreateMessage(MessageContent(deathErrorText(err), true))
+			return
+		}
+
+		event.CreateMessage(MessageContent(fmt.Sprintf("Podniesiono %s x%d", ItemName(item), item.Count), false))
+
+		return
+	case "hardcore":
+		if playerChar.Meta.Hardcore {
+			event.CreateMessage(MessageContent("Twoja postać jest już w trybie hardcore", true))
+			return
+		}
+
+		event.CreateMessage(
+			discord.NewMessageCreateBuilder().
+				SetContent("W trybie hardcore śmierć jest ostateczna - postać zostanie usunięta, a jej imię trafi na listę poległych. Tej decyzji nie można cofnąć.").
+				AddActionRow(discord.NewDangerButton("Włącz hardcore", "hc/confirm")).
+				SetEphemeral(true).
+				Build(),
+		)
+
+		return
+	case "ranking":
+		hardcore := interactionData.Bool("hardcore")
+
+		event.CreateMessage(MessageEmbed(LeaderboardEmbed(World.Leaderboard(hardcore), hardcore)))
+
+		return
+	case "czas":
+		event.CreateMessage(MessageEmbed(CalendarEmbed(playerChar)))
+
+		return
+	case "wydarzenie":
+		switch *interactionData.SubCommandName {
+		case "lista":
+			event.CreateMessage(MessageEmbed(EventsEmbed()))
+		case "dołącz":
+			if playerChar == nil {
+				event.CreateMessage(noCharMessage)
+				return
+			}
+
+			eventUuid, err := uuid.Parse(interactionData.String("wydarzenie"))
+
+			if err != nil {
+				event.CreateMessage(MessageContent("Nie ma takiego wydarzenia", true))
+				return
+			}
+
+			err = World.JoinEvent(playerChar.GetUUID(), eventUuid)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(eventErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Dołączono do wydarzenia!", true))
+		case "start":
+			if !isAdmin(member) {
+				event.CreateMessage(MessageContent("Nie masz uprawnień do tej komendy", true))
+				return
+			}
+
+			inHours, _ := interactionData.OptInt("za_godzin")
+
+			err := World.ScheduleEvent(interactionData.String("wydarzenie"), inHours)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(eventErrorText(err), true))
+				return
+			}
+
+			if inHours > 0 {
+				event.CreateMessage(MessageContent(fmt.Sprintf("Zaplanowano wydarzenie za %d godz.", inHours), true))
+			} else {
+				event.CreateMessage(MessageContent("Rozpoczęto wydarzenie", true))
+			}
+		}
+
+		return
+	case "pojedynek":
+		switch *interactionData.SubCommandName {
+		case "wyzwij":
+			opponentUser := interactionData.User("gracz")
+			opponent := World.GetPlayer(opponentUser.ID.String())
+
+			if opponent == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			stake, _ := interactionData.OptInt("stawka")
+			restoreHP, _ := interactionData.OptBool("przywróć_hp")
+
+			challenge, err := World.ChallengeDuel(playerChar.GetUUID(), opponent.GetUUID(), stake, restoreHP)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(duelErrorText(err), true))
+				return
+			}
+
+			content := fmt.Sprintf("<@%s>, %s wyzywa cię na pojedynek!", opponent.Meta.UserID, playerChar.GetName())
+
+			if stake > 0 {
+				content += fmt.Sprintf("\nStawka: %d golda od każdego", stake)
+			}
+
+			content += "\n" + utils.BoolToText(restoreHP, "HP zostanie przywrócone po walce", "Obrażenia zostaną po walce")
+			content += fmt.Sprintf("\nWyzwanie wygasa <t:%d:R>", challenge.Expires.Unix())
+
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					SetContent(content).
+					AddActionRow(
+						discord.NewSuccessButton("Przyjmij", "duel/acc|"+challenge.Uuid.String()),
+						discord.NewDangerButton("Odrzuć", "duel/dec|"+challenge.Uuid.String()),
+					).
+					Build(),
+			)
+		case "historia":
+			if mentionedUser, exists := interactionData.OptUser("gracz"); exists {
+				playerChar = World.GetPlayer(mentionedUser.ID.String())
+
+				if playerChar == nil {
+					event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+					return
+				}
+			}
+
+			event.CreateMessage(MessageEmbed(DuelHistoryEmbed(playerChar)))
+		}
+
+		return
+	case "gildia":
+		switch *interactionData.SubCommandName {
+		case "załóż":
+			var channelId, roleId string
+
+			guildObj, err := World.CreateGuild(playerChar.GetUUID(), interactionData.String("nazwa"), func(name string) (string, string, error) {
+				guildId := snowflake.MustParse(config.Config.GuildID)
+
+				role, err := (*Client).Rest().CreateRole(guildId, discord.RoleCreate{Name: "Gildia " + name})
+
+				if err != nil {
+					return "", "", err
+				}
+
+				channel, err := (*Client).Rest().CreateGuildChannel(guildId, discord.GuildTextChannelCreate{
+					Name: "gildia-" + name,
+					PermissionOverwrites: []discord.PermissionOverwrite{
+						discord.RolePermissionOverwrite{RoleID: guildId, Deny: discord.PermissionViewChannel},
+						discord.RolePermissionOverwrite{RoleID: role.ID, Allow: discord.PermissionViewChannel | discord.PermissionSendMessages},
+					},
+				})
+
+				if err != nil {
+					(*Client).Rest().DeleteRole(guildId, role.ID)
+
+					return "", "", err
+				}
+
+				channelId, roleId = channel.ID().String(), role.ID.String()
+
+				return channelId, roleId, nil
+			})
+
+			if err != nil {
+				//Name or gold could change while channel was being created
+				removeGuildChannel(&guild.Guild{ChannelID: channelId, RoleID: roleId})
+
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			setGuildRole(playerChar.Meta.UserID, guildObj.RoleID, true)
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("Założono gildię %s, kanał: <#%s>", guildObj.Name, guildObj.ChannelID), false))
+		case "info":
+			guildObj := World.PlayerGuild(playerChar.GetUUID())
+
+			if guildObj == nil {
+				event.CreateMessage(MessageContent("Nie jesteś w gildii", true))
+				return
+			}
+
+			event.CreateMessage(MessageEmbed(GuildEmbed(guildObj)))
+		case "zaproś":
+			targetUser := interactionData.User("gracz")
+			target := World.GetPlayer(targetUser.ID.String())
+
+			if target == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			invite, err := World.InviteToGuild(playerChar.GetUUID(), target.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					SetContentf("<@%s>, %s zaprasza cię do gildii %s!\nZaproszenie wygasa <t:%d:R>", target.Meta.UserID, playerChar.GetName(), World.PlayerGuild(playerChar.GetUUID()).Name, invite.Expires.Unix()).
+					AddActionRow(
+						discord.NewSuccessButton("Dołącz", "guild/acc|"+invite.Uuid.String()),
+						discord.NewDangerButton("Odrzuć", "guild/dec|"+invite.Uuid.String()),
+					).
+					Build(),
+			)
+		case "opuść":
+			guildObj, err := World.LeaveGuild(playerChar.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			setGuildRole(playerChar.Meta.UserID, guildObj.RoleID, false)
+
+			if len(guildObj.Members) == 0 {
+				removeGuildChannel(guildObj)
+
+				event.CreateMessage(MessageContent(fmt.Sprintf("Gildia %s została rozwiązana", guildObj.Name), false))
+				return
+			}
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("Opuszczono gildię %s", guildObj.Name), false))
+		case "wyrzuć":
+			target := World.GetPlayer(interactionData.User("gracz").ID.String())
+
+			if target == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			guildObj, err := World.KickFromGuild(playerChar.GetUUID(), target.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			setGuildRole(target.Meta.UserID, guildObj.RoleID, false)
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("%s został wyrzucony z gildii %s", target.GetName(), guildObj.Name), false))
+		case "ranga":
+			target := World.GetPlayer(interactionData.User("gracz").ID.String())
+
+			if target == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			rank := guild.Rank(interactionData.Int("ranga"))
+
+			err := World.SetGuildRank(playerChar.GetUUID(), target.GetUUID(), rank)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("%s ma teraz rangę %s", target.GetName(), GuildRankToString[rank]), false))
+		case "uprawnienia":
+			rank := guild.Rank(interactionData.Int("ranga"))
+			permission := guild.Permission(interactionData.Int("uprawnienie"))
+			enabled := interactionData.Bool("włączone")
+
+			err := World.SetGuildPermission(playerChar.GetUUID(), rank, permission, enabled)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("%s: %s - %s", GuildRankToString[rank], GuildPermissionToString[permission], utils.BoolToText(enabled, "włączone", "wyłączone")), true))
+		case "wpłać", "wypłać":
+			deposit := *interactionData.SubCommandName == "wpłać"
+			messages := make([]string, 0)
+
+			if gold, exists := interactionData.OptInt("złoto"); exists {
+				if err := World.GuildBankGold(playerChar.GetUUID(), gold, deposit); err != nil {
+					event.CreateMessage(MessageContent(guildErrorText(err), true))
+					return
+				}
+
+				messages = append(messages, fmt.Sprintf("%d golda", gold))
+			}
+
+			if rawItem, exists := interactionData.OptString("przedmiot"); exists {
+				count, countExists := interactionData.OptInt("ilość")
+
+				if !countExists {
+					count = 1
+				}
+
+				var itemName string
+				var err error
+
+				if itemIdx, idxErr := strconv.Atoi(rawItem); deposit && idxErr == nil {
+					//Items are deposited by inventory index, materials by uuid
+					if itemIdx >= 0 && itemIdx < len(playerChar.Inventory.Items) {
+						itemName = ItemName(playerChar.Inventory.Items[itemIdx])
+					}
+
+					err = World.DepositGuildItemAt(playerChar.GetUUID(), itemIdx, count)
+				} else {
+					itemUuid, uuidErr := uuid.Parse(rawItem)
+
+					if uuidErr != nil {
+						event.CreateMessage(MessageContent("Nie znaleziono przedmiotu", true))
+						return
+					}
+
+					if deposit {
+						itemName = data.GetItemName(types.ITEM_MATERIAL, itemUuid)
+
+						err = World.DepositGuildItem(playerChar.GetUUID(), itemUuid, count)
+					} else {
+						//Bank entry is gone once everything is withdrawn
+						if guildObj := World.PlayerGuild(playerChar.GetUUID()); guildObj != nil {
+							if bankItem, exists := guildObj.Items[itemUuid]; exists {
+								itemName = BankItemName(bankItem)
+							}
+						}
+
+						err = World.WithdrawGuildItem(playerChar.GetUUID(), itemUuid, count)
+					}
+				}
+
+				if err != nil {
+					event.CreateMessage(MessageContent(guildErrorText(err), true))
+					return
+				}
+
+				messages = append(messages, fmt.Sprintf("%s x%d", itemName, count))
+			}
+
+			if len(messages) == 0 {
+				event.CreateMessage(MessageContent("Podaj złoto lub przedmiot", true))
+				return
+			}
+
+			event.CreateMessage(MessageContent(utils.BoolToText(deposit, "Wpłacono: ", "Wypłacono: ")+strings.Join(messages, ", "), true))
+		case "bank":
+			guildObj := World.PlayerGuild(playerChar.GetUUID())
+
+			if guildObj == nil {
+				event.CreateMessage(MessageContent("Nie jesteś w gildii", true))
+				return
+			}
+
+			event.CreateMessage(MessageEmbed(GuildBankEmbed(guildObj)))
+		}
+
+		return
+	case "bitwa":
+		switch *interactionData.SubCommandName {
+		case "wyzwij":
+			opponentUser := interactionData.User("gracz")
+			opponent := World.GetPlayer(opponentUser.ID.String())
+
+			if opponent == nil {
+				event.CreateMessage(MessageContent("Użytkownik nie ma postaci", true))
+				return
+			}
+
+			challenge, err := World.ChallengeTeam(playerChar.GetUUID(), opponent.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(teamBattleErrorText(err), true))
+				return
+			}
+
+			content := fmt.Sprintf("<@%s>, drużyna %s wyzywa twoją drużynę na bitwę!", opponent.Meta.UserID, playerChar.GetName())
+			content += "\nHP zostanie przywrócone po walce"
+			content += fmt.Sprintf("\nWyzwanie wygasa <t:%d:R>", challenge.Expires.Unix())
+
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					SetContent(content).
+					AddActionRow(
+						discord.NewSuccessButton("Przyjmij", "team/acc|"+challenge.Uuid.String()),
+						discord.NewDangerButton("Odrzuć", "team/dec|"+challenge.Uuid.String()),
+					).
+					Build(),
+			)
+		case "ranking":
+			event.CreateMessage(MessageEmbed(TeamRankingEmbed(World.TeamRanking(), World.TeamResults)))
+		}
+
+		return
+	case "loch":
+		switch *interactionData.SubCommandName {
+		case "wejdź":
+			currentLocation := World.Floors[playerChar.Meta.Location.Floor].FindLocation(playerChar.Meta.Location.Location)
+
+			_, err := World.EnterDungeon(playerChar.GetUUID(), interactionData.String("loch"), func(name string) (string, error) {
+				thread, err := (*Client).Rest().CreateThread(snowflake.MustParse(currentLocation.CID), discord.GuildPrivateThreadCreate{
+					Name: name,
+				})
+
+				if err != nil {
+					return "", err
+				}
+
+				return thread.ID().String(), nil
+			})
+
+			if err != nil {
+				event.CreateMessage(MessageContent(dungeonErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Drużyna wchodzi do lochu!", false))
+		case "dalej":
+			err := World.NextDungeonRoom(playerChar.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(dungeonErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Idziecie dalej...", true))
+		case "opuść":
+			err := World.AbandonDungeon(playerChar.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(dungeonErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Opuściłeś loch", true))
+		case "ranking":
+			event.CreateMessage(MessageEmbed(DungeonRankingEmbed(interactionData.String("loch"))))
+		}
+
+		return
+	case "questy":
+		switch *interactionData.SubCommandName {
+		case "dziennik":
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					AddEmbeds(QuestJournalEmbed(playerChar)).
+					SetEphemeral(true).
+					Build(),
+			)
+		case "dostępne":
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					AddEmbeds(AvailableQuestsEmbed(World.AvailableQuests(playerChar.GetUUID()))).
+					SetEphemeral(true).
+					Build(),
+			)
+		case "przyjmij", "oddaj", "porzuć":
+			questUuid, err := uuid.Parse(interactionData.String("zadanie"))
+
+			if err != nil {
+				event.CreateMessage(MessageContent("Nie ma takiego zadania", true))
+				return
+			}
+
+			questData := data.Quests[questUuid]
+
+			switch *interactionData.SubCommandName {
+			case "przyjmij":
+				err = World.AcceptQuest(playerChar.GetUUID(), questUuid)
+
+				if err != nil {
+					event.CreateMessage(MessageContent(questErrorText(err), true))
+					return
+				}
+
+				event.CreateMessage(MessageContent(fmt.Sprintf("Przyjęto zadanie %s", questData.Name), true))
+			case "oddaj":
+				_, err = World.CompleteQuest(playerChar.GetUUID(), questUuid)
+
+				if err != nil {
+					event.CreateMessage(MessageContent(questErrorText(err), true))
+					return
+				}
+
+				event.CreateMessage(
+					discord.NewMessageCreateBuilder().
+						AddEmbeds(
+							discord.NewEmbedBuilder().
+								SetTitle("Zadanie wykonane!").
+								SetDescriptionf("%s oddaje zadanie %s\n\nNagrody:\n%s", playerChar.GetName(), questData.Name, questRewardsText(questData)).
+								Build(),
+						).
+						Build(),
+				)
+			case "porzuć":
+				err = World.AbandonQuest(playerChar.GetUUID(), questUuid)
+
+				if err != nil {
+					event.CreateMessage(MessageContent(questErrorText(err), true))
+					return
+				}
+
+				event.CreateMessage(MessageContent(fmt.Sprintf("Porzucono zadanie %s", questData.Name), true))
+			}
+		}
+
+		return
+	case "zlecenia":
+		switch *interactionData.SubCommandName {
+		case "tablica":
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					AddEmbeds(BountyBoardEmbed(playerChar)).
+					SetEphemeral(true).
+					Build(),
+			)
+		case "przyjmij", "odbierz":
+			bountyUuid, err := uuid.Parse(interactionData.String("zlecenie"))
+
+			if err != nil {
+				event.CreateMessage(MessageContent("Tego zlecenia już nie ma na tablicy", true))
+				return
+			}
+
+			bountyData := World.GetBounty(bountyUuid)
+
+			if *interactionData.SubCommandName == "przyjmij" {
+				err = World.AcceptBounty(playerChar.GetUUID(), bountyUuid)
+
+				if err != nil {
+					event.CreateMessage(MessageContent(bountyErrorText(err), true))
+					return
+				}
+
+				event.CreateMessage(MessageContent("Przyjęto zlecenie: "+BountyText(bountyData), true))
+
+				return
+			}
+
+			exp, gold, err := World.ClaimBounty(playerChar.GetUUID(), bountyUuid)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(bountyErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(
+				MessageContent(
+					fmt.Sprintf("%s wykonuje zlecenie: %s\nNagroda: %d XP, %d golda (seria: %d dni)", playerChar.GetName(), BountyText(bountyData), exp, gold, playerChar.Meta.Bounties.Streak),
+					false,
+				),
+			)
+		}
+
+		return
+	case "staty":
+		switch *interactionData.SubCommandName {
+		case "pokaż":
+			event.CreateMessage(
+				discord.NewMessageCreateBuilder().
+					AddEmbeds(StatsEmbed(playerChar)).
+					AddContainerComponents(StatButtons(playerChar)...).
+					SetEphemeral(true).
+					Build(),
+			)
+		case "klasa":
+			err := playerChar.ChooseArchetype(interactionData.String("nazwa"))
+
+			if err != nil {
+				event.CreateMessage(MessageContent(statErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Wybrano klasę "+playerChar.Attributes.Archetype, false))
+		}
+
+		return
 	case "skill":
 		switch *interactionData.SubCommandName {
 		case "pokaż":
@@ -474,14 +1133,15 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 			unlockedUpgrades := playerChar.Inventory.LevelSkillsUpgrades[lvl]
 
-			availableUpgrades := make([]types.PlayerSkillUpgrade, 0)
+			//Upgrade index => upgrade, buttons need index in skill upgrades
+			availableUpgrades := make(map[int]types.PlayerSkillUpgrade)
 
 			for idx, upgrade := range upgrades {
 				if inventory.HasUpgrade(unlockedUpgrades, idx+1) {
 					continue
 				}
 
-				availableUpgrades = append(availableUpgrades, upgrade)
+				availableUpgrades[idx] = upgrade
 			}
 
 			if len(availableUpgrades) == 0 {
@@ -498,7 +1158,11 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			buttons := make([]discord.InteractiveComponent, 0)
 			embed := discord.NewEmbedBuilder()
 
-			for idx, upgrade := range availableUpgrades {
+			for idx, upgrade := range upgrades {
+				if _, available := availableUpgrades[idx]; !available {
+					continue
+				}
+
 				embed.AddField(
 					fmt.Sprintf("Ulepszenie %v", idx+1),
 					upgrade.Description,
@@ -518,7 +1182,39 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 					AddActionRow(buttons...).
 					Build(),
 			)
+		case "reset":
+			fromLvl := 1
+
+			if value, exists := interactionData.OptInt("od_poziomu"); exists {
+				fromLvl = value
+			}
+
+			cost, err := World.RespecCost(playerChar.GetUUID(), fromLvl)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(respecErrorText(err), true))
+				return
+			}
+
+			preview := playerChar.PreviewRespec(fromLvl)
+
+			scrollButton := discord.NewSecondaryButton("Użyj zwoju", fmt.Sprintf("respec/scroll|%d", fromLvl))
+
+			if scroll, exists := playerChar.Inventory.Ingredients[data.RespecScroll]; !exists || scroll.Count < 1 {
+				scrollButton = scrollButton.AsDisabled()
+			}
 
+			event.CreateMessage(
+				discord.
+					NewMessageCreateBuilder().
+					AddEmbeds(RespecPreviewEmbed(playerChar, preview, cost)).
+					AddActionRow(
+						discord.NewDangerButton(fmt.Sprintf("Zapłać %d golda", cost), fmt.Sprintf("respec/gold|%d", fromLvl)),
+						scrollButton,
+					).
+					SetEphemeral(true).
+					Build(),
+			)
 		}
 	case "plecak":
 		switch *interactionData.SubCommandName {
@@ -536,7 +1232,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 					}
 				}
 
-				embed.AddField("Przedmioty", fmt.Sprintf("%d/10", count), false)
+				embed.AddField("Przedmioty", fmt.Sprintf("%d/%d", count, inventory.MaxSlots), false)
 			}
 
 			for _, item := range playerChar.Inventory.Items {
@@ -544,9 +1240,16 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 					continue
 				}
 
-				embed.AddField(item.Name, item.Description, false)
+				itemName := ItemName(item)
+
+				if item.Equipped {
+					itemName += " [" + types.ItemSlotToString[item.Slot] + "]"
+				}
+
+				embed.AddField(itemName, ItemDescription(item), false)
 			}
 
+			embed.AddField("Wyposażenie", EquipmentText(playerChar), false)
 			embed.AddField("Złoto", fmt.Sprintf("%d", playerChar.Inventory.Gold), false)
 
 			event.CreateMessage(
@@ -557,6 +1260,78 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			)
 
 			return
+		case "załóż":
+			itemIdx, _ := strconv.Atoi(interactionData.String("przedmiot"))
+
+			replaced, err := playerChar.Equip(itemIdx)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(equipmentErrorText(err), true))
+				return
+			}
+
+			item := playerChar.Inventory.Items[itemIdx]
+			resultText := fmt.Sprintf("Założono %s (%s)", ItemName(item), types.ItemSlotToString[item.Slot])
+
+			for _, replacedItem := range replaced {
+				resultText += "\nZdjęto " + ItemName(replacedItem)
+			}
+
+			event.CreateMessage(MessageContent(resultText, true))
+		case "zdejmij":
+			itemIdx, _ := strconv.Atoi(interactionData.String("przedmiot"))
+
+			err := playerChar.Unequip(itemIdx)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(equipmentErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Zdjęto "+ItemName(playerChar.Inventory.Items[itemIdx]), true))
+		case "zapisz":
+			name := interactionData.String("nazwa")
+
+			err := playerChar.SaveLoadout(name)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(equipmentErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Zapisano zestaw "+name, true))
+		case "wczytaj":
+			name := interactionData.String("nazwa")
+
+			missing, err := playerChar.ApplyLoadout(name)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(equipmentErrorText(err), true))
+				return
+			}
+
+			resultText := "Wczytano zestaw " + name + "\n" + EquipmentText(playerChar)
+
+			if len(missing) > 0 {
+				resultText += "\nBrakujące przedmioty:"
+
+				for _, itemUuid := range missing {
+					resultText += "\n- " + data.GetItemName(types.ITEM_OTHER, itemUuid)
+				}
+			}
+
+			event.CreateMessage(MessageContent(resultText, true))
+		case "usuń":
+			name := interactionData.String("nazwa")
+
+			err := playerChar.DeleteLoadout(name)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(equipmentErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Usunięto zestaw "+name, true))
 		}
 	case "szukaj":
 		dChannel, error := (*Client).Rest().GetChannel(event.Channel().ID())
@@ -648,15 +1423,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				return
 			}
 
-			World.PlayerSearch(playerChar.GetUUID(), threadId, event)
-
-			event.CreateMessage(
-				discord.
-					NewMessageCreateBuilder().
-					SetContent("Szukanie...").
-					SetEphemeral(true).
-					Build(),
-			)
+			startSearch(event, playerChar, threadId, "Szukanie...")
 		} else {
 			pFloor := playerChar.Meta.Location.Floor
 
@@ -664,13 +1431,13 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				pFloor = dFloor.Name
 			}
 
-			err := World.MovePlayer(playerChar.GetUUID(), pFloor, newLocation.Name, "")
+			ticks, err := World.Travel(playerChar.GetUUID(), pFloor, newLocation.Name)
 
 			if err != nil {
 				event.CreateMessage(
 					discord.
 						NewMessageCreateBuilder().
-						SetContent("Nie możesz tam iść").
+						SetContent(travelErrorText(err)).
 						SetEphemeral(true).
 						Build(),
 				)
@@ -678,6 +1445,11 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				return
 			}
 
+			if ticks > 0 {
+				event.CreateMessage(MessageContent(fmt.Sprintf("Wyruszyłeś do %s, dotrzesz <t:%d:R>. Wtedy możesz zacząć szukać", newLocation.Name, time.Now().Add(time.Duration(ticks)*time.Minute).Unix()), true))
+				return
+			}
+
 			loc := World.Floors[playerChar.Meta.Location.Floor].FindLocation(playerChar.Meta.Location.Location)
 
 			if loc.CityPart {
@@ -686,25 +1458,17 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 						NewMessageCreateBuilder().
 						SetContent("Nie ma czego tu szukać...").
 						SetEphemeral(true).
-						Build(),
-				)
-				return
-			}
-
-			go World.PlayerSearch(playerChar.GetUUID(), threadId, event)
-
-			event.CreateMessage(
-				discord.
-					NewMessageCreateBuilder().
-					SetContent("Szukanie (automatycznie przeniosłam cię do lokacji)...").
-					SetEphemeral(true).
-					Build(),
-			)
+						Build(),
+				)
+				return
+			}
+
+			startSearch(event, playerChar, threadId, "Szukanie (automatycznie przeniosłam cię do lokacji)...")
 		}
 
 		return
 	case "party":
-		if playerChar.Meta.Party == nil && *interactionData.SubCommandName != "zapros" {
+		if playerChar.Meta.Party == nil && !slices.Contains([]string{"zapros", "ogłoś", "tablica"}, *interactionData.SubCommandName) {
 			event.CreateMessage(
 				discord.
 					NewMessageCreateBuilder().
@@ -736,6 +1500,8 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			partyLeader := World.Players[partyObj.Leader]
 
 			embed.AddField("Lider", fmt.Sprintf("<@%s> - %s\n", partyLeader.Meta.UserID, partyLeader.GetName()), false)
+			embed.AddField("Łupy", party.LootModeToString[partyObj.LootMode], false)
+			embed.AddField("Podążanie za liderem", utils.BoolToText(partyObj.AutoFollow, "Automatyczne", "Na prośbę"), false)
 
 			event.CreateMessage(
 				discord.
@@ -758,7 +1524,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				})
 			}
 
-			if len(World.Parties[playerChar.Meta.Party.UUID].Players) >= 6 {
+			if len(World.Parties[playerChar.Meta.Party.UUID].Players) >= party.MaxMembers {
 				event.CreateMessage(
 					discord.
 						NewMessageCreateBuilder().
@@ -784,19 +1550,22 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 			mentionedUser := interactionData.User("gracz")
 
-			for _, pl := range World.Players {
-				if pl.Meta.UserID == mentionedUser.ID.String() {
-					if pl.Meta.Party != nil {
-						event.CreateMessage(
-							discord.
-								NewMessageCreateBuilder().
-								SetContent("Gracz jest już w party").
-								SetEphemeral(true).
-								Build(),
-						)
-						return
-					}
-				}
+			invitedPlayer := World.GetPlayer(mentionedUser.ID.String())
+
+			if invitedPlayer == nil {
+				event.CreateMessage(MessageContent("Gracz nie ma postaci", true))
+				return
+			}
+
+			if invitedPlayer.Meta.Party != nil {
+				event.CreateMessage(
+					discord.
+						NewMessageCreateBuilder().
+						SetContent("Gracz jest już w party").
+						SetEphemeral(true).
+						Build(),
+				)
+				return
 			}
 
 			ch, error := event.Client().Rest().CreateDMChannel(mentionedUser.ID)
@@ -813,11 +1582,13 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 
 			chID := ch.ID()
 
+			invite := World.CreatePartyInvite(playerChar.Meta.Party.UUID, invitedPlayer.GetUUID())
+
 			_, error = event.Client().Rest().CreateMessage(chID, discord.NewMessageCreateBuilder().
-				SetContent(fmt.Sprintf("<@%s> (%s) zaprasza cię do party", user.ID.String(), playerChar.GetName())).
+				SetContent(fmt.Sprintf("<@%s> (%s) zaprasza cię do party. Zaproszenie wygasa <t:%d:R>", user.ID.String(), playerChar.GetName(), invite.Expires.Unix())).
 				AddActionRow(
-					discord.NewPrimaryButton("Akceptuj", "party/res|"+(playerChar.Meta.Party.UUID).String()),
-					discord.NewDangerButton("Odrzuć", "party/rej|"+(playerChar.Meta.Party.UUID).String()),
+					discord.NewPrimaryButton("Akceptuj", "party/res|"+invite.Uuid.String()),
+					discord.NewDangerButton("Odrzuć", "party/rej|"+invite.Uuid.String()),
 				).
 				Build(),
 			)
@@ -1006,6 +1777,8 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 							case "Tank":
 								World.Parties[playerChar.Meta.Party.UUID].Players[i].Role = party.Tank
 							}
+
+							pl.Meta.Party.Role = World.Parties[playerChar.Meta.Party.UUID].Players[i].Role
 							break
 						}
 					}
@@ -1039,6 +1812,8 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				World.Players[partyMember.PlayerUuid].Meta.Party = nil
 			}
 
+			World.RemovePartyListing(uuid)
+
 			delete(World.Parties, uuid)
 
 			event.CreateMessage(
@@ -1049,6 +1824,81 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 					Build(),
 			)
 
+			return
+		case "ogłoś":
+			roles := make([]party.PartyRole, 0)
+
+			for _, role := range []party.PartyRole{party.DPS, party.Support, party.Tank} {
+				if interactionData.Bool(strings.ToLower(RoleToString[role])) {
+					roles = append(roles, role)
+				}
+			}
+
+			if len(roles) == 0 {
+				roles = []party.PartyRole{party.DPS, party.Support, party.Tank}
+			}
+
+			listing, err := World.CreatePartyListing(playerChar.GetUUID(), interactionData.String("lokacja"), roles, interactionData.Int("poziom"))
+
+			if err != nil {
+				switch err.Error() {
+				case "NOT_LEADER":
+					event.CreateMessage(MessageContent("Nie jesteś liderem", true))
+				case "PARTY_FULL":
+					event.CreateMessage(MessageContent("Party jest pełne", true))
+				case "LOCATION_NOT_FOUND":
+					event.CreateMessage(MessageContent("Nie znaleziono lokacji", true))
+				}
+
+				return
+			}
+
+			event.CreateMessage(PartyListingsMessage([]*party.Listing{listing}))
+
+			return
+		case "tablica":
+			event.CreateMessage(PartyListingsMessage(World.ActivePartyListings()))
+
+			return
+		case "zamknij":
+			part := World.Parties[playerChar.Meta.Party.UUID]
+
+			if playerChar.GetUUID() != part.Leader {
+				event.CreateMessage(MessageContent("Nie jesteś liderem", true))
+				return
+			}
+
+			World.RemovePartyListing(playerChar.Meta.Party.UUID)
+
+			event.CreateMessage(MessageContent("Usunięto ogłoszenie", true))
+
+			return
+		case "podążanie":
+			part := World.Parties[playerChar.Meta.Party.UUID]
+
+			if playerChar.GetUUID() != part.Leader {
+				event.CreateMessage(MessageContent("Nie jesteś liderem", true))
+				return
+			}
+
+			part.AutoFollow = interactionData.Bool("automatycznie")
+
+			event.CreateMessage(MessageContent("Automatyczne podążanie: "+utils.BoolToText(part.AutoFollow, "Tak", "Nie"), false))
+
+			return
+		case "łup":
+			part := World.Parties[playerChar.Meta.Party.UUID]
+
+			if playerChar.GetUUID() != part.Leader {
+				event.CreateMessage(MessageContent("Nie jesteś liderem", true))
+				return
+			}
+
+			part.LootMode = party.LootMode(interactionData.Int("tryb"))
+			part.RoundRobin = 0
+
+			event.CreateMessage(MessageContent("Zmieniono tryb łupów na: "+party.LootModeToString[part.LootMode], false))
+
 			return
 		}
 	case "ratuj":
@@ -1128,26 +1978,80 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			return
 		}
 
-		err := playerChar.Inventory.Craft(recipe)
+		times, isTimesPresent := interactionData.OptInt("ilość")
+
+		if !isTimesPresent || times < 1 {
+			times = 1
+		}
+
+		results := make([]player.CraftResult, 0)
+		var craftErr error
+
+		for i := 0; i < times; i++ {
+			result, err := World.CraftRecipe(playerChar.GetUUID(), recipe.UUID)
+
+			if err != nil {
+				craftErr = err
+				break
+			}
+
+			results = append(results, result)
+		}
+
+		if len(results) == 0 {
+			switch craftErr.Error() {
+			case "UNKNOWN_RECIPE":
+				event.CreateMessage(MessageContent("Nie znasz tego przepisu", true))
+			case "IN_FIGHT":
+				event.CreateMessage(MessageContent("Nie możesz tworzyć przedmiotów podczas walki", true))
+			case "WRONG_STATION":
+				event.CreateMessage(MessageContent(fmt.Sprintf("Ten przedmiot można stworzyć tylko w lokacji %s (%s)", recipe.Station.Location, recipe.Station.Floor), true))
+			case "LEVEL_TOO_LOW":
+				event.CreateMessage(MessageContent(fmt.Sprintf("Wymagany poziom rzemiosła: %d", recipe.Level), true))
+			case "MISSING_INGREDIENT":
+				event.CreateMessage(MessageContent("Brakuje składników", true))
+			case "NOT_ENOUGH_GOLD":
+				event.CreateMessage(MessageContent(fmt.Sprintf("Za mało pieniędzy, koszt: %d", recipe.Cost), true))
+			case "INVENTORY_FULL":
+				event.CreateMessage(MessageContent("Nie masz miejsca w ekwipunku", true))
+			default:
+				event.CreateMessage(MessageContent("Nie znaleziono receptury? (XD)", true))
+			}
 
-		if err.Error() == "MISSING_INGREDIENT" {
-			event.CreateMessage(
-				discord.
-					NewMessageCreateBuilder().
-					SetContent("Brakuje składników").
-					SetEphemeral(true).
-					Build(),
-			)
 			return
 		}
 
-		event.CreateMessage(
-			discord.
-				NewMessageCreateBuilder().
-				SetContentf("Stworzono przedmiot\n%s x%d", recipe.Name, recipe.Product.Count).
-				SetEphemeral(true).
-				Build(),
-		)
+		resultText := ""
+		levelUp := false
+
+		for _, result := range results {
+			levelUp = levelUp || result.LevelUp
+
+			if !result.Success {
+				resultText += fmt.Sprintf("- Porażka, składniki przepadły (+%d XP)\n", result.Exp)
+				continue
+			}
+
+			if recipe.Product.Type == types.ITEM_MATERIAL {
+				resultText += fmt.Sprintf("- %s x%d (+%d XP)\n", recipe.Name, recipe.Product.Count, result.Exp)
+			} else {
+				resultText += fmt.Sprintf("- %s x%d, jakość: %s (+%d XP)\n", recipe.Name, recipe.Product.Count, types.QualityToString[result.Quality], result.Exp)
+			}
+		}
+
+		if craftErr != nil {
+			resultText += fmt.Sprintf("Przerwano po %d próbach\n", len(results))
+		}
+
+		if levelUp {
+			resultText += fmt.Sprintf("Poziom rzemiosła wzrósł do %d!", playerChar.Meta.Crafting.Level)
+		}
+
+		event.CreateMessage(MessageContent("Wynik tworzenia:\n"+resultText, true))
+	case "przepisy":
+		discovered := World.DiscoverRecipes(playerChar.GetUUID())
+
+		event.CreateMessage(discord.NewMessageCreateBuilder().AddEmbeds(RecipesEmbed(playerChar, discovered)).SetEphemeral(true).Build())
 	case "furia":
 		switch *interactionData.SubCommandName {
 		case "pokaż":
@@ -1169,6 +2073,7 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			embed := discord.NewEmbedBuilder()
 
 			embed.AddField("Nazwa", fury.Name, true)
+			embed.SetDescription(fury.Description)
 
 			levelTxt := fmt.Sprint(fury.XP.LVL)
 
@@ -1179,7 +2084,18 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			}
 
 			embed.AddField("Poziom", levelTxt, true)
-			embed.AddField("Umiejętności", "Brak", false)
+
+			skillsText := ""
+
+			for _, skill := range fury.GetSkills() {
+				skillsText += fmt.Sprintf("- %s: %s\n", skill.GetName(), skill.GetDescription())
+			}
+
+			if skillsText == "" {
+				skillsText = "Brak"
+			}
+
+			embed.AddField("Umiejętności", skillsText, false)
 
 			statsText := ""
 
@@ -1235,11 +2151,22 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				}
 
 				embed.AddField("Kolejny tier?", nextTierText, false)
-
-				message.AddEmbeds(embed.Build())
 			}
 
+			message.AddEmbeds(embed.Build())
+
 			event.CreateMessage(message.Build())
+		case "przebudź":
+			itemIdx, _ := strconv.Atoi(interactionData.String("przedmiot"))
+
+			awakened, err := World.AwakenFury(playerChar.GetUUID(), itemIdx)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(furyErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("Przebudzono furię: %s!", awakened.Name), true))
 		case "ulepsz":
 			if playerChar.Meta.Fury == nil {
 				event.CreateMessage(
@@ -1377,6 +2304,227 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 			}
 
 			event.CreateMessage(messageBuilder.Build())
+		case "napraw":
+			itemIdx, _ := strconv.Atoi(interactionData.String("przedmiot"))
+
+			cost, err := World.RepairItem(playerChar.GetUUID(), itemIdx)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(blacksmithErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("Naprawiono %s za %d golda", ItemName(playerChar.Inventory.Items[itemIdx]), cost), true))
+		case "ulepsz":
+			itemIdx, _ := strconv.Atoi(interactionData.String("przedmiot"))
+
+			success, err := World.EnhanceItem(playerChar.GetUUID(), itemIdx)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(blacksmithErrorText(err), true))
+				return
+			}
+
+			item := playerChar.Inventory.Items[itemIdx]
+
+			if !success {
+				event.CreateMessage(MessageContent(fmt.Sprintf("Ulepszenie %s nie powiodło się, materiały przepadły", ItemName(item)), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("Ulepszono przedmiot: %s", ItemName(item)), true))
+		case "historia":
+			event.CreateMessage(discord.NewMessageCreateBuilder().AddEmbeds(PurchaseHistoryEmbed(playerChar.Meta.Purchases)).SetEphemeral(true).Build())
+		case "sprzedaj":
+			amount, isAmountPresent := interactionData.OptInt("ilość")
+
+			if !isAmountPresent {
+				amount = 1
+			}
+
+			var gold int
+			var itemName string
+			var err error
+
+			//Items come as inventory index, materials as uuid
+			if itemIdx, idxErr := strconv.Atoi(interactionData.String("przedmiot")); idxErr == nil {
+				if itemIdx >= 0 && itemIdx < len(playerChar.Inventory.Items) {
+					itemName = ItemName(playerChar.Inventory.Items[itemIdx])
+				}
+
+				gold, err = World.SellItemToStore(playerChar.GetUUID(), itemIdx, amount)
+			} else {
+				itemUuid, uuidErr := uuid.Parse(interactionData.String("przedmiot"))
+
+				if uuidErr != nil {
+					event.CreateMessage(MessageContent("Nie znaleziono przedmiotu", true))
+					return
+				}
+
+				itemName = data.GetItemName(types.ITEM_MATERIAL, itemUuid)
+
+				gold, err = World.SellToStore(playerChar.GetUUID(), itemUuid, amount)
+			}
+
+			if err != nil {
+				switch err.Error() {
+				case "INVALID_AMOUNT":
+					event.CreateMessage(MessageContent("Nieprawidłowa ilość", true))
+				case "IN_FIGHT":
+					event.CreateMessage(MessageContent("Nie możesz handlować podczas walki", true))
+				case "ITEM_NOT_FOUND":
+					event.CreateMessage(MessageContent("Nie znaleziono przedmiotu", true))
+				case "ITEM_EQUIPPED":
+					event.CreateMessage(MessageContent("Zdejmij przedmiot zanim go sprzedasz", true))
+				case "NOT_BOUGHT":
+					event.CreateMessage(MessageContent("Żaden sklep w tej lokalizacji nie skupuje tego przedmiotu", true))
+				case "NOT_ENOUGH_ITEMS":
+					event.CreateMessage(MessageContent("Nie masz tylu przedmiotów", true))
+				default:
+					event.CreateMessage(MessageContent("Coś poszło nie tak", true))
+				}
+
+				return
+			}
+
+			event.CreateMessage(MessageContent(fmt.Sprintf("Sprzedano %dx %s za %d golda", amount, itemName, gold), false))
+		}
+	case "aukcje":
+		switch *interactionData.SubCommandName {
+		case "wystaw":
+			var auctionObj *auction.Auction
+			var err error
+
+			//Items come as inventory index, materials as uuid
+			if itemIdx, idxErr := strconv.Atoi(interactionData.String("przedmiot")); idxErr == nil {
+				auctionObj, err = World.CreateItemAuction(
+					playerChar.GetUUID(),
+					itemIdx,
+					interactionData.Int("ilość"),
+					interactionData.Int("cena"),
+					interactionData.Int("wykup"),
+					interactionData.Int("dni"),
+				)
+			} else {
+				itemUuid, uuidErr := uuid.Parse(interactionData.String("przedmiot"))
+
+				if uuidErr != nil {
+					event.CreateMessage(MessageContent("Nie znaleziono przedmiotu", true))
+					return
+				}
+
+				auctionObj, err = World.CreateAuction(
+					playerChar.GetUUID(),
+					itemUuid,
+					interactionData.Int("ilość"),
+					interactionData.Int("cena"),
+					interactionData.Int("wykup"),
+					interactionData.Int("dni"),
+				)
+			}
+
+			if err != nil {
+				switch err.Error() {
+				case "ITEM_NOT_FOUND":
+					event.CreateMessage(MessageContent("Nie znaleziono przedmiotu", true))
+				case "ITEM_EQUIPPED":
+					event.CreateMessage(MessageContent("Zdejmij przedmiot zanim go wystawisz", true))
+				case "NOT_IN_CITY":
+					event.CreateMessage(MessageContent("Dom aukcyjny jest tylko w mieście", true))
+				case "IN_FIGHT":
+					event.CreateMessage(MessageContent("Nie możesz handlować podczas walki", true))
+				case "INVALID_AMOUNT":
+					event.CreateMessage(MessageContent("Nieprawidłowa ilość", true))
+				case "INVALID_PRICE":
+					event.CreateMessage(MessageContent("Nieprawidłowa cena, wykup nie może być niższy od ceny wywoławczej", true))
+				case "INVALID_DURATION":
+					event.CreateMessage(MessageContent(fmt.Sprintf("Aukcja może trwać od 1 do %d dni", auction.MaxDuration), true))
+				case "NOT_ENOUGH_ITEMS":
+					event.CreateMessage(MessageContent("Nie masz tylu przedmiotów", true))
+				case "NOT_ENOUGH_GOLD":
+					event.CreateMessage(MessageContent(fmt.Sprintf("Nie stać cię na opłatę (%d golda)", auction.ListingFee(interactionData.Int("cena"))), true))
+				default:
+					event.CreateMessage(MessageContent("Coś poszło nie tak", true))
+				}
+
+				return
+			}
+
+			event.CreateMessage(MessageContent(
+				fmt.Sprintf("Wystawiono %s, opłata: %d golda. Koniec aukcji: %s", AuctionName(auctionObj), auction.ListingFee(auctionObj.StartPrice), calendar.DateFromDayNumber(auctionObj.Ends)),
+				true,
+			))
+		case "przeglądaj":
+			name := strings.ToLower(interactionData.String("nazwa"))
+			itemType, filterType := interactionData.OptInt("typ")
+			maxPrice, filterPrice := interactionData.OptInt("maks_cena")
+			page, pagePresent := interactionData.OptInt("strona")
+
+			if !pagePresent {
+				page = 1
+			}
+
+			auctions := make([]*auction.Auction, 0)
+
+			for _, auctionObj := range World.Auctions {
+				if filterType && auctionObj.ItemType != types.ItemType(itemType) {
+					continue
+				}
+
+				if filterPrice && auctionObj.MinBid() > maxPrice && (auctionObj.Buyout == 0 || auctionObj.Buyout > maxPrice) {
+					continue
+				}
+
+				if !strings.Contains(strings.ToLower(data.GetItemName(auctionObj.ItemType, auctionObj.Item)), name) {
+					continue
+				}
+
+				auctions = append(auctions, auctionObj)
+			}
+
+			event.CreateMessage(AuctionsMessage(auctions, page))
+		case "moje":
+			ownText := ""
+			bidText := ""
+			cancelButtons := make([]discord.InteractiveComponent, 0)
+
+			for _, auctionObj := range World.Auctions {
+				if auctionObj.Seller == playerChar.GetUUID() {
+					ownText += fmt.Sprintf("**%s**\n%s\n", AuctionName(auctionObj), AuctionText(auctionObj))
+
+					if !auctionObj.HasBids() && len(cancelButtons) < 5 {
+						cancelButtons = append(cancelButtons, discord.NewDangerButton("Wycofaj: "+AuctionName(auctionObj), "ah/cancel|"+auctionObj.Uuid.String()))
+					}
+				}
+
+				if auctionObj.Bidder == playerChar.GetUUID() {
+					bidText += fmt.Sprintf("**%s**\n%s\n", AuctionName(auctionObj), AuctionText(auctionObj))
+				}
+			}
+
+			if ownText == "" {
+				ownText = "Brak"
+			}
+
+			if bidText == "" {
+				bidText = "Brak"
+			}
+
+			message := discord.NewMessageCreateBuilder().
+				AddEmbeds(
+					discord.NewEmbedBuilder().
+						SetTitle("Twoje aukcje").
+						AddField("Wystawione", ownText, false).
+						AddField("Prowadzisz licytację", bidText, false).
+						Build(),
+				).
+				SetEphemeral(true)
+
+			if len(cancelButtons) > 0 {
+				message.AddActionRow(cancelButtons...)
+			}
+
+			event.CreateMessage(message.Build())
 		}
 	case "turniej":
 		switch *interactionData.SubCommandName {
@@ -1546,5 +2694,89 @@ func commandListener(event *events.ApplicationCommandInteractionCreate) {
 				)
 			}
 		}
+	case "bestiariusz":
+		mob, exists := mobs.Mobs[interactionData.String("nazwa")]
+
+		if !exists {
+			event.CreateMessage(MessageContent("Nie znaleziono przeciwnika", true))
+			return
+		}
+
+		event.CreateMessage(MessageEmbed(MobEmbed(&mob)))
+	}
+}
+
+// Party has to stand in one place and pass ready check before search
+func startSearch(event *events.ApplicationCommandInteractionCreate, playerChar *player.Player, threadId string, searchText string) {
+	elsewhere := World.PartyMembersElsewhere(playerChar.GetUUID())
+
+	if len(elsewhere) > 0 {
+		elsewhereText := ""
+
+		for _, member := range elsewhere {
+			elsewhereText += fmt.Sprintf("- %s (%s - %s)\n", member.GetName(), member.Meta.Location.Floor, member.Meta.Location.Location)
+		}
+
+		event.CreateMessage(MessageContent("Nie wszyscy członkowie party są w tej lokacji:\n"+elsewhereText, true))
+		return
+	}
+
+	if playerChar.Meta.Party != nil && len(World.Parties[playerChar.Meta.Party.UUID].Players) > 1 {
+		check := World.StartReadyCheck(playerChar.GetUUID(), threadId)
+
+		mentionText := ""
+
+		for _, member := range check.Members {
+			mentionText += fmt.Sprintf("<@%s> ", World.Players[member].Meta.UserID)
+		}
+
+		event.CreateMessage(
+			discord.NewMessageCreateBuilder().
+				SetContentf("%s\n%s chce rozpocząć walkę, czy jesteście gotowi? Sprawdzanie wygasa <t:%d:R>", mentionText, playerChar.GetName(), check.Expires.Unix()).
+				AddActionRow(
+					discord.NewSuccessButton("Gotowy", "rc/ok|"+check.Uuid.String()),
+					discord.NewDangerButton("Nie gotowy", "rc/no|"+check.Uuid.String()),
+				).
+				Build(),
+		)
+
+		return
+	}
+
+	replied := false
+
+	World.PlayerSearch(playerChar.GetUUID(), threadId, func(message discord.MessageCreate) {
+		replied = true
+
+		event.CreateMessage(message)
+	})
+
+	if !replied {
+		event.CreateMessage(MessageContent(searchText, true))
+	}
+}
+
+// Discord errors are ignored, guild works without its channel too
+func setGuildRole(userId string, roleId string, add bool) {
+	if roleId == "" {
+		return
+	}
+
+	guildId := snowflake.MustParse(config.Config.GuildID)
+
+	if add {
+		(*Client).Rest().AddMemberRole(guildId, snowflake.MustParse(userId), snowflake.MustParse(roleId))
+	} else {
+		(*Client).Rest().RemoveMemberRole(guildId, snowflake.MustParse(userId), snowflake.MustParse(roleId))
+	}
+}
+
+func removeGuildChannel(guildObj *guild.Guild) {
+	if guildObj.ChannelID != "" {
+		(*Client).Rest().DeleteChannel(snowflake.MustParse(guildObj.ChannelID))
+	}
+
+	if guildObj.RoleID != "" {
+		(*Client).Rest().DeleteRole(snowflake.MustParse(config.Config.GuildID), snowflake.MustParse(guildObj.RoleID))
 	}
 }
@@ -5,10 +5,12 @@ import (
 	"sao/data"
 	"sao/player"
 	"sao/types"
+	"sao/world/calendar"
 	"sao/world/party"
 	"sao/world/transaction"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/disgoorg/disgo/discord"
 	"github.com/disgoorg/disgo/events"
@@ -16,6 +18,11 @@ import (
 )
 
 func ModalSubmitHandler(event *events.ModalSubmitInteractionCreate) {
+	if event.Data.CustomID == "ah/bid" {
+		auctionBidSubmit(event)
+		return
+	}
+
 	if event.Data.CustomID != "shop/buy" {
 		return
 	}
@@ -27,16 +34,21 @@ func ModalSubmitHandler(event *events.ModalSubmitInteractionCreate) {
 	}
 
 	segments := strings.Split(componentCustomId, "/")
-	store := World.Stores[uuid.MustParse(segments[2])]
+	storeUuid, err := uuid.Parse(segments[2])
+
+	if err != nil {
+		event.CreateMessage(MessageContent("Nie znaleziono sklepu", true))
+		return
+	}
+
 	itemIdx, _ := strconv.Atoi(segments[3])
 
 	stringInput, _ := event.Data.TextInputComponent(componentCustomId)
 
-	amount, _ := strconv.Atoi(stringInput.Value)
-	stockItem := store.Stock[itemIdx]
+	amount, err := strconv.Atoi(strings.TrimSpace(stringInput.Value))
 
-	if amount <= 0 {
-		event.CreateMessage(discord.NewMessageCreateBuilder().SetContent("Nieprawidłowa ilość").Build())
+	if err != nil || amount <= 0 {
+		event.CreateMessage(MessageContent("Nieprawidłowa ilość", true))
 		return
 	}
 
@@ -47,30 +59,32 @@ func ModalSubmitHandler(event *events.ModalSubmitInteractionCreate) {
 		return
 	}
 
-	if player.Inventory.Gold < stockItem.Price*amount {
-		event.CreateMessage(discord.NewMessageCreateBuilder().SetContent("Za mało pieniędzy").Build())
-		return
-	}
-
-	player.Inventory.Gold -= stockItem.Price * amount
-
-	for i := 0; i < amount; i++ {
-		if stockItem.ItemType == types.ITEM_MATERIAL {
-			item := data.Ingredients[stockItem.ItemUUID]
-
-			item.Count = amount
-
-			player.Inventory.AddIngredient(&item)
-		} else {
-			item := data.Items[stockItem.ItemUUID]
-
-			item.Count = amount
-
-			player.Inventory.AddItem(&item)
+	purchase, err := World.BuyFromStore(player.GetUUID(), storeUuid, itemIdx, amount)
+
+	if err != nil {
+		switch err.Error() {
+		case "STORE_NOT_FOUND":
+			event.CreateMessage(MessageContent("Nie znaleziono sklepu", true))
+		case "ITEM_NOT_FOUND":
+			event.CreateMessage(MessageContent("Nie znaleziono przedmiotu", true))
+		case "WRONG_LOCATION":
+			event.CreateMessage(MessageContent("Nie jesteś już w lokacji sklepu", true))
+		case "IN_FIGHT":
+			event.CreateMessage(MessageContent("Nie możesz handlować podczas walki", true))
+		case "OUT_OF_STOCK":
+			event.CreateMessage(MessageContent(fmt.Sprintf("Sklep ma tylko %d sztuk", World.Stores[storeUuid].Stock[itemIdx].Quantity), true))
+		case "NOT_ENOUGH_GOLD":
+			event.CreateMessage(MessageContent("Za mało pieniędzy", true))
+		case "INVENTORY_FULL":
+			event.CreateMessage(MessageContent("Nie masz miejsca w ekwipunku", true))
+		default:
+			event.CreateMessage(MessageContent("Coś poszło nie tak", true))
 		}
+
+		return
 	}
 
-	event.CreateMessage(discord.NewMessageCreateBuilder().SetContent("Zakupiono").Build())
+	event.CreateMessage(MessageEmbed(ReceiptEmbed(purchase, player.Inventory.Gold)))
 }
 
 func ComponentHandler(event *events.ComponentInteractionCreate) {
@@ -333,55 +347,207 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 		}
 	}
 
-	if strings.HasPrefix(customId, "party") {
-		if strings.HasPrefix(customId, "party/res") {
-			userSnowflake := event.User().ID.String()
+	if strings.HasPrefix(customId, "duel/") {
+		segments := strings.Split(customId, "|")
 
-			partyUuid := uuid.MustParse(strings.Split(customId, "|")[1])
+		playerChar := World.GetPlayer(event.User().ID.String())
 
-			for _, pl := range World.Players {
-				if pl.Meta.UserID == userSnowflake {
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
 
-					if pl.Meta.Party != nil {
-						event.CreateMessage(
-							discord.
-								NewMessageCreateBuilder().
-								SetContent("Jesteś już w party").
-								SetEphemeral(true).
-								Build(),
-						)
-						return
-					}
+		challengeUuid := uuid.MustParse(segments[1])
 
-					World.Parties[partyUuid].Players = append(World.Parties[partyUuid].Players, &party.PartyEntry{
-						PlayerUuid: pl.GetUUID(),
-						Role:       party.None,
-					})
+		switch segments[0] {
+		case "duel/acc":
+			err := World.AcceptDuel(challengeUuid, playerChar.GetUUID())
 
-					for _, player := range World.Parties[partyUuid].Players {
-						World.Players[player.PlayerUuid].Meta.Party.MembersCount = len(World.Parties[partyUuid].Players)
-					}
+			if err != nil {
+				event.CreateMessage(MessageContent(duelErrorText(err), true))
+				return
+			}
 
-					pl.Meta.Party = &player.PartialParty{
-						UUID:         partyUuid,
-						Role:         party.None,
-						MembersCount: len(World.Parties[partyUuid].Players),
-					}
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Wyzwanie przyjęte, pojedynek się zaczyna!").Build())
+		case "duel/dec":
+			_, err := World.DeclineDuel(challengeUuid, playerChar.GetUUID())
 
-					event.CreateMessage(
-						discord.
-							NewMessageCreateBuilder().
-							SetContent("Dołączono do party").
-							SetEphemeral(true).
-							Build(),
-					)
+			if err != nil {
+				event.CreateMessage(MessageContent(duelErrorText(err), true))
+				return
+			}
 
-					return
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContentf("%s odwołuje pojedynek", playerChar.GetName()).Build())
+		}
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "guild/") {
+		segments := strings.Split(customId, "|")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		inviteUuid := uuid.MustParse(segments[1])
+
+		switch segments[0] {
+		case "guild/acc":
+			guildObj, err := World.AcceptGuildInvite(inviteUuid, playerChar.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			setGuildRole(playerChar.Meta.UserID, guildObj.RoleID, true)
+
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContentf("%s dołącza do gildii %s!", playerChar.GetName(), guildObj.Name).Build())
+		case "guild/dec":
+			_, err := World.DeclineGuildInvite(inviteUuid, playerChar.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(guildErrorText(err), true))
+				return
+			}
+
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContentf("%s odrzuca zaproszenie do gildii", playerChar.GetName()).Build())
+		}
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "team/") {
+		segments := strings.Split(customId, "|")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		challengeUuid := uuid.MustParse(segments[1])
+
+		switch segments[0] {
+		case "team/acc":
+			err := World.AcceptTeamBattle(challengeUuid, playerChar.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(teamBattleErrorText(err), true))
+				return
+			}
+
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Wyzwanie przyjęte, bitwa drużyn się zaczyna!").Build())
+		case "team/dec":
+			_, err := World.DeclineTeamBattle(challengeUuid, playerChar.GetUUID())
+
+			if err != nil {
+				event.CreateMessage(MessageContent(teamBattleErrorText(err), true))
+				return
+			}
+
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContentf("%s odwołuje bitwę drużyn", playerChar.GetName()).Build())
+		}
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "party") {
+		segments := strings.Split(customId, "|")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		if segments[0] == "party/follow" {
+			ticks, err := World.FollowLeader(playerChar.GetUUID())
+
+			if err != nil {
+				switch err.Error() {
+				case "NOT_IN_PARTY":
+					event.CreateMessage(MessageContent("Nie możesz podążyć za liderem", true))
+				default:
+					event.CreateMessage(MessageContent(travelErrorText(err), true))
 				}
+
+				return
+			}
+
+			if ticks == 0 {
+				event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContentf("Podążasz za liderem do %s", playerChar.Meta.Location.Location).Build())
+			} else {
+				event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContentf("Podążasz za liderem do %s, dotrzesz <t:%d:R>", playerChar.Meta.Travel.Destination.Location, time.Now().Add(time.Duration(ticks)*time.Minute).Unix()).Build())
 			}
+
+			return
 		}
 
-		if customId == "party/rej" {
+		inviteUuid := uuid.MustParse(segments[1])
+
+		switch segments[0] {
+		case "party/res", "party/acc":
+			invite, err := World.AcceptPartyInvite(inviteUuid, playerChar.GetUUID())
+
+			if err != nil {
+				switch err.Error() {
+				case "INVITE_NOT_FOUND", "PARTY_NOT_FOUND":
+					event.CreateMessage(MessageContent("Zaproszenie nie istnieje", true))
+				case "INVITE_EXPIRED":
+					event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Zaproszenie wygasło").Build())
+				case "NOT_ALLOWED":
+					event.CreateMessage(MessageContent("To nie twoje zaproszenie", true))
+				case "ALREADY_IN_PARTY":
+					event.CreateMessage(MessageContent("Gracz jest już w party", true))
+				case "PARTY_FULL":
+					event.CreateMessage(MessageContent("Party jest pełne", true))
+				}
+
+				return
+			}
+
+			if invite.Application {
+				World.BufferChannel <- types.DiscordMessageStruct{
+					ChannelID:      World.Players[invite.Player].Meta.UserID,
+					MessageContent: MessageContent(fmt.Sprintf("Zostałeś przyjęty do party gracza %s", playerChar.GetName()), false),
+					DM:             true,
+				}
+
+				event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Przyjęto do party").Build())
+			} else {
+				event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Dołączono do party").Build())
+			}
+
+			return
+		case "party/rej", "party/den":
+			invite, err := World.RejectPartyInvite(inviteUuid, playerChar.GetUUID())
+
+			if err != nil {
+				switch err.Error() {
+				case "INVITE_NOT_FOUND":
+					event.CreateMessage(MessageContent("Zaproszenie nie istnieje", true))
+				case "NOT_ALLOWED":
+					event.CreateMessage(MessageContent("To nie twoje zaproszenie", true))
+				}
+
+				return
+			}
+
+			if invite.Application {
+				World.BufferChannel <- types.DiscordMessageStruct{
+					ChannelID:      World.Players[invite.Player].Meta.UserID,
+					MessageContent: MessageContent(fmt.Sprintf("Gracz %s odrzucił twoje zgłoszenie do party", playerChar.GetName()), false),
+					DM:             true,
+				}
+			}
+
 			event.UpdateMessage(
 				discord.
 					NewMessageUpdateBuilder().
@@ -389,7 +555,310 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 					SetContent("Odrzucono zaproszenie").
 					Build(),
 			)
+
+			return
+		}
+	}
+
+	if strings.HasPrefix(customId, "rc/") {
+		segments := strings.Split(customId, "|")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		check, err := World.MarkReady(uuid.MustParse(segments[1]), playerChar.GetUUID(), segments[0] == "rc/ok")
+
+		if err != nil {
+			switch err.Error() {
+			case "CHECK_NOT_FOUND":
+				event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Sprawdzanie gotowości wygasło").Build())
+			case "NOT_MEMBER":
+				event.CreateMessage(MessageContent("Nie jesteś w tym party", true))
+			case "WRONG_LOCATION":
+				event.CreateMessage(MessageContent("Nie jesteś w tej samej lokacji co party", true))
+			case "IN_FIGHT":
+				event.CreateMessage(MessageContent("Jesteś w trakcie walki", true))
+			}
+
+			return
+		}
+
+		switch check.State() {
+		case party.ReadyPending:
+			event.CreateMessage(MessageContent(fmt.Sprintf("%s jest gotowy (%d/%d)", playerChar.GetName(), len(check.Ready), len(check.Members)), false))
+		case party.ReadyCancelled:
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContentf("%s nie jest gotowy, walka anulowana", playerChar.GetName()).Build())
+		case party.ReadyAll:
+			event.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Wszyscy gotowi, szukanie...").Build())
+
+			initiator, exists := World.Players[check.Initiator]
+
+			if !exists {
+				return
+			}
+
+			//Last click only confirms, search result belongs to the initiator
+			World.PlayerSearch(check.Initiator, check.ThreadId, func(message discord.MessageCreate) {
+				message.Content = strings.TrimSpace(fmt.Sprintf("<@%s> %s", initiator.Meta.UserID, message.Content))
+
+				World.BufferChannel <- types.DiscordMessageStruct{
+					ChannelID:      check.ThreadId,
+					MessageContent: message,
+				}
+			})
+		}
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "lfg/") {
+		segments := strings.Split(customId, "/")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		rawRole, _ := strconv.Atoi(segments[2])
+
+		invite, err := World.ApplyToPartyListing(uuid.MustParse(segments[1]), playerChar.GetUUID(), party.PartyRole(rawRole))
+
+		if err != nil {
+			switch err.Error() {
+			case "LISTING_NOT_FOUND":
+				event.CreateMessage(MessageContent("Ogłoszenie już nie istnieje", true))
+			case "ALREADY_IN_PARTY":
+				event.CreateMessage(MessageContent("Jesteś już w party", true))
+			case "LEVEL_TOO_LOW":
+				event.CreateMessage(MessageContent("Masz za niski poziom", true))
+			case "ROLE_NOT_WANTED":
+				event.CreateMessage(MessageContent("Party nie szuka tej roli", true))
+			case "PARTY_FULL":
+				event.CreateMessage(MessageContent("Party jest pełne", true))
+			case "ALREADY_APPLIED":
+				event.CreateMessage(MessageContent("Już wysłałeś zgłoszenie", true))
+			}
+
+			return
+		}
+
+		leader := World.Players[World.Parties[invite.Party].Leader]
+
+		World.BufferChannel <- types.DiscordMessageStruct{
+			ChannelID: leader.Meta.UserID,
+			MessageContent: discord.NewMessageCreateBuilder().
+				SetContentf(
+					"<@%s> (%s, poziom %d) chce dołączyć do party jako %s. Zgłoszenie wygasa <t:%d:R>",
+					playerChar.Meta.UserID, playerChar.GetName(), playerChar.XP.Level, RoleToString[invite.Role], invite.Expires.Unix(),
+				).
+				AddActionRow(
+					discord.NewPrimaryButton("Przyjmij", "party/acc|"+invite.Uuid.String()),
+					discord.NewDangerButton("Odrzuć", "party/den|"+invite.Uuid.String()),
+				).
+				Build(),
+			DM: true,
+		}
+
+		event.CreateMessage(MessageContent("Wysłano zgłoszenie do lidera", true))
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "ah/") {
+		segments := strings.Split(customId, "|")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		auctionUuid := uuid.MustParse(segments[1])
+
+		switch segments[0] {
+		case "ah/bid":
+			auctionObj, exists := World.Auctions[auctionUuid]
+
+			if !exists {
+				event.CreateMessage(MessageContent("Aukcja już się zakończyła", true))
+				return
+			}
+
+			modal := discord.NewModalCreateBuilder()
+
+			modal.SetTitle("Licytacja")
+			modal.SetCustomID("ah/bid")
+			modal.AddActionRow(
+				discord.NewShortTextInput("ah/bid/"+auctionUuid.String(), fmt.Sprintf("Oferta (min. %d)", auctionObj.MinBid())),
+			)
+
+			event.Modal(modal.Build())
+		case "ah/buy":
+			auctionObj, exists := World.Auctions[auctionUuid]
+
+			if !exists {
+				event.CreateMessage(MessageContent("Aukcja już się zakończyła", true))
+				return
+			}
+
+			_, err := World.BidAuction(playerChar.GetUUID(), auctionUuid, auctionObj.Buyout)
+
+			if err != nil {
+				event.CreateMessage(MessageContent(auctionErrorText(err), true))
+				return
+			}
+
+			event.CreateMessage(MessageContent("Wykupiono "+AuctionName(auctionObj), true))
+		case "ah/cancel":
+			err := World.CancelAuction(playerChar.GetUUID(), auctionUuid)
+
+			if err != nil {
+				switch err.Error() {
+				case "AUCTION_NOT_FOUND":
+					event.CreateMessage(MessageContent("Aukcja już się zakończyła", true))
+				case "NOT_OWNER":
+					event.CreateMessage(MessageContent("To nie twoja aukcja", true))
+				case "HAS_BIDS":
+					event.CreateMessage(MessageContent("Nie można wycofać aukcji z ofertami", true))
+				}
+
+				return
+			}
+
+			event.CreateMessage(MessageContent("Wycofano aukcję, przedmioty wróciły do ekwipunku", true))
+		}
+
+		return
+	}
+
+	if customId == "hc/confirm" {
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
 		}
+
+		err := playerChar.EnableHardcore()
+
+		if err != nil {
+			event.CreateMessage(MessageContent(deathErrorText(err), true))
+			return
+		}
+
+		event.UpdateMessage(
+			discord.
+				NewMessageUpdateBuilder().
+				ClearContainerComponents().
+				SetContent("Włączono tryb hardcore. Powodzenia!").
+				Build(),
+		)
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "stat/") {
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		stat, _ := strconv.Atoi(strings.TrimPrefix(customId, "stat/"))
+
+		err := playerChar.AllocateStat(types.Stat(stat), 1)
+
+		if err != nil {
+			event.CreateMessage(MessageContent(statErrorText(err), true))
+			return
+		}
+
+		event.UpdateMessage(
+			discord.
+				NewMessageUpdateBuilder().
+				SetEmbeds(StatsEmbed(playerChar)).
+				SetContainerComponents(StatButtons(playerChar)...).
+				Build(),
+		)
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "respec/") {
+		segments := strings.Split(customId, "|")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		fromLvl, _ := strconv.Atoi(segments[1])
+
+		refunded, err := World.RespecSkills(playerChar.GetUUID(), fromLvl, segments[0] == "respec/scroll")
+
+		if err != nil {
+			event.CreateMessage(MessageContent(respecErrorText(err), true))
+			return
+		}
+
+		event.UpdateMessage(
+			discord.
+				NewMessageUpdateBuilder().
+				ClearContainerComponents().
+				SetContentf("Zresetowano umiejętności, odzyskano %d punktów", refunded).
+				Build(),
+		)
+
+		return
+	}
+
+	if strings.HasPrefix(customId, "loot/") {
+		segments := strings.Split(customId, "|")
+
+		playerChar := World.GetPlayer(event.User().ID.String())
+
+		if playerChar == nil {
+			event.CreateMessage(noCharMessage)
+			return
+		}
+
+		choice := party.LootPass
+
+		switch segments[0] {
+		case "loot/need":
+			choice = party.LootNeed
+		case "loot/greed":
+			choice = party.LootGreed
+		}
+
+		err := World.ChooseLoot(uuid.MustParse(segments[1]), playerChar.GetUUID(), choice)
+
+		if err != nil {
+			switch err.Error() {
+			case "ROLL_NOT_FOUND":
+				event.CreateMessage(MessageContent("Losowanie już się zakończyło", true))
+			case "NOT_CANDIDATE":
+				event.CreateMessage(MessageContent("Nie bierzesz udziału w tym losowaniu", true))
+			case "ALREADY_CHOSEN":
+				event.CreateMessage(MessageContent("Już dokonałeś wyboru", true))
+			}
+
+			return
+		}
+
+		event.CreateMessage(MessageContent("Wybrano: "+party.LootChoiceToString[choice], true))
+
+		return
 	}
 
 	if strings.HasPrefix(customId, "f") {
@@ -813,7 +1282,13 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 					case 'f':
 						value := uuid.MustParse(strings.Split(selected, "|")[1])
 
+						var skillObj types.PlayerSkill
+
 						if player.Meta.Fury != nil {
+							skillObj = player.Meta.Fury.GetSkill(value)
+						}
+
+						if skillObj == nil {
 							event.CreateMessage(
 								discord.
 									NewMessageCreateBuilder().
@@ -821,14 +1296,8 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 									SetEphemeral(true).
 									Build(),
 							)
-						}
-
-						var skillObj types.PlayerSkill
 
-						for _, skill := range player.Meta.Fury.GetSkills() {
-							if skill.GetUUID() == value {
-								skillObj = skill
-							}
+							return
 						}
 
 						skillTrigger := skillObj.GetTrigger()
@@ -863,6 +1332,8 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 										SetEphemeral(true).
 										Build(),
 								)
+
+								return
 							}
 
 							selectMenuUuidDeep := uuid.New().String()
@@ -923,7 +1394,7 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 						}
 
 						if skillTrigger.Target.Target == types.TARGET_ALLY {
-							playerAllies := fight.GetEnemiesFor(player.GetUUID())
+							playerAllies := fight.GetAlliesFor(player.GetUUID())
 
 							if len(playerAllies) == 0 {
 								event.CreateMessage(
@@ -933,6 +1404,8 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 										SetEphemeral(true).
 										Build(),
 								)
+
+								return
 							}
 
 							selectMenuUuidDeep := uuid.New().String()
@@ -1204,13 +1677,29 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 					itemName = data.Items[stock.ItemUUID].Name
 				}
 
-				productButton := discord.NewPrimaryButton(itemName, "shop/buy/"+segments[3]+"/"+fmt.Sprint(itemIdx))
+				productButton := discord.NewPrimaryButton(itemName, "shop/buy/"+segments[3]+"/"+fmt.Sprint(pageStart+itemIdx))
+
+				quantity := "∞"
+
+				if stock.Quantity != -1 {
+					quantity = fmt.Sprintf("%d/%d", stock.Quantity, stock.MaxQuantity)
+				}
 
-				embed.AddField(itemName, fmt.Sprintf("Cena: %d", stock.Price), true)
+				if stock.SoldOut() {
+					productButton = productButton.AsDisabled()
+				}
+
+				embed.AddField(itemName, fmt.Sprintf("Cena: %d (skup: %d)\nIlość: %s", stock.Price, stock.SellPrice(), quantity), true)
 				productButtons = append(productButtons, productButton)
 			}
 
-			embed.SetFooterTextf("Ostatnia dostawa -, strona %d/%d", page, (len(store.Stock)/5)+1)
+			lastDelivery := "-"
+
+			if store.LastDelivery != -1 {
+				lastDelivery = calendar.DateFromDayNumber(store.LastDelivery)
+			}
+
+			embed.SetFooterTextf("Ostatnia dostawa %s, strona %d/%d", lastDelivery, page, (len(store.Stock)/5)+1)
 
 			message.AddEmbeds(embed.Build())
 			message.AddActionRow(productButtons...)
@@ -1380,3 +1869,69 @@ func ComponentHandler(event *events.ComponentInteractionCreate) {
 		}
 	}
 }
+
+func auctionErrorText(err error) string {
+	switch err.Error() {
+	case "AUCTION_NOT_FOUND":
+		return "Aukcja już się zakończyła"
+	case "NOT_IN_CITY":
+		return "Dom aukcyjny jest tylko w mieście"
+	case "OWN_AUCTION":
+		return "Nie możesz licytować własnej aukcji"
+	case "ALREADY_HIGHEST":
+		return "Twoja oferta jest już najwyższa"
+	case "BID_TOO_LOW":
+		return "Za niska oferta"
+	case "NOT_ENOUGH_GOLD":
+		return "Za mało pieniędzy"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func auctionBidSubmit(event *events.ModalSubmitInteractionCreate) {
+	var componentCustomId string
+
+	for _, comp := range event.Data.Components {
+		componentCustomId = comp.ID()
+	}
+
+	auctionUuid, err := uuid.Parse(strings.TrimPrefix(componentCustomId, "ah/bid/"))
+
+	if err != nil {
+		event.CreateMessage(MessageContent("Aukcja już się zakończyła", true))
+		return
+	}
+
+	stringInput, _ := event.Data.TextInputComponent(componentCustomId)
+
+	amount, err := strconv.Atoi(strings.TrimSpace(stringInput.Value))
+
+	if err != nil || amount <= 0 {
+		event.CreateMessage(MessageContent("Nieprawidłowa kwota", true))
+		return
+	}
+
+	player := World.GetPlayer(event.User().ID.String())
+
+	if player == nil {
+		event.CreateMessage(noCharMessage)
+		return
+	}
+
+	auctionObj := World.Auctions[auctionUuid]
+
+	boughtOut, err := World.BidAuction(player.GetUUID(), auctionUuid, amount)
+
+	if err != nil {
+		event.CreateMessage(MessageContent(auctionErrorText(err), true))
+		return
+	}
+
+	if boughtOut {
+		event.CreateMessage(MessageContent("Wykupiono "+AuctionName(auctionObj), true))
+		return
+	}
+
+	event.CreateMessage(MessageContent(fmt.Sprintf("Złożono ofertę %d golda na %s", amount, AuctionName(auctionObj)), true))
+}
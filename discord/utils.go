@@ -1,11 +1,45 @@
 package discord
 
 import (
+	"fmt"
+	"sao/battle/mobs"
+	"sao/data"
+	"sao/player"
+	"sao/player/inventory"
+	"sao/types"
+	"sao/utils"
+	"sao/world"
+	"sao/world/auction"
+	"sao/world/bounty"
+	"sao/world/calendar"
+	"sao/world/duel"
+	"sao/world/event"
+	"sao/world/gathering"
+	"sao/world/guild"
+	"sao/world/location"
 	"sao/world/party"
+	"sao/world/quest"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
 )
 
+var GuildRankToString = map[guild.Rank]string{
+	guild.RANK_MEMBER:  "Członek",
+	guild.RANK_OFFICER: "Oficer",
+	guild.RANK_LEADER:  "Lider",
+}
+
+var GuildPermissionToString = map[guild.Permission]string{
+	guild.PERM_INVITE:   "Zapraszanie",
+	guild.PERM_KICK:     "Wyrzucanie",
+	guild.PERM_WITHDRAW: "Wypłaty z banku",
+	guild.PERM_RANKS:    "Zmiana rang",
+}
+
 var RoleToString = map[party.PartyRole]string{
 	party.DPS:     "DPS",
 	party.Support: "Support",
@@ -28,6 +62,12 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 				Description: "Gracz",
 				Required:    true,
 			},
+			discord.ApplicationCommandOptionString{
+				Name:        "klasa",
+				Description: "Klasa postaci",
+				Required:    false,
+				Choices:     archetypeChoices(),
+			},
 		},
 	},
 	discord.SlashCommandCreate{
@@ -42,6 +82,22 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 			},
 		},
 	},
+	discord.SlashCommandCreate{
+		Name:        "eksploruj",
+		Description: "Przeszukaj okolicę w poszukiwaniu ukrytych miejsc",
+	},
+	discord.SlashCommandCreate{
+		Name:        "zbieraj",
+		Description: "Zbieraj surowce w obecnej lokacji",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionString{
+				Autocomplete: true,
+				Name:         "węzeł",
+				Description:  "Miejsce zbierania, bez niego pokazuje listę",
+				Required:     false,
+			},
+		},
+	},
 	discord.SlashCommandCreate{
 		Name:        "tp",
 		Description: "Teleportuj się na inne piętro",
@@ -102,212 +158,2508 @@ var DISCORD_COMMANDS = []discord.ApplicationCommandCreate{
 					},
 				},
 			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "reset",
+				Description: "Zresetuj umiejętności u mistrza",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "od_poziomu",
+						Description: "Resetuje umiejętności od tego poziomu w górę",
+						Required:    false,
+					},
+				},
+			},
 		},
 	},
 	discord.SlashCommandCreate{
-		Name:        "plecak",
-		Description: "Zarządzaj ekwipunkiem",
+		Name:        "podnieś",
+		Description: "Podnieś przedmiot upuszczony w tej lokacji",
 		Options: []discord.ApplicationCommandOption{
-			discord.ApplicationCommandOptionSubCommand{
-				Name:        "pokaż",
-				Description: "Pokaż ekwipunek",
+			discord.ApplicationCommandOptionString{
+				Autocomplete: true,
+				Name:         "przedmiot",
+				Description:  "Przedmiot",
+				Required:     true,
 			},
 		},
 	},
 	discord.SlashCommandCreate{
-		Name:        "szukaj",
-		Description: "Szukaj zajęcia",
+		Name:        "hardcore",
+		Description: "Włącz tryb hardcore (śmierć jest ostateczna)",
 	},
 	discord.SlashCommandCreate{
-		Name:        "party",
-		Description: "Zarządzaj party",
+		Name:        "ranking",
+		Description: "Ranking graczy",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionBool{
+				Name:        "hardcore",
+				Description: "Pokaż ranking postaci hardcore",
+				Required:    false,
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "questy",
+		Description: "Zadania",
 		Options: []discord.ApplicationCommandOption{
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "pokaż",
-				Description: "Pokaż party",
+				Name:        "dziennik",
+				Description: "Pokaż przyjęte zadania",
 			},
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "zapros",
-				Description: "Zaproś do party",
+				Name:        "dostępne",
+				Description: "Pokaż zadania oferowane w tej lokacji",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "przyjmij",
+				Description: "Przyjmij zadanie",
 				Options: []discord.ApplicationCommandOption{
-					discord.ApplicationCommandOptionUser{
-						Name:        "gracz",
-						Description: "Gracz",
-						Required:    true,
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "zadanie",
+						Description:  "Zadanie",
+						Required:     true,
 					},
 				},
 			},
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "wyrzuć",
-				Description: "Wyrzuć z party",
+				Name:        "oddaj",
+				Description: "Oddaj wykonane zadanie",
 				Options: []discord.ApplicationCommandOption{
-					discord.ApplicationCommandOptionUser{
-						Name:        "gracz",
-						Description: "Gracz",
-						Required:    true,
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "zadanie",
+						Description:  "Zadanie",
+						Required:     true,
 					},
 				},
 			},
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "opuść",
-				Description: "Opuść party",
-			},
-			discord.ApplicationCommandOptionSubCommand{
-				Name:        "zmień",
-				Description: "Zmień rolę",
+				Name:        "porzuć",
+				Description: "Porzuć zadanie",
 				Options: []discord.ApplicationCommandOption{
-					discord.ApplicationCommandOptionUser{
-						Name:        "gracz",
-						Description: "Gracz",
-						Required:    true,
-					},
 					discord.ApplicationCommandOptionString{
-						Name:        "rola",
-						Description: "Rola",
-						Required:    true,
-						Choices: []discord.ApplicationCommandOptionChoiceString{
-							{
-								Name:  "Lider",
-								Value: "Lider",
-							},
-							{
-								Name:  "DPS",
-								Value: "DPS",
-							},
-							{
-								Name:  "Support",
-								Value: "Support",
-							},
-							{
-								Name:  "Tank",
-								Value: "Tank",
-							},
-						},
+						Autocomplete: true,
+						Name:         "zadanie",
+						Description:  "Zadanie",
+						Required:     true,
 					},
 				},
 			},
-			discord.ApplicationCommandOptionSubCommand{
-				Name:        "rozwiąż",
-				Description: "Rozwiąż party",
-			},
 		},
 	},
 	discord.SlashCommandCreate{
-		Name:        "stwórz",
-		Description: "Stwórz przedmiot",
+		Name:        "zlecenia",
+		Description: "Tablica zleceń w mieście",
 		Options: []discord.ApplicationCommandOption{
-			discord.ApplicationCommandOptionString{
-				Name:         "nazwa",
-				Description:  "Nazwa przedmiotu",
-				Required:     true,
-				Autocomplete: true,
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "tablica",
+				Description: "Pokaż dzisiejsze zlecenia",
 			},
-			discord.ApplicationCommandOptionInt{
-				Name:        "ilość",
-				Description: "Ilość przedmiotów",
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "przyjmij",
+				Description: "Przyjmij zlecenie",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "zlecenie",
+						Description:  "Zlecenie",
+						Required:     true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "odbierz",
+				Description: "Odbierz nagrodę za wykonane zlecenie",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "zlecenie",
+						Description:  "Zlecenie",
+						Required:     true,
+					},
+				},
 			},
 		},
 	},
 	discord.SlashCommandCreate{
-		Name:        "furia",
-		Description: "Zarządzaj furiami",
+		Name:        "czas",
+		Description: "Pokaż datę, porę roku i to, co jest teraz aktywne",
+	},
+	discord.SlashCommandCreate{
+		Name:        "wydarzenie",
+		Description: "Wydarzenia świata",
 		Options: []discord.ApplicationCommandOption{
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "pokaż",
-				Description: "Pokaż swoje furie",
+				Name:        "lista",
+				Description: "Pokaż trwające i nadchodzące wydarzenia",
 			},
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "ulepsz",
-				Description: "Ulepsz furie kolejny tier",
+				Name:        "dołącz",
+				Description: "Dołącz do trwającego wydarzenia",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "wydarzenie",
+						Description:  "Wydarzenie",
+						Required:     true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "start",
+				Description: "Rozpocznij lub zaplanuj wydarzenie (admin)",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "wydarzenie",
+						Description:  "Wydarzenie",
+						Required:     true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "za_godzin",
+						Description: "Za ile godzin w grze rozpocząć wydarzenie",
+						Required:    false,
+					},
+				},
 			},
 		},
 	},
 	discord.SlashCommandCreate{
-		Name:        "sklep",
-		Description: "Zarządzaj sklepami",
+		Name:        "loch",
+		Description: "Lochy dla drużyn",
 		Options: []discord.ApplicationCommandOption{
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "pokaż",
-				Description: "Pokazuje sklepy w danej lokalizacji",
+				Name:        "wejdź",
+				Description: "Wejdź do lochu z całym party",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "loch",
+						Description:  "Loch",
+						Required:     true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "dalej",
+				Description: "Przejdź do następnej komnaty",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "opuść",
+				Description: "Opuść loch",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "ranking",
+				Description: "Najszybsze przejścia lochu",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Autocomplete: true,
+						Name:         "loch",
+						Description:  "Loch",
+						Required:     true,
+					},
+				},
 			},
 		},
 	},
 	discord.SlashCommandCreate{
-		Name:        "turniej",
-		Description: "Zarządzaj turniejami",
+		Name:        "pojedynek",
+		Description: "Pojedynki z innymi graczami",
 		Options: []discord.ApplicationCommandOption{
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "stwórz",
-				Description: "Stwórz turniej",
+				Name:        "wyzwij",
+				Description: "Wyzwij gracza na pojedynek (arena lub miasto)",
 				Options: []discord.ApplicationCommandOption{
-					discord.ApplicationCommandOptionString{
-						Name:        "nazwa",
-						Description: "Nazwa turnieju",
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Przeciwnik",
 						Required:    true,
 					},
 					discord.ApplicationCommandOptionInt{
-						Name:        "typ",
-						Description: "Typ turnieju",
-						Required:    true,
-						Choices: []discord.ApplicationCommandOptionChoiceInt{
-							{
-								Name:  "Zwykły",
-								Value: 0,
-							},
-						},
+						Name:        "stawka",
+						Description: "Złoto stawiane przez każdą ze stron",
+						Required:    false,
 					},
-					discord.ApplicationCommandOptionInt{
-						Name:        "max",
-						Description: "Maksymalna ilość graczy",
+					discord.ApplicationCommandOptionBool{
+						Name:        "przywróć_hp",
+						Description: "Przywróć HP i manę po pojedynku",
+						Required:    false,
 					},
 				},
 			},
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "rozpocznij",
-				Description: "Rozpocznij turniej",
+				Name:        "historia",
+				Description: "Historia pojedynków i ranking PvP",
 				Options: []discord.ApplicationCommandOption{
-					discord.ApplicationCommandOptionString{
-						Name:         "nazwa",
-						Description:  "Nazwa turnieju",
-						Required:     true,
-						Autocomplete: true,
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    false,
 					},
 				},
 			},
 		},
 	},
 	discord.SlashCommandCreate{
-		Name:        "handel",
-		Description: "Zarządzaj handlem",
+		Name:        "bitwa",
+		Description: "Bitwy drużyn",
 		Options: []discord.ApplicationCommandOption{
 			discord.ApplicationCommandOptionSubCommand{
-				Name:        "nowy",
-				Description: "Zaproś do handlu",
+				Name:        "wyzwij",
+				Description: "Wyzwij drużynę innego lidera na bitwę (arena lub miasto)",
 				Options: []discord.ApplicationCommandOption{
 					discord.ApplicationCommandOptionUser{
 						Name:        "gracz",
-						Description: "Kogo?",
+						Description: "Lider drużyny przeciwnej",
 						Required:    true,
 					},
 				},
 			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "ranking",
+				Description: "Ranking składów drużyn i ostatnie bitwy",
+			},
 		},
 	},
-}
-
-func isAdmin(member *discord.ResolvedMember) bool {
-	return member.Permissions.Has(discord.PermissionAdministrator)
-}
-
-func MessageContent(content string, ephemeral bool) discord.MessageCreate {
-	return discord.NewMessageCreateBuilder().SetContent(content).SetEphemeral(ephemeral).Build()
-}
-
-func MessageEmbed(embeds ...discord.Embed) discord.MessageCreate {
-	return discord.NewMessageCreateBuilder().SetEmbeds(embeds...).Build()
-}
-
-type LevelField struct {
-	Level int
-	Field discord.EmbedField
+	discord.SlashCommandCreate{
+		Name:        "gildia",
+		Description: "Zarządzaj gildią",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "załóż",
+				Description: fmt.Sprintf("Załóż gildię za %d golda (tylko w mieście)", guild.CreationCost),
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "nazwa",
+						Description: "Nazwa gildii",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "info",
+				Description: "Informacje o gildii",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zaproś",
+				Description: "Zaproś gracza do gildii",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Zapraszany gracz",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "opuść",
+				Description: "Opuść gildię, ostatni członek ją rozwiązuje",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wyrzuć",
+				Description: "Wyrzuć członka o niższej randze",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Członek gildii",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "ranga",
+				Description: "Zmień rangę członka",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Członek gildii",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "ranga",
+						Description: "Nowa ranga",
+						Required:    true,
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{
+								Name:  "Członek",
+								Value: int(guild.RANK_MEMBER),
+							},
+							{
+								Name:  "Oficer",
+								Value: int(guild.RANK_OFFICER),
+							},
+							{
+								Name:  "Lider (przekazanie gildii)",
+								Value: int(guild.RANK_LEADER),
+							},
+						},
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "uprawnienia",
+				Description: "Zmień uprawnienia rangi (tylko lider)",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "ranga",
+						Description: "Ranga",
+						Required:    true,
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{
+								Name:  "Członek",
+								Value: int(guild.RANK_MEMBER),
+							},
+							{
+								Name:  "Oficer",
+								Value: int(guild.RANK_OFFICER),
+							},
+						},
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "uprawnienie",
+						Description: "Uprawnienie",
+						Required:    true,
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{
+								Name:  "Zapraszanie",
+								Value: int(guild.PERM_INVITE),
+							},
+							{
+								Name:  "Wyrzucanie",
+								Value: int(guild.PERM_KICK),
+							},
+							{
+								Name:  "Wypłaty z banku",
+								Value: int(guild.PERM_WITHDRAW),
+							},
+							{
+								Name:  "Zmiana rang",
+								Value: int(guild.PERM_RANKS),
+							},
+						},
+					},
+					discord.ApplicationCommandOptionBool{
+						Name:        "włączone",
+						Description: "Czy ranga ma mieć to uprawnienie",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wpłać",
+				Description: "Wpłać złoto lub przedmiot do banku gildii",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "złoto",
+						Description: "Ilość złota",
+						Required:    false,
+					},
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot z plecaka",
+						Required:     false,
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "ilość",
+						Description: "Ilość przedmiotów",
+						Required:    false,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wypłać",
+				Description: "Wypłać złoto lub przedmiot z banku gildii",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "złoto",
+						Description: "Ilość złota",
+						Required:    false,
+					},
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot z banku",
+						Required:     false,
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "ilość",
+						Description: "Ilość przedmiotów",
+						Required:    false,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "bank",
+				Description: "Zawartość banku gildii i historia operacji",
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "staty",
+		Description: "Zarządzaj punktami statystyk",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "pokaż",
+				Description: "Pokaż rozdane punkty i rozdaj wolne",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "klasa",
+				Description: "Wybierz klasę postaci (tylko raz)",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "nazwa",
+						Description: "Klasa",
+						Required:    true,
+						Choices:     archetypeChoices(),
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "plecak",
+		Description: "Zarządzaj ekwipunkiem",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "pokaż",
+				Description: "Pokaż ekwipunek",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "załóż",
+				Description: "Załóż przedmiot",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot do założenia",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zdejmij",
+				Description: "Zdejmij przedmiot",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot do zdjęcia",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zapisz",
+				Description: "Zapisz założone przedmioty jako zestaw",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "nazwa",
+						Description: "Nazwa zestawu",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wczytaj",
+				Description: "Załóż zapisany zestaw",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "nazwa",
+						Description:  "Nazwa zestawu",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "usuń",
+				Description: "Usuń zapisany zestaw",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "nazwa",
+						Description:  "Nazwa zestawu",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "szukaj",
+		Description: "Szukaj zajęcia",
+	},
+	discord.SlashCommandCreate{
+		Name:        "party",
+		Description: "Zarządzaj party",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "pokaż",
+				Description: "Pokaż party",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zapros",
+				Description: "Zaproś do party",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wyrzuć",
+				Description: "Wyrzuć z party",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "opuść",
+				Description: "Opuść party",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zmień",
+				Description: "Zmień rolę",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Gracz",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionString{
+						Name:        "rola",
+						Description: "Rola",
+						Required:    true,
+						Choices: []discord.ApplicationCommandOptionChoiceString{
+							{
+								Name:  "Lider",
+								Value: "Lider",
+							},
+							{
+								Name:  "DPS",
+								Value: "DPS",
+							},
+							{
+								Name:  "Support",
+								Value: "Support",
+							},
+							{
+								Name:  "Tank",
+								Value: "Tank",
+							},
+						},
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "rozwiąż",
+				Description: "Rozwiąż party",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "ogłoś",
+				Description: "Dodaj ogłoszenie na tablicę poszukiwań",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "lokacja",
+						Description:  "Gdzie się spotykacie (domyślnie obecna lokacja)",
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "poziom",
+						Description: "Minimalny poziom",
+					},
+					discord.ApplicationCommandOptionBool{
+						Name:        "dps",
+						Description: "Szukasz DPS?",
+					},
+					discord.ApplicationCommandOptionBool{
+						Name:        "support",
+						Description: "Szukasz supporta?",
+					},
+					discord.ApplicationCommandOptionBool{
+						Name:        "tank",
+						Description: "Szukasz tanka?",
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "tablica",
+				Description: "Pokaż tablicę poszukiwań",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "zamknij",
+				Description: "Usuń swoje ogłoszenie z tablicy",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "podążanie",
+				Description: "Czy członkowie automatycznie podążają za liderem",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionBool{
+						Name:        "automatycznie",
+						Description: "Przenoś członków razem z liderem",
+						Required:    true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "łup",
+				Description: "Zmień sposób podziału łupów",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionInt{
+						Name:        "tryb",
+						Description: "Tryb podziału",
+						Required:    true,
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{
+								Name:  "Lider bierze wszystko",
+								Value: int(party.LootLeader),
+							},
+							{
+								Name:  "Po kolei",
+								Value: int(party.LootRoundRobin),
+							},
+							{
+								Name:  "Potrzeba/Chciwość",
+								Value: int(party.LootNeedGreed),
+							},
+							{
+								Name:  "Losowo",
+								Value: int(party.LootRandom),
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "przepisy",
+		Description: "Pokaż znane przepisy",
+	},
+	discord.SlashCommandCreate{
+		Name:        "stwórz",
+		Description: "Stwórz przedmiot",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionString{
+				Name:         "nazwa",
+				Description:  "Nazwa przedmiotu",
+				Required:     true,
+				Autocomplete: true,
+			},
+			discord.ApplicationCommandOptionInt{
+				Name:        "ilość",
+				Description: "Ilość przedmiotów",
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "furia",
+		Description: "Zarządzaj furiami",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "pokaż",
+				Description: "Pokaż swoje furie",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "ulepsz",
+				Description: "Ulepsz furie kolejny tier",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "przebudź",
+				Description: "Przebudź furię z przedmiotu",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot z furią",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "sklep",
+		Description: "Zarządzaj sklepami",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "pokaż",
+				Description: "Pokazuje sklepy w danej lokalizacji",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "historia",
+				Description: "Pokazuje historię zakupów",
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "napraw",
+				Description: "Napraw przedmiot u kowala",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot do naprawy",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "ulepsz",
+				Description: "Ulepsz przedmiot u kowala",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot do ulepszenia",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "sprzedaj",
+				Description: "Sprzedaj przedmiot sklepowi w lokalizacji",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot do sprzedania",
+						Required:     true,
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "ilość",
+						Description: "Ilość",
+						Required:    false,
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "aukcje",
+		Description: "Dom aukcyjny",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "wystaw",
+				Description: "Wystaw przedmiot na aukcję",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "przedmiot",
+						Description:  "Przedmiot do wystawienia",
+						Required:     true,
+						Autocomplete: true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "ilość",
+						Description: "Ilość",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "cena",
+						Description: "Cena wywoławcza",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "dni",
+						Description: "Ile dni trwa aukcja",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "wykup",
+						Description: "Cena natychmiastowego zakupu",
+						Required:    false,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "przeglądaj",
+				Description: "Przeglądaj aukcje",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "nazwa",
+						Description: "Nazwa przedmiotu",
+						Required:    false,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "typ",
+						Description: "Typ przedmiotu",
+						Required:    false,
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{
+								Name:  "Przedmiot",
+								Value: int(types.ITEM_OTHER),
+							},
+							{
+								Name:  "Składnik",
+								Value: int(types.ITEM_MATERIAL),
+							},
+						},
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "maks_cena",
+						Description: "Maksymalna cena",
+						Required:    false,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "strona",
+						Description: "Strona",
+						Required:    false,
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "moje",
+				Description: "Twoje aukcje i oferty",
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "turniej",
+		Description: "Zarządzaj turniejami",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "stwórz",
+				Description: "Stwórz turniej",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:        "nazwa",
+						Description: "Nazwa turnieju",
+						Required:    true,
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "typ",
+						Description: "Typ turnieju",
+						Required:    true,
+						Choices: []discord.ApplicationCommandOptionChoiceInt{
+							{
+								Name:  "Zwykły",
+								Value: 0,
+							},
+						},
+					},
+					discord.ApplicationCommandOptionInt{
+						Name:        "max",
+						Description: "Maksymalna ilość graczy",
+					},
+				},
+			},
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "rozpocznij",
+				Description: "Rozpocznij turniej",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionString{
+						Name:         "nazwa",
+						Description:  "Nazwa turnieju",
+						Required:     true,
+						Autocomplete: true,
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "handel",
+		Description: "Zarządzaj handlem",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionSubCommand{
+				Name:        "nowy",
+				Description: "Zaproś do handlu",
+				Options: []discord.ApplicationCommandOption{
+					discord.ApplicationCommandOptionUser{
+						Name:        "gracz",
+						Description: "Kogo?",
+						Required:    true,
+					},
+				},
+			},
+		},
+	},
+	discord.SlashCommandCreate{
+		Name:        "bestiariusz",
+		Description: "Informacje o przeciwniku",
+		Options: []discord.ApplicationCommandOption{
+			discord.ApplicationCommandOptionString{
+				Name:         "nazwa",
+				Description:  "Nazwa przeciwnika",
+				Required:     true,
+				Autocomplete: true,
+			},
+		},
+	},
+}
+
+func isAdmin(member *discord.ResolvedMember) bool {
+	return member.Permissions.Has(discord.PermissionAdministrator)
+}
+
+func MessageContent(content string, ephemeral bool) discord.MessageCreate {
+	return discord.NewMessageCreateBuilder().SetContent(content).SetEphemeral(ephemeral).Build()
+}
+
+func MessageEmbed(embeds ...discord.Embed) discord.MessageCreate {
+	return discord.NewMessageCreateBuilder().SetEmbeds(embeds...).Build()
+}
+
+type LevelField struct {
+	Level int
+	Field discord.EmbedField
+}
+
+var DamageTypeToString = map[int]string{
+	int(types.DMG_PHYSICAL): "Fizyczne",
+	int(types.DMG_MAGICAL):  "Magiczne",
+	int(types.DMG_TRUE):     "Nieuchronne",
+	int(types.DMG_ALL):      "Wszystkie",
+}
+
+func MobEmbed(mob *mobs.MobEntity) discord.Embed {
+	attackType := "Fizyczny"
+
+	if mob.GetAdaptiveAttackType() == types.ADAPTIVE_AP {
+		attackType = "Magiczny"
+	}
+
+	resistText := ""
+
+	for _, effect := range mob.GetAllEffects() {
+		if effect.Effect != types.EFFECT_RESIST {
+			continue
+		}
+
+		meta := effect.Meta.(types.ActionEffectResist)
+
+		switch {
+		case meta.All:
+			resistText += fmt.Sprintf("- %s: pełna odporność\n", DamageTypeToString[meta.DmgType])
+		case meta.IsPercent && meta.Value < 0:
+			resistText += fmt.Sprintf("- %s: +%d%% (słabość)\n", DamageTypeToString[meta.DmgType], -meta.Value)
+		case meta.IsPercent:
+			resistText += fmt.Sprintf("- %s: -%d%%\n", DamageTypeToString[meta.DmgType], meta.Value)
+		default:
+			resistText += fmt.Sprintf("- %s: -%d\n", DamageTypeToString[meta.DmgType], meta.Value)
+		}
+	}
+
+	if resistText == "" {
+		resistText = "Brak"
+	}
+
+	lootText := LootTableText(mob.LootTable, "")
+
+	if lootText == "" {
+		lootText = "Brak"
+	}
+
+	return discord.NewEmbedBuilder().
+		AddField("Nazwa", mob.GetName(), true).
+		AddField("HP", fmt.Sprint(mob.GetStat(types.STAT_HP)), true).
+		AddField("Typ ataku", attackType, true).
+		AddField("Atak", fmt.Sprint(mob.GetStat(types.STAT_AD)), true).
+		AddField("AP", fmt.Sprint(mob.GetStat(types.STAT_AP)), true).
+		AddField("DEF/RES", fmt.Sprintf("%d/%d", mob.GetStat(types.STAT_DEF), mob.GetStat(types.STAT_MR)), true).
+		AddField("SPD/AGL", fmt.Sprintf("%d/%d", mob.GetStat(types.STAT_SPD), mob.GetStat(types.STAT_AGL)), true).
+		AddField("Odporności", resistText, false).
+		AddField("Łup", lootText, false).
+		Build()
+}
+
+func LootTableText(table []types.LootEntry, indent string) string {
+	lootText := ""
+
+	for _, entry := range table {
+		line := indent + "- "
+
+		if len(entry.Pool) > 0 {
+			line += fmt.Sprintf("%dx jedno z", entry.Rolls)
+		} else {
+			countText := fmt.Sprint(entry.Min)
+
+			if entry.Max != entry.Min {
+				countText = fmt.Sprintf("%d-%d", entry.Min, entry.Max)
+			}
+
+			switch entry.Loot.Type {
+			case types.LOOT_EXP:
+				line += "XP: " + countText
+			case types.LOOT_GOLD:
+				line += "Złoto: " + countText
+			case types.LOOT_ITEM:
+				line += fmt.Sprintf(
+					"[%s] %s x%s",
+					types.RarityToString[entry.Loot.Meta.Rarity],
+					data.GetItemName(entry.Loot.Meta.Type, entry.Loot.Meta.Uuid),
+					countText,
+				)
+			case types.LOOT_FURY:
+				line += "Furia: " + data.GetFuryName(entry.Loot.Meta.Uuid)
+			}
+		}
+
+		if entry.Weight > 0 {
+			line += fmt.Sprintf(" (waga %d)", entry.Weight)
+		}
+
+		if entry.Chance < 100 {
+			line += fmt.Sprintf(" (%d%%)", entry.Chance)
+		}
+
+		if entry.FirstKill {
+			line += " (pierwsze pokonanie)"
+		}
+
+		lootText += line + "\n"
+
+		if len(entry.Pool) > 0 {
+			lootText += LootTableText(entry.Pool, indent+"  ")
+		}
+	}
+
+	return lootText
+}
+
+func PartyListingsMessage(listings []*party.Listing) discord.MessageCreate {
+	if len(listings) == 0 {
+		return MessageContent("Brak ogłoszeń", true)
+	}
+
+	embed := discord.NewEmbedBuilder().SetTitle("Tablica poszukiwań")
+	message := discord.NewMessageCreateBuilder()
+
+	sort.Slice(listings, func(i, j int) bool {
+		return listings[i].Expires.Before(listings[j].Expires)
+	})
+
+	//Discord allows only 5 rows of buttons
+	if len(listings) > 5 {
+		listings = listings[:5]
+	}
+
+	for _, listing := range listings {
+		partyData := World.Parties[listing.Party]
+		leader := World.Players[partyData.Leader]
+
+		rolesText := ""
+		buttons := make([]discord.InteractiveComponent, 0)
+
+		for _, role := range listing.Roles {
+			rolesText += RoleToString[role] + ", "
+
+			buttons = append(buttons, discord.NewPrimaryButton(
+				fmt.Sprintf("%s: %s", leader.GetName(), RoleToString[role]),
+				fmt.Sprintf("lfg/%s/%d", listing.Uuid, role),
+			))
+		}
+
+		rolesText = strings.TrimSuffix(rolesText, ", ")
+
+		embed.AddField(
+			fmt.Sprintf("Party %s (%d/%d)", leader.GetName(), len(partyData.Players), party.MaxMembers),
+			fmt.Sprintf(
+				"Lokacja: %s - %s\nRole: %s\nMin. poziom: %d\nWygasa: <t:%d:R>",
+				listing.Floor, listing.Location, rolesText, listing.MinLevel, listing.Expires.Unix(),
+			),
+			false,
+		)
+
+		message.AddActionRow(buttons...)
+	}
+
+	return message.AddEmbeds(embed.Build()).Build()
+}
+
+func ReceiptEmbed(purchase player.Purchase, goldLeft int) discord.Embed {
+	storeName := "Nieznany sklep"
+
+	if store, exists := World.Stores[purchase.Store]; exists {
+		storeName = store.Name
+	}
+
+	return discord.NewEmbedBuilder().
+		SetTitle("Paragon").
+		AddField("Sklep", storeName, true).
+		AddField("Przedmiot", fmt.Sprintf("%dx %s", purchase.Count, data.GetItemName(purchase.ItemType, purchase.Item)), true).
+		AddField("Cena za sztukę", fmt.Sprint(purchase.Price/purchase.Count), true).
+		AddField("Razem", fmt.Sprint(purchase.Price), true).
+		AddField("Pozostało golda", fmt.Sprint(goldLeft), true).
+		SetFooterTextf("Data zakupu %s", purchase.Date).
+		Build()
+}
+
+func PurchaseHistoryEmbed(purchases []player.Purchase) discord.Embed {
+	historyText := ""
+
+	//Newest first
+	for i := len(purchases) - 1; i >= 0; i-- {
+		purchase := purchases[i]
+
+		storeName := "Nieznany sklep"
+
+		if store, exists := World.Stores[purchase.Store]; exists {
+			storeName = store.Name
+		}
+
+		historyText += fmt.Sprintf("- %s: %dx %s za %d (%s)\n", purchase.Date, purchase.Count, data.GetItemName(purchase.ItemType, purchase.Item), purchase.Price, storeName)
+	}
+
+	if historyText == "" {
+		historyText = "Brak zakupów"
+	}
+
+	return discord.NewEmbedBuilder().
+		SetTitle("Historia zakupów").
+		SetDescription(historyText).
+		Build()
+}
+
+func AuctionName(auctionObj *auction.Auction) string {
+	if auctionObj.Instance != nil {
+		return fmt.Sprintf("%dx %s", auctionObj.Count, ItemName(auctionObj.Instance))
+	}
+
+	return fmt.Sprintf("%dx %s", auctionObj.Count, data.GetItemName(auctionObj.ItemType, auctionObj.Item))
+}
+
+func BankItemName(item *guild.BankItem) string {
+	if item.Instance != nil {
+		return ItemName(item.Instance)
+	}
+
+	return data.GetItemName(item.ItemType, item.Item)
+}
+
+func GuildItemName(item *guild.BankItem) string {
+	return fmt.Sprintf("%s x%d", BankItemName(item), item.Count)
+}
+
+func AuctionText(auctionObj *auction.Auction) string {
+	text := fmt.Sprintf("Sprzedający: %s\n", World.Players[auctionObj.Seller].GetName())
+
+	if auctionObj.HasBids() {
+		text += fmt.Sprintf("Oferta: %d (%s)\n", auctionObj.Bid, World.Players[auctionObj.Bidder].GetName())
+	} else {
+		text += fmt.Sprintf("Cena wywoławcza: %d\n", auctionObj.StartPrice)
+	}
+
+	if auctionObj.Buyout != 0 {
+		text += fmt.Sprintf("Wykup: %d\n", auctionObj.Buyout)
+	}
+
+	return text + "Koniec: " + calendar.DateFromDayNumber(auctionObj.Ends)
+}
+
+const AuctionsPerPage = 5
+
+func AuctionsMessage(auctions []*auction.Auction, page int) discord.MessageCreate {
+	if len(auctions) == 0 {
+		return MessageContent("Brak aukcji", true)
+	}
+
+	sort.Slice(auctions, func(i, j int) bool {
+		return auctions[i].Ends < auctions[j].Ends
+	})
+
+	pages := (len(auctions) + AuctionsPerPage - 1) / AuctionsPerPage
+
+	if page < 1 {
+		page = 1
+	}
+
+	if page > pages {
+		page = pages
+	}
+
+	pageStart := (page - 1) * AuctionsPerPage
+	pageEnd := pageStart + AuctionsPerPage
+
+	if pageEnd > len(auctions) {
+		pageEnd = len(auctions)
+	}
+
+	embed := discord.NewEmbedBuilder().SetTitle("Dom aukcyjny")
+	message := discord.NewMessageCreateBuilder()
+
+	for idx, auctionObj := range auctions[pageStart:pageEnd] {
+		embed.AddField(fmt.Sprintf("%d. %s", pageStart+idx+1, AuctionName(auctionObj)), AuctionText(auctionObj), false)
+
+		buttons := []discord.InteractiveComponent{
+			discord.NewPrimaryButton(fmt.Sprintf("%d. Licytuj (min. %d)", pageStart+idx+1, auctionObj.MinBid()), "ah/bid|"+auctionObj.Uuid.String()),
+		}
+
+		if auctionObj.Buyout != 0 {
+			buttons = append(buttons, discord.NewSuccessButton(fmt.Sprintf("%d. Wykup (%d)", pageStart+idx+1, auctionObj.Buyout), "ah/buy|"+auctionObj.Uuid.String()))
+		}
+
+		message.AddActionRow(buttons...)
+	}
+
+	embed.SetFooterTextf("Strona %d/%d", page, pages)
+
+	return message.AddEmbeds(embed.Build()).SetEphemeral(true).Build()
+}
+
+func RecipesEmbed(playerChar *player.Player, discovered []types.Recipe) discord.Embed {
+	embed := discord.NewEmbedBuilder().
+		SetTitle("Przepisy").
+		SetDescriptionf("Poziom rzemiosła: %d (%d/%d XP)", playerChar.Meta.Crafting.Level, playerChar.Meta.Crafting.Exp, playerChar.Meta.Crafting.ExpToNextLevel())
+
+	if len(discovered) > 0 {
+		discoveredText := ""
+
+		for _, recipe := range discovered {
+			discoveredText += "- " + recipe.Name + "\n"
+		}
+
+		embed.AddField("Odkryto nowe przepisy!", discoveredText, false)
+	}
+
+	recipes := make([]types.Recipe, 0)
+
+	for _, recipe := range data.Recipes {
+		if playerChar.Meta.Crafting.Knows(recipe) {
+			recipes = append(recipes, recipe)
+		}
+	}
+
+	sort.Slice(recipes, func(i, j int) bool {
+		return recipes[i].Name < recipes[j].Name
+	})
+
+	//Discord allows 25 fields
+	if len(recipes) > 24 {
+		recipes = recipes[:24]
+	}
+
+	for _, recipe := range recipes {
+		missing := make(map[uuid.UUID]int)
+
+		for _, entry := range playerChar.Inventory.MissingIngredients(recipe) {
+			missing[entry.Item] = entry.Count
+		}
+
+		recipeText := ""
+
+		if recipe.Station.Location != "" {
+			recipeText += fmt.Sprintf("Stanowisko: %s (%s)\n", recipe.Station.Location, recipe.Station.Floor)
+		}
+
+		recipeText += fmt.Sprintf("Poziom: %d, szansa: %d%%, koszt: %d\n", recipe.Level, playerChar.Meta.Crafting.SuccessChance(recipe), recipe.Cost)
+
+		for _, ingredient := range recipe.Ingredients {
+			if count, isMissing := missing[ingredient.Item]; isMissing {
+				recipeText += fmt.Sprintf("❌ %s x%d (brakuje %d)\n", data.GetItemName(types.ITEM_MATERIAL, ingredient.Item), ingredient.Count, count)
+			} else {
+				recipeText += fmt.Sprintf("✅ %s x%d\n", data.GetItemName(types.ITEM_MATERIAL, ingredient.Item), ingredient.Count)
+			}
+		}
+
+		embed.AddField(recipe.Name, recipeText, false)
+	}
+
+	if len(recipes) == 0 {
+		embed.AddField("Brak", "Nie znasz żadnych przepisów", false)
+	}
+
+	return embed.Build()
+}
+
+func ItemName(item *types.PlayerItem) string {
+	name := item.Name
+
+	if item.Enhancement > 0 {
+		name += fmt.Sprintf(" +%d", item.Enhancement)
+	}
+
+	if item.Quality != types.QUALITY_NORMAL {
+		name += fmt.Sprintf(" (%s)", types.QualityToString[item.Quality])
+	}
+
+	return name
+}
+
+func ItemDescription(item *types.PlayerItem) string {
+	description := fmt.Sprintf("Rzadkość: %s", types.RarityToString[item.Rarity])
+
+	if item.MaxDurability > 0 {
+		description += fmt.Sprintf(", wytrzymałość: %d/%d", item.Durability, item.MaxDurability)
+
+		if item.Broken() {
+			description += " (zepsuty)"
+		}
+	}
+
+	return description + "\n" + item.Description
+}
+
+var EquipmentSlots = []types.ItemSlot{types.SLOT_WEAPON, types.SLOT_HEAD, types.SLOT_ARMOUR, types.SLOT_ACCESSORY, types.SLOT_RUNE}
+
+func EquipmentText(playerChar *player.Player) string {
+	text := ""
+
+	for _, slot := range EquipmentSlots {
+		equipped := playerChar.Inventory.EquippedInSlot(slot)
+
+		for i := 0; i < types.SlotCapacity[slot]; i++ {
+			itemName := "-"
+
+			if i < len(equipped) {
+				itemName = ItemName(equipped[i])
+			}
+
+			text += fmt.Sprintf("%s: %s\n", types.ItemSlotToString[slot], itemName)
+		}
+	}
+
+	return text
+}
+
+func equipmentErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz zmieniać wyposażenia podczas walki"
+	case "ITEM_NOT_FOUND":
+		return "Nie znaleziono przedmiotu"
+	case "NOT_EQUIPPABLE":
+		return "Tego przedmiotu nie można założyć"
+	case "ALREADY_EQUIPPED":
+		return "Przedmiot jest już założony"
+	case "NOT_EQUIPPED":
+		return "Przedmiot nie jest założony"
+	case "TOO_MANY_LOADOUTS":
+		return fmt.Sprintf("Możesz mieć maksymalnie %d zestawów", player.MaxLoadouts)
+	case "LOADOUT_NOT_FOUND":
+		return "Nie znaleziono zestawu"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func blacksmithErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "NO_BLACKSMITH":
+		return "W tej lokacji nie ma kowala"
+	case "ITEM_NOT_FOUND":
+		return "Nie znaleziono przedmiotu"
+	case "NOT_DAMAGED":
+		return "Przedmiot nie jest uszkodzony"
+	case "NOT_ENHANCEABLE":
+		return "Tego przedmiotu nie można ulepszyć"
+	case "MAX_ENHANCEMENT":
+		return fmt.Sprintf("Przedmiot ma już maksymalny poziom ulepszenia (+%d)", types.MAX_ENHANCEMENT)
+	case "MISSING_INGREDIENT":
+		return "Brakuje kamieni wzmocnienia"
+	case "NOT_ENOUGH_GOLD":
+		return "Za mało pieniędzy"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func furyErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "ITEM_NOT_FOUND":
+		return "Nie znaleziono przedmiotu"
+	case "NOT_FURY_ITEM":
+		return "Ten przedmiot nie skrywa furii"
+	case "FURY_NOT_FOUND":
+		return "Ta furia nie istnieje"
+	case "ALREADY_HAS_FURY":
+		return "Masz już furię"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func respecErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "NO_TRAINER":
+		return "W tej lokacji nie ma nikogo, kto zresetuje umiejętności"
+	case "NOTHING_TO_RESET":
+		return "Nie masz umiejętności do zresetowania"
+	case "MISSING_INGREDIENT":
+		return "Nie masz zwoju zapomnienia"
+	case "NOT_ENOUGH_GOLD":
+		return "Za mało pieniędzy"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func RespecPreviewEmbed(playerChar *player.Player, preview player.RespecPreview, cost int) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Reset umiejętności")
+	embed.SetDescriptionf("Zwrócone punkty: %d\nKoszt: %d golda lub zwój zapomnienia", preview.Refunded, cost)
+
+	skillsText := ""
+
+	for _, lvl := range preview.Levels {
+		skill := playerChar.Inventory.LevelSkills[lvl]
+
+		skillsText += fmt.Sprintf("- %d: %s (%s)", lvl, skill.GetName(), types.PathToString[skill.GetPath()])
+
+		for idx, upgrade := range skill.GetUpgrades() {
+			if inventory.HasUpgrade(playerChar.Inventory.LevelSkillsUpgrades[lvl], idx+1) {
+				skillsText += fmt.Sprintf("\n  - %s", upgrade.Description)
+			}
+		}
+
+		skillsText += "\n"
+	}
+
+	embed.AddField("Usunięte umiejętności", skillsText, false)
+
+	statsText := ""
+
+	for _, stat := range player.RespecPreviewStats {
+		before, after := preview.Before[stat], preview.After[stat]
+
+		if before == after {
+			statsText += fmt.Sprintf("%s: %d\n", types.StatToString[stat], before)
+		} else {
+			statsText += fmt.Sprintf("%s: %d -> **%d**\n", types.StatToString[stat], before, after)
+		}
+	}
+
+	embed.AddField("Statystyki", statsText, false)
+
+	return embed.Build()
+}
+
+// Order in which stats are shown in /staty
+var AllocatableStats = []types.Stat{
+	types.STAT_HP,
+	types.STAT_AD,
+	types.STAT_AP,
+	types.STAT_DEF,
+	types.STAT_MR,
+	types.STAT_SPD,
+	types.STAT_AGL,
+	types.STAT_MANA,
+}
+
+func archetypeChoices() []discord.ApplicationCommandOptionChoiceString {
+	choices := make([]discord.ApplicationCommandOptionChoiceString, 0)
+
+	for name := range player.Archetypes {
+		choices = append(choices, discord.ApplicationCommandOptionChoiceString{Name: name, Value: name})
+	}
+
+	sort.Slice(choices, func(i, j int) bool { return choices[i].Name < choices[j].Name })
+
+	return choices
+}
+
+func statErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "NO_POINTS":
+		return "Nie masz wolnych punktów"
+	case "STAT_NOT_ALLOCATABLE":
+		return "Tej statystyki nie można zwiększać punktami"
+	case "STAT_CAP_REACHED":
+		return "Osiągnięto limit punktów dla tej statystyki"
+	case "ARCHETYPE_ALREADY_CHOSEN":
+		return "Klasa została już wybrana"
+	case "ARCHETYPE_NOT_FOUND":
+		return "Nie ma takiej klasy"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func StatsEmbed(playerChar *player.Player) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Statystyki")
+
+	archetypeText := "Brak (wybierz przez /staty klasa)"
+
+	if archetype, exists := player.Archetypes[playerChar.Attributes.Archetype]; exists {
+		archetypeText = fmt.Sprintf("%s - %s", archetype.Name, archetype.Description)
+	}
+
+	embed.SetDescriptionf("Klasa: %s\nWolne punkty: %d", archetypeText, playerChar.FreeStatPoints())
+
+	for _, stat := range AllocatableStats {
+		statCap := playerChar.StatCap(stat)
+
+		if statCap == 0 {
+			continue
+		}
+
+		embed.AddField(
+			types.StatToString[stat],
+			fmt.Sprintf(
+				"%d (punkty %d/%d, +%d za punkt)",
+				playerChar.GetStat(stat),
+				playerChar.Attributes.Allocated[stat],
+				statCap,
+				player.Default.PointValues[stat],
+			),
+			true,
+		)
+	}
+
+	return embed.Build()
+}
+
+func StatButtons(playerChar *player.Player) []discord.ContainerComponent {
+	rows := make([]discord.ContainerComponent, 0)
+	row := discord.ActionRowComponent{}
+
+	for _, stat := range AllocatableStats {
+		statCap := playerChar.StatCap(stat)
+
+		if statCap == 0 {
+			continue
+		}
+
+		button := discord.NewPrimaryButton("+ "+types.StatToString[stat], fmt.Sprintf("stat/%d", stat)).
+			WithDisabled(playerChar.FreeStatPoints() == 0 || playerChar.Attributes.Allocated[stat] >= statCap)
+
+		row = append(row, button)
+
+		if len(row) == 5 {
+			rows = append(rows, row)
+			row = discord.ActionRowComponent{}
+		}
+	}
+
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+func deathErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "DROP_NOT_FOUND":
+		return "Tego przedmiotu już tu nie ma"
+	case "NOT_HERE":
+		return "Ten przedmiot leży w innej lokacji"
+	case "INVENTORY_FULL":
+		return "Nie masz miejsca w ekwipunku"
+	case "ALREADY_HARDCORE":
+		return "Twoja postać jest już w trybie hardcore"
+	case "HARDCORE_TOO_LATE":
+		return "Tryb hardcore można włączyć tylko przed zdobyciem pierwszego doświadczenia"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func LeaderboardEmbed(entries []world.LeaderboardEntry, hardcore bool) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle(utils.BoolToText(hardcore, "Ranking hardcore", "Ranking"))
+
+	description := ""
+
+	for idx, entry := range entries {
+		if idx >= 10 {
+			break
+		}
+
+		description += fmt.Sprintf("%d. %s (<@%s>) - poziom %d, pokonani: %d", idx+1, entry.Name, entry.UserID, entry.Level, entry.Kills)
+
+		if entry.Fallen {
+			description += " †"
+		}
+
+		description += "\n"
+	}
+
+	if description == "" {
+		description = "Brak postaci"
+	}
+
+	embed.SetDescription(description)
+
+	return embed.Build()
+}
+
+func questErrorText(err error) string {
+	switch err.Error() {
+	case "QUEST_NOT_FOUND":
+		return "Nie ma takiego zadania"
+	case "WRONG_LOCATION":
+		return "Zleceniodawcy tego zadania nie ma w tej lokacji"
+	case "ALREADY_ACTIVE":
+		return "Masz już to zadanie"
+	case "ALREADY_COMPLETED":
+		return "To zadanie zostało już wykonane"
+	case "PREREQUISITES_MISSING":
+		return "Najpierw wykonaj poprzednie zadania"
+	case "LEVEL_TOO_LOW":
+		return "Masz za niski poziom"
+	case "TOO_MANY_QUESTS":
+		return fmt.Sprintf("Możesz mieć maksymalnie %d zadań", quest.MaxActive)
+	case "QUEST_NOT_ACTIVE":
+		return "Nie masz takiego zadania"
+	case "OBJECTIVES_INCOMPLETE":
+		return "Zadanie nie jest jeszcze wykonane"
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "INVENTORY_FULL":
+		return "Nie masz miejsca w ekwipunku na nagrodę"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func objectiveText(objective quest.Objective) string {
+	switch objective.Type {
+	case quest.OBJECTIVE_KILL:
+		mobName := objective.Target
+
+		if mob, exists := mobs.Mobs[objective.Target]; exists {
+			mobName = mob.Name
+		}
+
+		return "Pokonaj: " + mobName
+	case quest.OBJECTIVE_COLLECT:
+		return "Zdobądź: " + data.GetItemName(types.ITEM_MATERIAL, uuid.MustParse(objective.Target))
+	case quest.OBJECTIVE_REACH:
+		return "Dotrzyj do: " + strings.Split(objective.Target, ",")[1]
+	case quest.OBJECTIVE_CRAFT:
+		recipeName := objective.Target
+
+		if recipe, exists := data.Recipes[uuid.MustParse(objective.Target)]; exists {
+			recipeName = recipe.Name
+		}
+
+		return "Wytwórz: " + recipeName
+	case quest.OBJECTIVE_TOURNAMENT:
+		return "Wygraj walkę w turnieju"
+	}
+
+	return "?"
+}
+
+func questRewardsText(questData quest.Quest) string {
+	rewardsText := ""
+
+	for _, reward := range questData.Rewards {
+		switch reward.Type {
+		case types.LOOT_EXP:
+			rewardsText += fmt.Sprintf("- %d XP\n", reward.Count)
+		case types.LOOT_GOLD:
+			rewardsText += fmt.Sprintf("- %d golda\n", reward.Count)
+		default:
+			rewardsText += fmt.Sprintf("- %s\n", World.LootName(reward))
+		}
+	}
+
+	for _, floor := range questData.UnlockFloors {
+		rewardsText += fmt.Sprintf("- Dostęp do piętra %s\n", floor)
+	}
+
+	if rewardsText == "" {
+		return "Brak"
+	}
+
+	return rewardsText
+}
+
+func QuestJournalEmbed(playerChar *player.Player) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Dziennik zadań")
+	embed.SetDescriptionf("Przyjęte: %d/%d\nWykonane: %d", len(playerChar.Meta.Quests.Active), quest.MaxActive, len(playerChar.Meta.Quests.Completed))
+
+	active := make([]quest.Quest, 0)
+
+	for questUuid := range playerChar.Meta.Quests.Active {
+		if questData, exists := data.Quests[questUuid]; exists {
+			active = append(active, questData)
+		}
+	}
+
+	sort.Slice(active, func(i, j int) bool { return active[i].Name < active[j].Name })
+
+	for _, questData := range active {
+		progress := playerChar.Meta.Quests.Active[questData.UUID]
+
+		progressText := ""
+
+		for idx, objective := range questData.Objectives {
+			progressText += fmt.Sprintf("- %s (%d/%d)\n", objectiveText(objective), progress.Objectives[idx], objective.Count)
+		}
+
+		if progress.Done(questData) {
+			progressText += fmt.Sprintf("Gotowe do oddania u %s (%s)", questData.Giver.Name, questData.Giver.Location.Location)
+		}
+
+		embed.AddField(questData.Name, progressText, false)
+	}
+
+	return embed.Build()
+}
+
+func AvailableQuestsEmbed(quests []quest.Quest) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Dostępne zadania")
+
+	if len(quests) == 0 {
+		embed.SetDescription("Nikt tutaj nie ma dla ciebie zadań")
+	}
+
+	for _, questData := range quests {
+		objectivesText := ""
+
+		for _, objective := range questData.Objectives {
+			objectivesText += fmt.Sprintf("- %s x%d\n", objectiveText(objective), objective.Count)
+		}
+
+		embed.AddField(
+			fmt.Sprintf("%s (%s)", questData.Name, questData.Giver.Name),
+			fmt.Sprintf("%s\n\nCele:\n%s\nNagrody:\n%s", questData.Description, objectivesText, questRewardsText(questData)),
+			false,
+		)
+	}
+
+	return embed.Build()
+}
+
+func bountyErrorText(err error) string {
+	switch err.Error() {
+	case "NOT_IN_CITY":
+		return "Tablica zleceń jest tylko w mieście"
+	case "BOUNTY_NOT_FOUND":
+		return "Tego zlecenia już nie ma na tablicy"
+	case "ALREADY_ACCEPTED":
+		return "Masz już to zlecenie"
+	case "ALREADY_CLAIMED":
+		return "Nagroda za to zlecenie została już odebrana"
+	case "TOO_MANY_BOUNTIES":
+		return fmt.Sprintf("Możesz mieć maksymalnie %d zleceń", player.MaxBounties)
+	case "BOUNTY_NOT_ACCEPTED":
+		return "Nie przyjąłeś tego zlecenia"
+	case "BOUNTY_INCOMPLETE":
+		return "Zlecenie nie jest jeszcze wykonane"
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "TRAVELING":
+		return "Jesteś w drodze, poczekaj aż skończysz"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func gatherErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "TRAVELING":
+		return "Jesteś w drodze, poczekaj aż skończysz"
+	case "GATHER_COOLDOWN":
+		return "Jesteś zmęczony, odpocznij chwilę przed kolejnym zbieraniem"
+	case "NODE_NOT_FOUND":
+		return "Nie ma tu takiego miejsca"
+	case "LEVEL_TOO_LOW":
+		return "Twój poziom profesji jest za niski"
+	case "NODE_DEPLETED":
+		return "To miejsce jest wyczerpane, wróć później"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func dungeonErrorText(err error) string {
+	switch err.Error() {
+	case "DUNGEON_NOT_FOUND":
+		return "Nie ma takiego lochu"
+	case "NOT_HERE":
+		return "Musisz stać przy wejściu do lochu"
+	case "NOT_LEADER":
+		return "Tylko lider party może wprowadzić drużynę do lochu"
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "PARTY_NOT_READY":
+		return "Cała drużyna musi być żywa i stać przy wejściu"
+	case "IN_DUNGEON":
+		return "Ktoś z drużyny jest już w lochu"
+	case "LOCKED_OUT":
+		return "Ktoś z drużyny był tu niedawno, loch jest jeszcze zamknięty"
+	case "NOT_IN_DUNGEON":
+		return "Nie jesteś w lochu"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func duelErrorText(err error) string {
+	opponent, isOpponent := strings.CutPrefix(err.Error(), "OPPONENT_")
+
+	switch opponent {
+	case "IN_FIGHT":
+		return utils.BoolToText(isOpponent, "Przeciwnik walczy", "Nie możesz tego zrobić podczas walki")
+	case "TRAVELING":
+		return utils.BoolToText(isOpponent, "Przeciwnik jest w drodze", "Jesteś w drodze")
+	case "PLAYER_DEAD":
+		return utils.BoolToText(isOpponent, "Przeciwnik nie żyje", "Nie żyjesz")
+	case "NOT_HERE":
+		return utils.BoolToText(isOpponent, "Przeciwnik musi być w tej samej lokacji", "Musisz być w lokacji wyzwania")
+	case "NOT_ENOUGH_GOLD":
+		return utils.BoolToText(isOpponent, "Przeciwnika nie stać na stawkę", "Nie stać cię na stawkę")
+	case "SELF_CHALLENGE":
+		return "Nie możesz wyzwać samego siebie"
+	case "INVALID_STAKE":
+		return "Stawka nie może być ujemna"
+	case "LOCATION_NOT_ALLOWED":
+		return "Pojedynki są dozwolone tylko na arenie i w mieście"
+	case "ALREADY_CHALLENGED":
+		return "Między wami czeka już wyzwanie"
+	case "CHALLENGE_NOT_FOUND":
+		return "Wyzwanie nie istnieje"
+	case "CHALLENGE_EXPIRED":
+		return "Wyzwanie wygasło"
+	case "NOT_ALLOWED":
+		return "To nie twoje wyzwanie"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func teamBattleErrorText(err error) string {
+	opponent, isOpponent := strings.CutPrefix(err.Error(), "OPPONENT_")
+
+	switch opponent {
+	case "IN_FIGHT":
+		return utils.BoolToText(isOpponent, "Ktoś z drużyny przeciwnej walczy", "Ktoś z twojej drużyny walczy")
+	case "IN_DUNGEON":
+		return utils.BoolToText(isOpponent, "Drużyna przeciwna jest w lochu", "Twoja drużyna jest w lochu")
+	case "PARTY_NOT_READY":
+		return utils.BoolToText(isOpponent, "Cała drużyna przeciwna musi być na miejscu i żywa", "Cała twoja drużyna musi być na miejscu i żywa")
+	case "PARTY_NOT_FOUND":
+		return utils.BoolToText(isOpponent, "Drużyna przeciwna już nie istnieje", "Twoja drużyna już nie istnieje")
+	case "NOT_IN_PARTY":
+		return utils.BoolToText(isOpponent, "Przeciwnik nie jest w drużynie", "Nie jesteś w drużynie")
+	case "NOT_LEADER":
+		return utils.BoolToText(isOpponent, "Przeciwnik nie jest liderem drużyny", "Tylko lider może wyzwać drużynę")
+	case "SAME_PARTY":
+		return "Nie możesz wyzwać własnej drużyny"
+	case "LOCATION_NOT_ALLOWED":
+		return "Bitwy są dozwolone tylko na arenie i w mieście"
+	case "ALREADY_CHALLENGED":
+		return "Między waszymi drużynami czeka już wyzwanie"
+	case "CHALLENGE_NOT_FOUND":
+		return "Wyzwanie nie istnieje"
+	case "CHALLENGE_EXPIRED":
+		return "Wyzwanie wygasło"
+	case "NOT_ALLOWED":
+		return "To nie twoje wyzwanie"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func guildErrorText(err error) string {
+	switch err.Error() {
+	case "IN_GUILD":
+		return "Jesteś już w gildii"
+	case "NOT_IN_GUILD":
+		return "Nie jesteś w gildii"
+	case "INVALID_NAME":
+		return fmt.Sprintf("Nazwa gildii musi mieć od 1 do %d znaków", guild.MaxNameLength)
+	case "NAME_TAKEN":
+		return "Gildia o tej nazwie już istnieje"
+	case "NOT_IN_CITY":
+		return "Możesz to zrobić tylko w mieście"
+	case "NOT_ENOUGH_GOLD":
+		return "Nie ma tyle złota"
+	case "NO_PERMISSION":
+		return "Twoja ranga na to nie pozwala"
+	case "TARGET_IN_GUILD":
+		return "Gracz jest już w gildii"
+	case "GUILD_FULL":
+		return fmt.Sprintf("Gildia jest pełna (%d członków)", guild.MaxMembers)
+	case "ALREADY_INVITED":
+		return "Gracz ma już zaproszenie"
+	case "INVITE_NOT_FOUND", "GUILD_NOT_FOUND":
+		return "Zaproszenie nie istnieje"
+	case "INVITE_EXPIRED":
+		return "Zaproszenie wygasło"
+	case "NOT_ALLOWED":
+		return "To nie twoje zaproszenie"
+	case "LEADER_CANNOT_LEAVE":
+		return "Najpierw przekaż gildię innemu członkowi"
+	case "NOT_MEMBER":
+		return "Gracz nie jest w twojej gildii"
+	case "RANK_TOO_HIGH":
+		return "Ranga tego gracza jest za wysoka"
+	case "SELF_RANK":
+		return "Nie możesz zmienić własnej rangi"
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "INVALID_AMOUNT":
+		return "Nieprawidłowa ilość"
+	case "NOT_ENOUGH_ITEMS":
+		return "Nie ma tylu przedmiotów"
+	case "ITEM_NOT_FOUND":
+		return "Przedmiot już nie istnieje"
+	case "ITEM_EQUIPPED":
+		return "Zdejmij przedmiot zanim go wpłacisz"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func travelErrorText(err error) string {
+	switch err.Error() {
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	case "TRAVELING":
+		return "Jesteś już w drodze"
+	case "ALREADY_HERE":
+		return "Już tu jesteś"
+	case "IN_DUNGEON":
+		return "Najpierw opuść loch"
+	case "LOCATION_LOCKED":
+		return "Nie możesz tam przejść"
+	case "NOTHING_TO_EXPLORE":
+		return "W mieście nie ma czego eksplorować"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func BountyText(bountyData *bounty.Bounty) string {
+	mobName := bountyData.Mob
+
+	if mob, exists := mobs.Mobs[bountyData.Mob]; exists {
+		mobName = mob.Name
+	}
+
+	text := utils.BoolToText(bountyData.Weekly, "[Tygodniowe] ", "")
+
+	switch bountyData.Type {
+	case bounty.BOUNTY_KILL:
+		text += fmt.Sprintf("Pokonaj: %s x%d", mobName, bountyData.Count)
+	case bounty.BOUNTY_GATHER:
+		text += fmt.Sprintf("Zdobądź: %s x%d (%s)", data.GetItemName(bountyData.ItemType, bountyData.Item), bountyData.Count, mobName)
+	case bounty.BOUNTY_DELIVER:
+		text += fmt.Sprintf("Dostarcz: %s x%d (%s)", data.GetItemName(bountyData.ItemType, bountyData.Item), bountyData.Count, mobName)
+	}
+
+	return text
+}
+
+func BountyBoardEmbed(playerChar *player.Player) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	day := World.Time.DayNumber()
+	streak := playerChar.CurrentStreak(day)
+
+	embed.SetTitle("Tablica zleceń")
+	embed.SetDescriptionf(
+		"Seria: %d dni (premia %d%%)\nZlecenia dzienne zmieniają się codziennie, tygodniowe co %d dni",
+		streak,
+		bounty.StreakBonus(max(streak-1, 0)),
+		bounty.WeekLength,
+	)
+
+	for _, board := range World.BountyBoards() {
+		boardText := ""
+
+		for _, bountyData := range board.All() {
+			boardText += fmt.Sprintf("- %s - %d XP, %d golda", BountyText(bountyData), bountyData.Exp, bountyData.Gold)
+
+			if slices.Contains(playerChar.Meta.Bounties.Claimed, bountyData.Uuid) {
+				boardText += " (odebrane)"
+			} else if _, active := playerChar.Meta.Bounties.Active[bountyData.Uuid]; active {
+				boardText += fmt.Sprintf(" (%d/%d)", World.BountyProgress(playerChar.GetUUID(), bountyData), bountyData.Count)
+			}
+
+			boardText += "\n"
+		}
+
+		if boardText == "" {
+			boardText = "Brak zleceń"
+		}
+
+		embed.AddField(board.Floor, boardText, false)
+	}
+
+	return embed.Build()
+}
+
+var EffectTargetToString = map[location.EffectTarget]string{
+	location.TARGET_ALL:     "wszyscy",
+	location.TARGET_PLAYERS: "gracze",
+	location.TARGET_MOBS:    "przeciwnicy",
+}
+
+func ScheduleText(schedule location.Schedule) string {
+	parts := make([]string, 0)
+
+	if len(schedule.Hours) == 2 {
+		parts = append(parts, fmt.Sprintf("%d:00-%d:00", schedule.Hours[0], schedule.Hours[1]))
+	}
+
+	for _, season := range schedule.Seasons {
+		parts = append(parts, calendar.SeasonToString(season))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func LocationEffectText(effect location.LocationEffect) string {
+	sign := "+"
+
+	if types.Effect(effect.Effect) == types.EFFECT_STAT_DEC {
+		sign = "-"
+	}
+
+	text := fmt.Sprintf("%s %s%d%s (%s)", types.StatToString[effect.Stat], sign, effect.Value, utils.BoolToText(effect.IsPercent, "%", ""), EffectTargetToString[effect.Target])
+
+	if !effect.Schedule.Always() {
+		text += " - " + ScheduleText(effect.Schedule)
+	}
+
+	return text
+}
+
+func CalendarEmbed(playerChar *player.Player) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Kalendarz")
+	embed.SetDescriptionf(
+		"Data: %s\nGodzina: %d:00\nPora roku: %s\nPora dnia: %s",
+		calendar.DateFromDayNumber(World.Time.DayNumber()),
+		World.Time.Time.Hour,
+		calendar.SeasonToString(World.Time.GetSeason()),
+		utils.BoolToText(World.Time.IsNight(), "Noc", "Dzień"),
+	)
+
+	if playerChar == nil {
+		return embed.Build()
+	}
+
+	floor := World.Floors[playerChar.Meta.Location.Floor]
+	currentLocation := floor.FindLocation(playerChar.Meta.Location.Location)
+
+	if currentLocation == nil {
+		return embed.Build()
+	}
+
+	enemyText := ""
+
+	for _, enemy := range currentLocation.Enemies {
+		if enemy.Schedule.Active(World.Time) {
+			enemyText += "- " + mobs.Mobs[enemy.Enemy].Name
+		} else {
+			enemyText += "- ~~" + mobs.Mobs[enemy.Enemy].Name + "~~"
+		}
+
+		if !enemy.Schedule.Always() {
+			enemyText += " (" + ScheduleText(enemy.Schedule) + ")"
+		}
+
+		enemyText += "\n"
+	}
+
+	if enemyText != "" {
+		embed.AddField("Przeciwnicy - "+currentLocation.Name, enemyText, false)
+	}
+
+	effectText := ""
+
+	for _, effect := range floor.ActiveEffects(*currentLocation, World.Time) {
+		effectText += "- " + LocationEffectText(effect) + "\n"
+	}
+
+	if effectText == "" {
+		effectText = "Brak"
+	}
+
+	embed.AddField("Aktywne efekty", effectText, false)
+
+	return embed.Build()
+}
+
+func eventErrorText(err error) string {
+	switch err.Error() {
+	case "EVENT_NOT_FOUND":
+		return "Nie ma takiego wydarzenia"
+	case "EVENT_ALREADY_ACTIVE":
+		return "To wydarzenie już trwa"
+	case "EVENT_CLOSED":
+		return "Nie można już dołączyć do tego wydarzenia"
+	case "LOCATION_NOT_FOUND":
+		return "Lokacja wydarzenia nie istnieje"
+	case "NOT_HERE":
+		return "Musisz być w lokacji wydarzenia, żeby dołączyć"
+	case "PLAYER_DEAD":
+		return "Nie możesz dołączyć, dopóki się nie odrodzisz"
+	case "IN_FIGHT":
+		return "Nie możesz tego zrobić podczas walki"
+	}
+
+	return "Coś poszło nie tak"
+}
+
+func EventsEmbed() discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Wydarzenia")
+
+	now := event.AbsoluteHour(World.Time)
+
+	for _, eventObj := range World.ActiveEvents() {
+		definition := data.Events[eventObj.Definition]
+		fight := World.Fights[eventObj.Fight]
+
+		if fight == nil {
+			continue
+		}
+
+		eventText := fmt.Sprintf("Lokacja: %s\n", definition.Location.Location)
+
+		if eventObj.Open {
+			eventText += fmt.Sprintf("Dołączanie jeszcze przez %d godz.\n", eventObj.Expires-now)
+		} else {
+			eventText += "Dołączanie zamknięte\n"
+		}
+
+		for idx, contributor := range event.Rank(fight.Contribution) {
+			if idx >= 5 {
+				break
+			}
+
+			if playerObj, exists := World.Players[contributor.Player]; exists {
+				eventText += fmt.Sprintf("%d. %s - %d\n", idx+1, playerObj.GetName(), contributor.Contribution.Total())
+			}
+		}
+
+		embed.AddField(definition.Name, eventText, false)
+	}
+
+	upcomingText := ""
+
+	for _, definition := range data.Events {
+		if definition.Trigger != nil {
+			upcomingText += fmt.Sprintf("- %s - co %d dni o %d:00 (%s)\n", definition.Name, definition.Trigger.EveryDays, definition.Trigger.Hour, definition.Location.Location)
+		}
+	}
+
+	for _, scheduled := range World.ScheduledEvents {
+		upcomingText += fmt.Sprintf("- %s - za %d godz.\n", data.Events[scheduled.Event].Name, scheduled.At-now)
+	}
+
+	if upcomingText == "" {
+		upcomingText = "Brak"
+	}
+
+	embed.AddField("Nadchodzące", upcomingText, false)
+
+	return embed.Build()
+}
+
+var ProfessionToString = map[gathering.Profession]string{
+	gathering.PROFESSION_HERBALISM: "Zielarstwo",
+	gathering.PROFESSION_MINING:    "Górnictwo",
+	gathering.PROFESSION_FISHING:   "Rybołówstwo",
+}
+
+func GatheringEmbed(playerChar *player.Player) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Zbieranie - " + playerChar.Meta.Location.Location)
+
+	professionText := ""
+
+	for _, profession := range []gathering.Profession{gathering.PROFESSION_HERBALISM, gathering.PROFESSION_MINING, gathering.PROFESSION_FISHING} {
+		skill := playerChar.Meta.Professions.Get(profession)
+
+		professionText += fmt.Sprintf("%s: poziom %d (%d/%d XP)\n", ProfessionToString[profession], skill.Level, skill.Exp, skill.ExpToNextLevel())
+	}
+
+	embed.AddField("Profesje", professionText, false)
+
+	currentLocation := World.Floors[playerChar.Meta.Location.Floor].FindLocation(playerChar.Meta.Location.Location)
+
+	if currentLocation == nil || len(currentLocation.Nodes) == 0 {
+		embed.SetDescription("Nie ma tu nic do zebrania")
+
+		return embed.Build()
+	}
+
+	for _, node := range currentLocation.Nodes {
+		ingredientNames := make([]string, 0)
+
+		for _, ingredientUuid := range node.Ingredients {
+			ingredientNames = append(ingredientNames, data.Ingredients[ingredientUuid].Name)
+		}
+
+		charges := World.NodeCharges(playerChar.Meta.Location, node)
+
+		embed.AddField(
+			node.Id,
+			fmt.Sprintf(
+				"%s, poziom %d\nSurowce: %s\nPozostało: %s",
+				ProfessionToString[node.Type],
+				node.Level,
+				strings.Join(ingredientNames, ", "),
+				utils.BoolToText(charges > 0, fmt.Sprintf("%d/%d", charges, node.Charges), "wyczerpane"),
+			),
+			false,
+		)
+	}
+
+	return embed.Build()
+}
+
+func DungeonRankingEmbed(dungeonId string) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	definition, exists := data.Dungeons[dungeonId]
+
+	if !exists {
+		return embed.SetTitle("Nie ma takiego lochu").Build()
+	}
+
+	embed.SetTitle("Ranking - " + definition.Name)
+
+	records := World.DungeonRecords[dungeonId]
+
+	if len(records) == 0 {
+		embed.SetDescription("Nikt jeszcze nie przeszedł tego lochu")
+
+		return embed.Build()
+	}
+
+	recordText := ""
+
+	for idx, record := range records {
+		recordText += fmt.Sprintf("%d. %s - %d tur (%s)\n", idx+1, strings.Join(record.Players, ", "), record.Turns, calendar.DateFromDayNumber(record.Day))
+	}
+
+	embed.SetDescription(recordText)
+
+	return embed.Build()
+}
+
+func DuelHistoryEmbed(playerChar *player.Player) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	record := playerChar.Meta.PvP
+
+	embed.SetTitle("Pojedynki - " + playerChar.GetName())
+	embed.SetDescriptionf("Ranking: %d\nWygrane: %d, przegrane: %d", record.Rating, record.Wins, record.Losses)
+
+	if len(record.History) == 0 {
+		embed.AddField("Historia", "Brak pojedynków", false)
+
+		return embed.Build()
+	}
+
+	historyText := ""
+
+	for i := len(record.History) - 1; i >= 0; i-- {
+		result := record.History[i]
+
+		historyText += fmt.Sprintf(
+			"%s - %s vs %s (%+d)",
+			calendar.DateFromDayNumber(result.Day),
+			utils.BoolToText(result.Won, "Wygrana", "Przegrana"),
+			result.Opponent,
+			result.RatingChange,
+		)
+
+		if result.Stake > 0 {
+			historyText += fmt.Sprintf(", stawka %d", result.Stake)
+		}
+
+		historyText += "\n"
+	}
+
+	embed.AddField("Historia", historyText, false)
+
+	return embed.Build()
+}
+
+func TeamRankingEmbed(ratings []*duel.TeamRating, results []duel.TeamResult) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Ranking drużyn")
+
+	if len(ratings) == 0 {
+		embed.SetDescription("Nie stoczono jeszcze żadnej bitwy")
+
+		return embed.Build()
+	}
+
+	rankingText := ""
+
+	for idx, rating := range ratings {
+		if idx >= 10 {
+			break
+		}
+
+		rankingText += fmt.Sprintf("%d. %s - %d (%d/%d)\n", idx+1, strings.Join(rating.Players, ", "), rating.Rating, rating.Wins, rating.Losses)
+	}
+
+	embed.SetDescription(rankingText)
+
+	resultText := ""
+
+	for i := len(results) - 1; i >= 0 && i >= len(results)-5; i-- {
+		result := results[i]
+
+		resultText += fmt.Sprintf(
+			"%s - %s pokonują %s (%+d)\n",
+			calendar.DateFromDayNumber(result.Day),
+			strings.Join(result.Winners, ", "),
+			strings.Join(result.Losers, ", "),
+			result.RatingChange,
+		)
+	}
+
+	if resultText != "" {
+		embed.AddField("Ostatnie bitwy", resultText, false)
+	}
+
+	return embed.Build()
+}
+
+func GuildEmbed(guildObj *guild.Guild) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Gildia " + guildObj.Name)
+
+	if guildObj.Level >= guild.MaxLevel {
+		embed.SetDescriptionf("Poziom: %d (maksymalny)\nZłoto w banku: %d", guildObj.Level, guildObj.Gold)
+	} else {
+		embed.SetDescriptionf("Poziom: %d (%d/%d XP)\nZłoto w banku: %d", guildObj.Level, guildObj.Exp, guild.ExpForLevel(guildObj.Level), guildObj.Gold)
+	}
+
+	members := make([]*guild.Member, 0)
+
+	for _, member := range guildObj.Members {
+		members = append(members, member)
+	}
+
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].Rank != members[j].Rank {
+			return members[i].Rank > members[j].Rank
+		}
+
+		return members[i].Contributed > members[j].Contributed
+	})
+
+	memberText := ""
+
+	for _, member := range members {
+		memberObj, exists := World.Players[member.Player]
+
+		if !exists {
+			continue
+		}
+
+		memberText += fmt.Sprintf("%s - %s, %d XP\n", memberObj.GetName(), GuildRankToString[member.Rank], member.Contributed)
+	}
+
+	embed.AddField(fmt.Sprintf("Członkowie (%d/%d)", len(guildObj.Members), guild.MaxMembers), memberText, false)
+
+	permissionText := ""
+
+	for _, rank := range []guild.Rank{guild.RANK_OFFICER, guild.RANK_MEMBER} {
+		names := make([]string, 0)
+
+		for _, permission := range []guild.Permission{guild.PERM_INVITE, guild.PERM_KICK, guild.PERM_WITHDRAW, guild.PERM_RANKS} {
+			if guildObj.Permissions[rank]&permission != 0 {
+				names = append(names, GuildPermissionToString[permission])
+			}
+		}
+
+		if len(names) == 0 {
+			names = append(names, "Brak")
+		}
+
+		permissionText += fmt.Sprintf("%s: %s\n", GuildRankToString[rank], strings.Join(names, ", "))
+	}
+
+	embed.AddField("Uprawnienia", permissionText, false)
+
+	perkText := ""
+
+	for _, perk := range guild.Perks {
+		perkText += fmt.Sprintf(
+			"%s Poz. %d: +%d%s %s\n",
+			utils.BoolToText(perk.Level <= guildObj.Level, "✅", "🔒"),
+			perk.Level,
+			perk.Value,
+			utils.BoolToText(perk.IsPercent, "%", ""),
+			types.StatToString[perk.Stat],
+		)
+	}
+
+	embed.AddField("Bonusy", perkText, false)
+
+	return embed.Build()
+}
+
+func GuildBankEmbed(guildObj *guild.Guild) discord.Embed {
+	embed := discord.NewEmbedBuilder()
+
+	embed.SetTitle("Bank gildii " + guildObj.Name)
+	embed.SetDescriptionf("Złoto: %d", guildObj.Gold)
+
+	itemText := ""
+
+	for _, item := range guildObj.Items {
+		itemText += GuildItemName(item) + "\n"
+	}
+
+	if itemText == "" {
+		itemText = "Brak przedmiotów"
+	}
+
+	embed.AddField("Przedmioty", itemText, false)
+
+	logText := ""
+
+	for i := len(guildObj.Logs) - 1; i >= 0 && i >= len(guildObj.Logs)-10; i-- {
+		entry := guildObj.Logs[i]
+
+		what := fmt.Sprintf("%d golda", entry.Gold)
+
+		if entry.Item != uuid.Nil {
+			what = fmt.Sprintf("%s x%d", data.GetItemName(entry.ItemType, entry.Item), entry.Count)
+		}
+
+		logText += fmt.Sprintf(
+			"%s - %s %s %s\n",
+			calendar.DateFromDayNumber(entry.Day),
+			entry.Player,
+			utils.BoolToText(entry.Deposit, "wpłaca", "wypłaca"),
+			what,
+		)
+	}
+
+	if logText == "" {
+		logText = "Brak operacji"
+	}
+
+	embed.AddField("Historia", logText, false)
+
+	return embed.Build()
 }
@@ -0,0 +1,128 @@
+ReservedUIDs = {
+  "00000000-0000-0003-0000-000000000001",
+  "00000000-0000-0003-0001-000000000001",
+  "00000000-0000-0003-0001-000000000002",
+  "00000000-0000-0003-0001-000000000003",
+}
+
+-- Meta
+UUID = ReservedUIDs[1]
+Name = "Furia płomieni"
+Description = "Gniew smoka, który spala wszystko na swojej drodze."
+
+-- Stats gained per level, multiplied by tier
+LvlStats = {
+  ATK = 2,
+}
+
+local function dealDamage(owner, target, fightInstance, value)
+  ---@diagnostic disable-next-line: undefined-global
+  HandleAction(fightInstance, {
+    Event = "ACTION_DMG",
+    ---@diagnostic disable-next-line: undefined-global
+    Source = GetUUID(owner),
+    ---@diagnostic disable-next-line: undefined-global
+    Target = GetUUID(target),
+    Meta = {
+      CanDodge = false,
+      Damage = {
+        {
+          Value = value,
+          Type = 1,
+        },
+      },
+    },
+  })
+end
+
+Tiers = {
+  {
+    Stats = {
+      ATK = 10,
+    },
+    Skills = { {
+      UUID = ReservedUIDs[2],
+      Name = "Płomienny cios",
+      Description = "Zadaje obrażenia magiczne równe 150% ATK.",
+      Cost = 10,
+      CD = 3,
+      Trigger = {
+        Type = "ACTIVE",
+        Event = "NONE",
+        Target = {
+          Target = "ENEMY",
+          MaxTargets = 1,
+        },
+      },
+      Execute = function(owner, target, fightInstance, meta)
+        ---@diagnostic disable-next-line: undefined-global
+        local atk = GetStat(owner, StatsConst.STAT_AD)
+
+        dealDamage(owner, target, fightInstance, utils.PercentOf(atk, 150))
+      end,
+    } },
+  },
+  {
+    Ingredients = {
+      { UUID = "00000000-0000-0000-0000-000000000000", Count = 2 },
+    },
+    Stats = {
+      ATK = 20,
+      HP = 50,
+    },
+    Skills = { {
+      UUID = ReservedUIDs[3],
+      Name = "Żar",
+      Description = "Co dwie tury atak zadaje dodatkowe obrażenia równe 10% ATK.",
+      CD = 2,
+      Trigger = {
+        Type = "PASSIVE",
+        Event = "ATTACK_BEFORE",
+      },
+      Execute = function(owner, target, fightInstance, meta)
+        ---@diagnostic disable-next-line: undefined-global
+        local atk = GetStat(owner, StatsConst.STAT_AD)
+
+        return {
+          Effects = {
+            {
+              Value = utils.PercentOf(atk, 10),
+              Type = 1,
+              Percent = false
+            },
+          }
+        }
+      end,
+    } },
+  },
+  {
+    Ingredients = {
+      { UUID = "00000000-0000-0000-0000-000000000001", Count = 3 },
+    },
+    Stats = {
+      ATK = 40,
+      AP = 20,
+    },
+    Skills = { {
+      UUID = ReservedUIDs[4],
+      Name = "Inferno",
+      Description = "Zadaje wszystkim wrogom obrażenia magiczne równe 100% ATK.",
+      Cost = 30,
+      CD = 5,
+      Trigger = {
+        Type = "ACTIVE",
+        Event = "NONE",
+        Target = {
+          Target = "ENEMY",
+          MaxTargets = -1,
+        },
+      },
+      Execute = function(owner, target, fightInstance, meta)
+        ---@diagnostic disable-next-line: undefined-global
+        local atk = GetStat(owner, StatsConst.STAT_AD)
+
+        dealDamage(owner, target, fightInstance, atk)
+      end,
+    } },
+  },
+}
@@ -0,0 +1,97 @@
+ReservedUIDs = {
+  "00000000-0000-0003-0000-000000000002",
+  "00000000-0000-0003-0002-000000000001",
+  "00000000-0000-0003-0002-000000000002",
+}
+
+-- Meta
+UUID = ReservedUIDs[1]
+Name = "Furia mrozu"
+Description = "Chłodny spokój zamknięty w krysztale lodu."
+
+-- Stats gained per level, multiplied by tier
+LvlStats = {
+  DEF = 2,
+  HP = 5,
+}
+
+Tiers = {
+  {
+    Stats = {
+      DEF = 10,
+      MR = 10,
+    },
+    Skills = { {
+      UUID = ReservedUIDs[2],
+      Name = "Lodowy oddech",
+      Description = "Leczy 20% brakującego zdrowia.",
+      Cost = 15,
+      CD = 4,
+      Trigger = {
+        Type = "ACTIVE",
+        Event = "NONE",
+      },
+      Execute = function(owner, target, fightInstance, meta)
+        ---@diagnostic disable-next-line: undefined-global
+        local missingHP = GetStat(owner, StatsConst.STAT_HP) - GetCurrentHP(owner)
+
+        ---@diagnostic disable-next-line: undefined-global
+        HandleAction(fightInstance, {
+          Event = "ACTION_EFFECT",
+          ---@diagnostic disable-next-line: undefined-global
+          Source = GetUUID(owner),
+          ---@diagnostic disable-next-line: undefined-global
+          Target = GetUUID(owner),
+          Meta = {
+            Effect = "EFFECT_HEAL",
+            Value = 0,
+            Duration = 0,
+            Uuid = utils.GenerateUUID(),
+            ---@diagnostic disable-next-line: undefined-global
+            Target = GetUUID(owner),
+            ---@diagnostic disable-next-line: undefined-global
+            Caster = GetUUID(owner),
+            OnExpire = function() end,
+            Meta = {
+              Value = utils.PercentOf(missingHP, 20)
+            }
+          },
+        })
+      end,
+    } },
+  },
+  {
+    Ingredients = {
+      { UUID = "00000000-0000-0000-0000-000000000001", Count = 2 },
+    },
+    Stats = {
+      DEF = 25,
+      MR = 25,
+      HP = 100,
+    },
+    Skills = { {
+      UUID = ReservedUIDs[3],
+      Name = "Szron",
+      Description = "Co trzy tury atak zadaje dodatkowe obrażenia równe 20% DEF.",
+      CD = 3,
+      Trigger = {
+        Type = "PASSIVE",
+        Event = "ATTACK_BEFORE",
+      },
+      Execute = function(owner, target, fightInstance, meta)
+        ---@diagnostic disable-next-line: undefined-global
+        local def = GetStat(owner, StatsConst.STAT_DEF)
+
+        return {
+          Effects = {
+            {
+              Value = utils.PercentOf(def, 20),
+              Type = 0,
+              Percent = false
+            },
+          }
+        }
+      end,
+    } },
+  },
+}
@@ -9,11 +9,13 @@ UUID = ReservedUIDs[1]
 Name = "Ognisty trybularz"
 Description = "Leczenie i tarcze zwiększają obrażenia i prędkość sojusznika."
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "EPIC"
 
 -- Stats
 Stats = {
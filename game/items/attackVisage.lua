@@ -9,11 +9,14 @@ UUID = ReservedUIDs[1]
 Name = "Oblicze ataku"
 Description = "Dostajesz HP w zależności od ATK."
 TakesSlot = true
+Slot = "HEAD"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
+Durability = 120
 
 -- Stats
 Stats = {
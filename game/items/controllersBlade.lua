@@ -9,11 +9,14 @@ UUID = ReservedUIDs[1]
 Name = "Ostrze kontrolera"
 Description = "Atakowanie zmniejsza prędkość wrogów."
 TakesSlot = true
+Slot = "WEAPON"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
+Durability = 100
 
 -- Stats
 Stats = {
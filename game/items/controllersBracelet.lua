@@ -8,11 +8,13 @@ UUID = ReservedUIDs[1]
 Name = "Bransoleta kontrolera"
 Description = "Nałożenie efektu CC leczy ciebie i sojusznika."
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
 
 -- Stats
 Stats = {
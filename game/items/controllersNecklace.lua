@@ -9,11 +9,13 @@ UUID = ReservedUIDs[1]
 Name = "Naszyjnik kontrolera"
 Description = "Nałożenie efektu CC zwiększa twoją prędkość."
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
 
 -- Stats
 Stats = {
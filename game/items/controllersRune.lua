@@ -9,11 +9,13 @@ UUID = ReservedUIDs[1]
 Name = "Runa kontrolera"
 Description = "Zabicie wroga objętego CC przywraca manę."
 TakesSlot = true
+Slot = "RUNE"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
 
 -- Stats
 Stats = {
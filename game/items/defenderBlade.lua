@@ -8,11 +8,14 @@ UUID = ReservedUIDs[1]
 Name = "Ostrze obrońcy"
 Description = "Zwiększa ataki o twój RES i DEF."
 TakesSlot = true
+Slot = "WEAPON"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
+Durability = 100
 
 -- Stats
 Stats = {
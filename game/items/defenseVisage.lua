@@ -9,11 +9,14 @@ UUID = ReservedUIDs[1]
 Name = "Oblicze obrony"
 Description = "Dostajesz ATK w zależności od maks. HP."
 TakesSlot = true
+Slot = "HEAD"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
+Durability = 120
 
 -- Stats
 Stats = {
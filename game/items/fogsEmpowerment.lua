@@ -8,11 +8,13 @@ UUID = ReservedUIDs[1]
 Name = "Mgliste wzmocenienie"
 Description = "Otrzymujesz AP w zależności od siły leczenia i tarcz."
 TakesSlot = true
+Slot = "RUNE"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "UNCOMMON"
 
 -- Stats
 Stats = {
@@ -0,0 +1,18 @@
+ReservedUIDs = {
+  "00000000-0000-0000-0000-000000000201",
+}
+
+UUID = ReservedUIDs[1]
+Name = "Kryształ mrozu"
+Description = "Skrywa w sobie furię mrozu. Użyj go, by ją przebudzić."
+
+TakesSlot = false
+Stacks = false
+Consume = true
+Count = 1
+MaxCount = 1
+Hidden = false
+Rarity = "EPIC"
+Fury = "00000000-0000-0003-0000-000000000002"
+
+Stats = {}
@@ -8,11 +8,14 @@ UUID = ReservedUIDs[1]
 Name = "Pogromca gigantów"
 Description = "Zadaje dodatkowe obrażenia w zależności od pancerza przeciwnika."
 TakesSlot = true
+Slot = "WEAPON"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "EPIC"
+Durability = 100
 
 -- Stats
 Stats = {
@@ -8,11 +8,14 @@ UUID = ReservedUIDs[1]
 Name = "Pancerz zwady"
 Description = "Zadaje obrażenia wrogom, którzy cię uderzają i zmniejsza ich leczenie."
 TakesSlot = true
+Slot = "ARMOUR"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
+Durability = 150
 
 -- Stats
 Stats = {
@@ -8,11 +8,13 @@ UUID = ReservedUIDs[1]
 Name = "Pasek Kyoki"
 Description = "Obrażenia magiczne są zwiększone przez losowy mnożnik (0.8-1.8)."
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
 
 -- Stats
 Stats = {
@@ -8,11 +8,14 @@ UUID = ReservedUIDs[1]
 Name = "Gniew Lilith"
 Description = "Co ture zadaje obrażenia w zależności od zdrowia użytkownika."
 TakesSlot = true
+Slot = "WEAPON"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "LEGENDARY"
+Durability = 100
 
 -- Stats
 Stats = {
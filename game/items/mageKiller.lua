@@ -8,11 +8,14 @@ UUID = ReservedUIDs[1]
 Name = "Zabójca magów"
 Description = "Atakowanie celi osłoniętych tarczą zwiększa obrażenia twojego ataku."
 TakesSlot = true
+Slot = "WEAPON"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "RARE"
+Durability = 100
 
 -- Stats
 Stats = {
@@ -13,6 +13,7 @@ Consume = true
 Count = 1
 MaxCount = 5
 Hidden = false
+Rarity = "UNCOMMON"
 
 Stats = {}
 
@@ -9,11 +9,13 @@ UUID = ReservedUIDs[1]
 Name = "Błogosławieństwo Reimi"
 Description = "Przeleczenie daje tarczę."
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "LEGENDARY"
 
 -- Stats
 Stats = {
@@ -8,11 +8,14 @@ UUID = ReservedUIDs[1]
 Name = "Piaskowe ostrze"
 Description = "Zadawanie obrażeń zmniejsza leczenie wroga."
 TakesSlot = true
+Slot = "WEAPON"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "UNCOMMON"
+Durability = 100
 
 -- Stats
 Stats = {
@@ -5,11 +5,13 @@ UUID = ReservedUIDs[1]
 Name = "Drugi oddech"
 Description = "Zwiększa otrzymywane leczenie."
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "UNCOMMON"
 
 -- Stats
 Stats = {
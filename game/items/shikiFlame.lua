@@ -8,11 +8,13 @@ UUID = ReservedUIDs[1]
 Name = "PŁomień Shiki"
 Description = "Obrażenia magiczne są zwiększone w zależności od zdrowia wroga"
 TakesSlot = true
+Slot = "RUNE"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "LEGENDARY"
 
 -- Stats
 Stats = {
@@ -8,11 +8,13 @@ UUID = ReservedUIDs[1]
 Name = "Syreni śpiew"
 Description = "Leczenie i tarcze przeskakują na sojusznika"
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "EPIC"
 
 -- Stats
 Stats = {
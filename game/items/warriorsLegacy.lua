@@ -8,11 +8,13 @@ UUID = ReservedUIDs[1]
 Name = "Dziedzictwo wojownika"
 Description = "Zwiększa obrażenia w zależności od maks zdrowia."
 TakesSlot = true
+Slot = "ACCESSORY"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "LEGENDARY"
 
 -- Stats
 Stats = {
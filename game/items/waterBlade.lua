@@ -8,11 +8,14 @@ UUID = ReservedUIDs[1]
 Name = "Wodne ostrze"
 Description = "Zadawanie obrażeń leczy o brakujące zdrowie."
 TakesSlot = true
+Slot = "WEAPON"
 Stacks = false
 Consume = false
 Count = 1
 MaxCount = 1
 Hidden = false
+Rarity = "UNCOMMON"
+Durability = 100
 
 -- Stats
 Stats = {
@@ -8,11 +8,13 @@ Name = "Smok"
 Const = {
   ITEM = 0,
   EXP = 1,
-  GOLD = 2
+  GOLD = 2,
+  FURY = 3
 }
 
 --Loot
 Loot = {
   { Type = Const.EXP,  Count = 130 },
-  { Type = Const.GOLD, Count = 315 }
+  { Type = Const.GOLD, Count = 315 },
+  { Type = Const.FURY, Fury = "00000000-0000-0003-0000-000000000001", Chance = 25, FirstKill = true }
 }
\ No newline at end of file
@@ -0,0 +1,18 @@
+--Base
+Id = "LV0_Upior"
+HP = 110
+SPD = 45
+ATK = 45
+Name = "Upiór"
+
+Const = {
+  ITEM = 0,
+  EXP = 1,
+  GOLD = 2
+}
+
+--Loot
+Loot = {
+  { Type = Const.EXP,  Count = 110 },
+  { Type = Const.GOLD, Count = 120 }
+}
\ No newline at end of file
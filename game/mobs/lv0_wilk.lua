@@ -13,6 +13,7 @@ Const = {
 
 --Loot
 Loot = {
+  { Type = Const.ITEM, Item = "00000000-0000-0000-0000-000000000101", Chance = 25, Min = 1, Max = 2 },
   { Type = Const.EXP,  Count = 80 },
   { Type = Const.GOLD, Count = 85 }
 }
\ No newline at end of file
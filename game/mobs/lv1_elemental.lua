@@ -5,6 +5,16 @@ SPD = 45
 ATK = 50
 Name = "Żywiołak"
 
+Stats = {
+  AP = 65,
+  MR = 25
+}
+
+Affinities = {
+  DMG_MAGICAL = 25,
+  DMG_PHYSICAL = -10
+}
+
 Const = {
   ITEM = 0,
   EXP = 1,
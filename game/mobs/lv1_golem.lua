@@ -5,6 +5,16 @@ SPD = 20
 ATK = 60
 Name = "Golem"
 
+Stats = {
+  DEF = 30,
+  MR = 5
+}
+
+Affinities = {
+  DMG_PHYSICAL = 20,
+  DMG_MAGICAL = -15
+}
+
 Const = {
   ITEM = 0,
   EXP = 1,
@@ -14,5 +24,15 @@ Const = {
 --Loot
 Loot = {
   { Type = Const.EXP,  Count = 115 },
-  { Type = Const.GOLD, Count = 190 }
+  { Type = Const.GOLD, Min = 170, Max = 210 },
+  { Type = Const.ITEM, Ingredient = "00000000-0000-0000-0000-000000000001", Rarity = "EPIC", FirstKill = true },
+  {
+    Type = Const.ITEM,
+    Chance = 40,
+    Rolls = 1,
+    Pool = {
+      { Type = Const.ITEM, Ingredient = "00000000-0000-0000-0000-000000000000", Rarity = "UNCOMMON", Weight = 70 },
+      { Type = Const.ITEM, Ingredient = "00000000-0000-0000-0000-000000000001", Rarity = "RARE",     Weight = 30 }
+    }
+  }
 }
\ No newline at end of file
@@ -5,6 +5,10 @@ SPD = 35
 ATK = 60
 Name = "Strach na wróble"
 
+Resist = {
+  { DmgType = "DMG_PHYSICAL", Value = 10, IsPercent = false }
+}
+
 Const = {
   ITEM = 0,
   EXP = 1,
@@ -5,6 +5,10 @@ SPD = 45
 ATK = 50
 Name = "Jadowity Pająk"
 
+Stats = {
+  AGL = 15
+}
+
 Const = {
   ITEM = 0,
   EXP = 1,
@@ -5,13 +5,13 @@ go 1.22
 toolchain go1.22.2
 
 require (
+	github.com/Shopify/go-lua v0.0.0-20240527182111-9ab1540f3f5f
 	github.com/disgoorg/disgo v0.18.8
 	github.com/disgoorg/snowflake/v2 v2.0.1
 	github.com/google/uuid v1.6.0
 )
 
 require (
-	github.com/Shopify/go-lua v0.0.0-20240527182111-9ab1540f3f5f // indirect
 	github.com/disgoorg/json v1.1.0 // indirect
 	github.com/gorilla/websocket v1.5.3 // indirect
 	github.com/sasha-s/go-csync v0.0.0-20240107134140-fcbab37b09ad // indirect
@@ -222,8 +222,8 @@ func AddFightFunctions(state *lua.State) {
 				value := value.(map[string]interface{})
 
 				dmg.Damage = append(dmg.Damage, types.Damage{
-					Value: value["Value"].(int),
-					Type:  types.DamageType(value["Type"].(int)),
+					Value: int(value["Value"].(float64)),
+					Type:  types.DamageType(value["Type"].(float64)),
 				})
 			}
 
@@ -593,13 +593,35 @@ func ReadMapAsTrigger(dataMap map[string]interface{}) types.Trigger {
 				trigger.Cooldown = &cooldown
 			}
 		case "Flags":
-			trigger.Flags = types.SkillFlag(value.(int))
+			trigger.Flags = types.SkillFlag(value.(float64))
+		case "Target":
+			target := types.TargetTrigger{
+				Target:     types.TARGET_SELF,
+				MaxTargets: 1,
+			}
+
+			for key, value := range value.(map[string]interface{}) {
+				switch key {
+				case "Target":
+					target.Target = StringToTargetTag[value.(string)]
+				case "MaxTargets":
+					target.MaxTargets = int(value.(float64))
+				}
+			}
+
+			trigger.Target = &target
 		}
 	}
 
 	return trigger
 }
 
+var StringToTargetTag = map[string]types.TargetTag{
+	"SELF":  types.TARGET_SELF,
+	"ENEMY": types.TARGET_ENEMY,
+	"ALLY":  types.TARGET_ALLY,
+}
+
 var StringToActionEvent map[string]types.ActionEnum = map[string]types.ActionEnum{
 	"ACTION_ATTACK":  types.ACTION_ATTACK,
 	"ACTION_DEFEND":  types.ACTION_DEFEND,
@@ -832,8 +854,12 @@ func ParseActionReturn(dataMap map[string]interface{}, state *lua.State) types.A
 	switch act.Event {
 	case types.ACTION_SUMMON:
 		panic("Summon not implemented")
-	case types.ACTION_DMG:
-		localMeta := dataMap["Meta"].(map[string]interface{})
+	case types.ACTION_DMG, types.ACTION_ATTACK:
+		localMeta, ok := dataMap["Meta"].(map[string]interface{})
+
+		if !ok {
+			break
+		}
 
 		dmg := types.ActionDamage{
 			Damage:   make([]types.Damage, 0),
@@ -844,8 +870,9 @@ func ParseActionReturn(dataMap map[string]interface{}, state *lua.State) types.A
 			value := value.(map[string]interface{})
 
 			dmg.Damage = append(dmg.Damage, types.Damage{
-				Value: value["Value"].(int),
-				Type:  types.DamageType(value["Type"].(int)),
+				Value:    int(value["Value"].(float64)),
+				Type:     StringToDamageType[value["Type"].(string)],
+				CanDodge: dmg.CanDodge,
 			})
 		}
 
@@ -933,8 +960,12 @@ func SerializeAction(act types.Action, state *lua.State) {
 	switch act.Event {
 	case types.ACTION_SUMMON:
 		panic("Summon not implemented")
-	case types.ACTION_DMG:
-		dmg := act.Meta.(types.ActionDamage)
+	case types.ACTION_DMG, types.ACTION_ATTACK:
+		dmg, ok := act.Meta.(types.ActionDamage)
+
+		if !ok {
+			break
+		}
 
 		state.NewTable()
 
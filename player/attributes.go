@@ -0,0 +1,176 @@
+package player
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"sao/config"
+	"sao/types"
+	"sao/utils"
+	"strconv"
+)
+
+type Archetype struct {
+	Name        string
+	Description string
+	//Added to starting stats once archetype is picked
+	Stats map[types.Stat]int
+	//Extra points that can be spent in stat above default cap
+	CapBonus map[types.Stat]int
+}
+
+type PlayerAttributes struct {
+	//Empty until player picks one
+	Archetype string
+	//Points spent in each stat
+	Allocated map[types.Stat]int
+}
+
+var Archetypes = GetArchetypes()
+
+func GetArchetypes() map[string]Archetype {
+	rawData, err := os.ReadFile(config.Config.GameDataLocation + "/players/archetypes.json")
+
+	if err != nil {
+		panic(err)
+	}
+
+	var parsedData []map[string]interface{}
+
+	err = json.Unmarshal(rawData, &parsedData)
+
+	if err != nil {
+		panic(err)
+	}
+
+	archetypes := make(map[string]Archetype)
+
+	for _, rawArchetype := range parsedData {
+		archetype := Archetype{
+			Name:        rawArchetype["Name"].(string),
+			Description: rawArchetype["Description"].(string),
+			Stats:       make(map[types.Stat]int),
+			CapBonus:    make(map[types.Stat]int),
+		}
+
+		if rawStats, ok := rawArchetype["Stats"].(map[string]interface{}); ok {
+			for key, value := range rawStats {
+				archetype.Stats[utils.StringToStat[key]] = int(value.(float64))
+			}
+		}
+
+		if rawCaps, ok := rawArchetype["CapBonus"].(map[string]interface{}); ok {
+			for key, value := range rawCaps {
+				archetype.CapBonus[utils.StringToStat[key]] = int(value.(float64))
+			}
+		}
+
+		archetypes[archetype.Name] = archetype
+	}
+
+	return archetypes
+}
+
+func (a *PlayerAttributes) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"archetype": a.Archetype,
+		"allocated": a.Allocated,
+	}
+}
+
+func DeserializeAttributes(data map[string]interface{}) PlayerAttributes {
+	attributes := PlayerAttributes{
+		Archetype: data["archetype"].(string),
+		Allocated: make(map[types.Stat]int),
+	}
+
+	for key, value := range data["allocated"].(map[string]interface{}) {
+		stat, _ := strconv.Atoi(key)
+
+		attributes.Allocated[types.Stat(stat)] = int(value.(float64))
+	}
+
+	return attributes
+}
+
+func (p *Player) FreeStatPoints() int {
+	free := (p.XP.Level - 1) * Default.PointsPerLevel
+
+	for _, points := range p.Attributes.Allocated {
+		free -= points
+	}
+
+	return free
+}
+
+// Max points that can be spent in stat, 0 if stat can't be raised with points
+func (p *Player) StatCap(stat types.Stat) int {
+	if _, allocatable := Default.PointValues[stat]; !allocatable {
+		return 0
+	}
+
+	statCap := Default.StatCaps[stat]
+
+	if archetype, exists := Archetypes[p.Attributes.Archetype]; exists {
+		statCap += archetype.CapBonus[stat]
+	}
+
+	return statCap
+}
+
+// Stat value given by spent points
+func (p *Player) GetAllocatedStat(stat types.Stat) int {
+	return p.Attributes.Allocated[stat] * Default.PointValues[stat]
+}
+
+func (p *Player) AllocateStat(stat types.Stat, points int) error {
+	if p.Meta.FightInstance != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	if points < 1 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	statCap := p.StatCap(stat)
+
+	if statCap == 0 {
+		return errors.New("STAT_NOT_ALLOCATABLE")
+	}
+
+	if p.FreeStatPoints() < points {
+		return errors.New("NO_POINTS")
+	}
+
+	if p.Attributes.Allocated[stat]+points > statCap {
+		return errors.New("STAT_CAP_REACHED")
+	}
+
+	p.Attributes.Allocated[stat] += points
+
+	return nil
+}
+
+// Archetype can be picked only once
+func (p *Player) ChooseArchetype(name string) error {
+	if p.Attributes.Archetype != "" {
+		return errors.New("ARCHETYPE_ALREADY_CHOSEN")
+	}
+
+	archetype, exists := Archetypes[name]
+
+	if !exists {
+		return errors.New("ARCHETYPE_NOT_FOUND")
+	}
+
+	p.DefaultStats = maps.Clone(p.DefaultStats)
+
+	for stat, value := range archetype.Stats {
+		p.DefaultStats[stat] += value
+	}
+
+	p.Attributes.Archetype = name
+
+	return nil
+}
@@ -0,0 +1,130 @@
+package player
+
+import (
+	"errors"
+	"sao/world/bounty"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Max accepted bounties at once
+const MaxBounties = 5
+
+type BountyLog struct {
+	//Bounty uuid => kills or gathered items, unused for deliveries
+	Active  map[uuid.UUID]int
+	Claimed []uuid.UUID
+	//Consecutive calendar days with claimed daily bounty
+	Streak int
+	//-1 if player never claimed daily bounty
+	LastClaimDay int
+}
+
+func NewBountyLog() BountyLog {
+	return BountyLog{
+		Active:       make(map[uuid.UUID]int),
+		Claimed:      make([]uuid.UUID, 0),
+		Streak:       0,
+		LastClaimDay: -1,
+	}
+}
+
+func (b *BountyLog) Serialize() map[string]interface{} {
+	active := make(map[string]int)
+
+	for bountyUuid, progress := range b.Active {
+		active[bountyUuid.String()] = progress
+	}
+
+	claimed := make([]string, 0)
+
+	for _, bountyUuid := range b.Claimed {
+		claimed = append(claimed, bountyUuid.String())
+	}
+
+	return map[string]interface{}{
+		"active":     active,
+		"claimed":    claimed,
+		"streak":     b.Streak,
+		"last_claim": b.LastClaimDay,
+	}
+}
+
+func DeserializeBountyLog(data map[string]interface{}) BountyLog {
+	log := NewBountyLog()
+
+	for key, value := range data["active"].(map[string]interface{}) {
+		log.Active[uuid.MustParse(key)] = int(value.(float64))
+	}
+
+	for _, value := range data["claimed"].([]interface{}) {
+		log.Claimed = append(log.Claimed, uuid.MustParse(value.(string)))
+	}
+
+	log.Streak = int(data["streak"].(float64))
+	log.LastClaimDay = int(data["last_claim"].(float64))
+
+	return log
+}
+
+func (p *Player) AcceptBounty(bountyData *bounty.Bounty) error {
+	if _, active := p.Meta.Bounties.Active[bountyData.Uuid]; active {
+		return errors.New("ALREADY_ACCEPTED")
+	}
+
+	if slices.Contains(p.Meta.Bounties.Claimed, bountyData.Uuid) {
+		return errors.New("ALREADY_CLAIMED")
+	}
+
+	if len(p.Meta.Bounties.Active) >= MaxBounties {
+		return errors.New("TOO_MANY_BOUNTIES")
+	}
+
+	p.Meta.Bounties.Active[bountyData.Uuid] = 0
+
+	return nil
+}
+
+// Current streak is kept only if player claimed daily bounty yesterday or today
+func (p *Player) CurrentStreak(day int) int {
+	if p.Meta.Bounties.LastClaimDay < day-1 {
+		return 0
+	}
+
+	return p.Meta.Bounties.Streak
+}
+
+// Moves bounty to claimed and returns reward bonus in percent
+func (p *Player) ClaimBounty(bountyData *bounty.Bounty, day int) int {
+	delete(p.Meta.Bounties.Active, bountyData.Uuid)
+
+	p.Meta.Bounties.Claimed = append(p.Meta.Bounties.Claimed, bountyData.Uuid)
+
+	if !bountyData.Weekly && p.Meta.Bounties.LastClaimDay != day {
+		p.Meta.Bounties.Streak = p.CurrentStreak(day) + 1
+		p.Meta.Bounties.LastClaimDay = day
+	}
+
+	//First day of streak gives no bonus
+	return bounty.StreakBonus(max(p.CurrentStreak(day)-1, 0))
+}
+
+// Drops bounties that are no longer on any board
+func (p *Player) PruneBounties(exists func(bountyUuid uuid.UUID) bool) {
+	for bountyUuid := range p.Meta.Bounties.Active {
+		if !exists(bountyUuid) {
+			delete(p.Meta.Bounties.Active, bountyUuid)
+		}
+	}
+
+	claimed := make([]uuid.UUID, 0)
+
+	for _, bountyUuid := range p.Meta.Bounties.Claimed {
+		if exists(bountyUuid) {
+			claimed = append(claimed, bountyUuid)
+		}
+	}
+
+	p.Meta.Bounties.Claimed = claimed
+}
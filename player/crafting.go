@@ -0,0 +1,120 @@
+package player
+
+import (
+	"sao/types"
+	"sao/utils"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Success chance gained per crafting level above recipe level
+const CraftingChancePerLevel = 5
+
+type CraftingSkill struct {
+	Level int
+	Exp   int
+	//Discovered hidden recipes
+	Recipes []uuid.UUID
+}
+
+type CraftResult struct {
+	Success bool
+	Quality types.Quality
+	Exp     int
+	LevelUp bool
+}
+
+func (c *CraftingSkill) ExpToNextLevel() int {
+	return c.Level * 100
+}
+
+// Returns true on level up
+func (c *CraftingSkill) AddExp(value int) bool {
+	c.Exp += value
+
+	leveled := false
+
+	for c.Exp >= c.ExpToNextLevel() {
+		c.Exp -= c.ExpToNextLevel()
+		c.Level++
+
+		leveled = true
+	}
+
+	return leveled
+}
+
+func (c *CraftingSkill) Knows(recipe types.Recipe) bool {
+	return !recipe.Hidden || slices.Contains(c.Recipes, recipe.UUID)
+}
+
+// Returns false if recipe was already known
+func (c *CraftingSkill) Learn(recipe types.Recipe) bool {
+	if c.Knows(recipe) {
+		return false
+	}
+
+	c.Recipes = append(c.Recipes, recipe.UUID)
+
+	return true
+}
+
+func (c *CraftingSkill) SuccessChance(recipe types.Recipe) int {
+	chance := recipe.Chance + (c.Level-recipe.Level)*CraftingChancePerLevel
+
+	if chance > 100 {
+		return 100
+	}
+
+	if chance < 0 {
+		return 0
+	}
+
+	return chance
+}
+
+// Every level above recipe makes better quality more likely
+func (c *CraftingSkill) RollQuality(recipe types.Recipe) types.Quality {
+	bonus := (c.Level - recipe.Level) * 2
+	roll := utils.RandomNumber(0, 99) - bonus
+
+	switch {
+	case roll < 2:
+		return types.QUALITY_MASTERWORK
+	case roll < 10:
+		return types.QUALITY_EXCELLENT
+	case roll < 30:
+		return types.QUALITY_GOOD
+	}
+
+	return types.QUALITY_NORMAL
+}
+
+func (c *CraftingSkill) Serialize() map[string]interface{} {
+	recipes := make([]string, 0)
+
+	for _, recipe := range c.Recipes {
+		recipes = append(recipes, recipe.String())
+	}
+
+	return map[string]interface{}{
+		"level":   c.Level,
+		"exp":     c.Exp,
+		"recipes": recipes,
+	}
+}
+
+func DeserializeCraftingSkill(data map[string]interface{}) CraftingSkill {
+	skill := CraftingSkill{
+		Level:   int(data["level"].(float64)),
+		Exp:     int(data["exp"].(float64)),
+		Recipes: make([]uuid.UUID, 0),
+	}
+
+	for _, recipe := range data["recipes"].([]interface{}) {
+		skill.Recipes = append(skill.Recipes, uuid.MustParse(recipe.(string)))
+	}
+
+	return skill
+}
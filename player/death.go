@@ -0,0 +1,81 @@
+package player
+
+import (
+	"errors"
+	"sao/types"
+	"sao/utils"
+)
+
+type DeathPenalty struct {
+	//Percent of exp needed for next level
+	XPPercent   int
+	GoldPercent int
+	//Chance to drop single unequipped item
+	ItemDropChance int
+	//Calendar days dropped item stays on the ground
+	DropDays int
+}
+
+type DeathResult struct {
+	ExpLost  int
+	GoldLost int
+	//nil if nothing dropped
+	Dropped *types.PlayerItem
+}
+
+// Exp loss stops at 0 so player never loses a level
+func (p *Player) ApplyDeathPenalty() DeathResult {
+	penalty := Default.DeathPenalty
+	result := DeathResult{}
+
+	result.ExpLost = min(p.XP.Exp, utils.PercentOf((p.XP.Level*100)+100, penalty.XPPercent))
+	p.XP.Exp -= result.ExpLost
+
+	result.GoldLost = utils.PercentOf(p.Inventory.Gold, penalty.GoldPercent)
+	p.Inventory.Gold -= result.GoldLost
+
+	if penalty.ItemDropChance > 0 && utils.RandomNumber(1, 100) <= penalty.ItemDropChance {
+		droppable := make([]int, 0)
+
+		for idx, item := range p.Inventory.Items {
+			if !item.Equipped && !item.Hidden {
+				droppable = append(droppable, idx)
+			}
+		}
+
+		if len(droppable) > 0 {
+			idx := droppable[utils.RandomNumber(0, len(droppable)-1)]
+
+			result.Dropped = p.Inventory.Items[idx]
+
+			p.RemoveItem(idx)
+		}
+	}
+
+	return result
+}
+
+// Hardcore can be turned on only before character gains any exp
+func (p *Player) EnableHardcore() error {
+	if p.Meta.Hardcore {
+		return errors.New("ALREADY_HARDCORE")
+	}
+
+	if p.XP.Level > 1 || p.XP.Exp > 0 {
+		return errors.New("HARDCORE_TOO_LATE")
+	}
+
+	p.Meta.Hardcore = true
+
+	return nil
+}
+
+func (p *Player) TotalKills() int {
+	total := 0
+
+	for _, count := range p.Meta.Kills {
+		total += count
+	}
+
+	return total
+}
@@ -0,0 +1,20 @@
+package player
+
+// Lockouts map dungeon id to absolute calendar hour when player can enter again
+func (p *Player) LockoutEnd(dungeonId string, now int) (int, bool) {
+	end, exists := p.Meta.Lockouts[dungeonId]
+
+	if !exists || end <= now {
+		return 0, false
+	}
+
+	return end, true
+}
+
+func (p *Player) LockOut(dungeonId string, until int) {
+	if p.Meta.Lockouts == nil {
+		p.Meta.Lockouts = make(map[string]int)
+	}
+
+	p.Meta.Lockouts[dungeonId] = until
+}
@@ -0,0 +1,212 @@
+package player
+
+import (
+	"errors"
+	"sao/types"
+
+	"github.com/google/uuid"
+)
+
+const MaxLoadouts = 5
+
+func (p *Player) triggerUnlock(item *types.PlayerItem) {
+	for _, effect := range item.Effects {
+		effectEvents := effect.GetEvents()
+
+		if _, exists := effectEvents[types.CUSTOM_TRIGGER_UNLOCK]; exists {
+			effectEvents[types.CUSTOM_TRIGGER_UNLOCK](p)
+		}
+	}
+}
+
+func (p *Player) removeDerivedStats(item *types.PlayerItem) {
+	for _, effect := range item.Effects {
+		if unlock, exists := effect.GetEvents()[types.CUSTOM_TRIGGER_UNLOCK]; exists {
+			p.revertUnlock(unlock)
+		}
+	}
+}
+
+func (p *Player) unequip(item *types.PlayerItem) {
+	//Broken items had their stats removed already
+	if !item.Broken() {
+		p.removeDerivedStats(item)
+	}
+
+	item.Equipped = false
+}
+
+func (p *Player) equip(item *types.PlayerItem) []*types.PlayerItem {
+	replaced := make([]*types.PlayerItem, 0)
+
+	inSlot := p.Inventory.EquippedInSlot(item.Slot)
+
+	//Oldest items in slot are taken off first
+	for len(inSlot) >= types.SlotCapacity[item.Slot] {
+		p.unequip(inSlot[0])
+
+		replaced = append(replaced, inSlot[0])
+		inSlot = inSlot[1:]
+	}
+
+	item.Equipped = true
+
+	if !item.Broken() {
+		p.triggerUnlock(item)
+	}
+
+	return replaced
+}
+
+// Older saves can have more items equipped than slot allows, extra ones are taken off
+func (p *Player) fitEquipmentSlots() {
+	for slot, capacity := range types.SlotCapacity {
+		inSlot := p.Inventory.EquippedInSlot(slot)
+
+		for len(inSlot) > capacity {
+			p.unequip(inSlot[len(inSlot)-1])
+
+			inSlot = inSlot[:len(inSlot)-1]
+		}
+	}
+}
+
+// Returns items that had to be taken off to make space
+func (p *Player) Equip(itemIdx int) ([]*types.PlayerItem, error) {
+	if p.Meta.FightInstance != nil {
+		return nil, errors.New("IN_FIGHT")
+	}
+
+	if itemIdx < 0 || itemIdx >= len(p.Inventory.Items) {
+		return nil, errors.New("ITEM_NOT_FOUND")
+	}
+
+	item := p.Inventory.Items[itemIdx]
+
+	if item.Slot == types.SLOT_NONE {
+		return nil, errors.New("NOT_EQUIPPABLE")
+	}
+
+	if item.Equipped {
+		return nil, errors.New("ALREADY_EQUIPPED")
+	}
+
+	return p.equip(item), nil
+}
+
+func (p *Player) Unequip(itemIdx int) error {
+	if p.Meta.FightInstance != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	if itemIdx < 0 || itemIdx >= len(p.Inventory.Items) {
+		return errors.New("ITEM_NOT_FOUND")
+	}
+
+	item := p.Inventory.Items[itemIdx]
+
+	if !item.Equipped {
+		return errors.New("NOT_EQUIPPED")
+	}
+
+	p.unequip(item)
+
+	return nil
+}
+
+// Weapons wear when attacking, armour when getting hit. Returns items that broke
+func (p *Player) WearEquipment(attacking bool) []*types.PlayerItem {
+	slots := []types.ItemSlot{types.SLOT_HEAD, types.SLOT_ARMOUR}
+
+	if attacking {
+		slots = []types.ItemSlot{types.SLOT_WEAPON}
+	}
+
+	broken := make([]*types.PlayerItem, 0)
+
+	for _, slot := range slots {
+		for _, item := range p.Inventory.EquippedInSlot(slot) {
+			if item.Wear(1) {
+				p.removeDerivedStats(item)
+
+				broken = append(broken, item)
+			}
+		}
+	}
+
+	return broken
+}
+
+func (p *Player) RepairItem(item *types.PlayerItem) {
+	wasBroken := item.Broken()
+
+	item.Durability = item.MaxDurability
+
+	if wasBroken && item.Equipped {
+		p.triggerUnlock(item)
+	}
+}
+
+func (p *Player) SaveLoadout(name string) error {
+	if _, exists := p.Inventory.Loadouts[name]; !exists && len(p.Inventory.Loadouts) >= MaxLoadouts {
+		return errors.New("TOO_MANY_LOADOUTS")
+	}
+
+	loadout := make([]uuid.UUID, 0)
+
+	for _, item := range p.Inventory.EquippedItems() {
+		loadout = append(loadout, item.UUID)
+	}
+
+	p.Inventory.Loadouts[name] = loadout
+
+	return nil
+}
+
+// Returns uuids of items from loadout that player no longer has
+func (p *Player) ApplyLoadout(name string) ([]uuid.UUID, error) {
+	if p.Meta.FightInstance != nil {
+		return nil, errors.New("IN_FIGHT")
+	}
+
+	loadout, exists := p.Inventory.Loadouts[name]
+
+	if !exists {
+		return nil, errors.New("LOADOUT_NOT_FOUND")
+	}
+
+	for _, item := range p.Inventory.EquippedItems() {
+		p.unequip(item)
+	}
+
+	missing := make([]uuid.UUID, 0)
+
+	for _, itemUuid := range loadout {
+		found := false
+
+		for _, item := range p.Inventory.Items {
+			if item.UUID == itemUuid && !item.Equipped && item.Slot != types.SLOT_NONE {
+				p.equip(item)
+
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			missing = append(missing, itemUuid)
+		}
+	}
+
+	return missing, nil
+}
+
+func (p *Player) DeleteLoadout(name string) error {
+	if _, exists := p.Inventory.Loadouts[name]; !exists {
+		return errors.New("LOADOUT_NOT_FOUND")
+	}
+
+	delete(p.Inventory.Loadouts, name)
+
+	return nil
+}
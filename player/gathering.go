@@ -0,0 +1,93 @@
+package player
+
+import (
+	"sao/world/gathering"
+	"strconv"
+	"time"
+)
+
+type ProfessionSkill struct {
+	Level int
+	Exp   int
+}
+
+func (s *ProfessionSkill) ExpToNextLevel() int {
+	return s.Level * 100
+}
+
+// Returns true on level up
+func (s *ProfessionSkill) AddExp(value int) bool {
+	s.Exp += value
+
+	leveled := false
+
+	for s.Exp >= s.ExpToNextLevel() {
+		s.Exp -= s.ExpToNextLevel()
+		s.Level++
+
+		leveled = true
+	}
+
+	return leveled
+}
+
+type Professions struct {
+	Skills map[gathering.Profession]*ProfessionSkill
+	//Not saved, cooldown resets with restart
+	NextGather time.Time
+}
+
+func NewProfessions() Professions {
+	return Professions{Skills: make(map[gathering.Profession]*ProfessionSkill)}
+}
+
+// Every profession starts at level 1
+func (p *Professions) Get(profession gathering.Profession) *ProfessionSkill {
+	if skill, exists := p.Skills[profession]; exists {
+		return skill
+	}
+
+	skill := &ProfessionSkill{Level: 1, Exp: 0}
+
+	p.Skills[profession] = skill
+
+	return skill
+}
+
+func (p *Professions) OnCooldown() bool {
+	return time.Now().Before(p.NextGather)
+}
+
+func (p *Professions) Serialize() map[string]interface{} {
+	skills := make(map[string]interface{})
+
+	for profession, skill := range p.Skills {
+		skills[strconv.Itoa(int(profession))] = map[string]interface{}{
+			"level": skill.Level,
+			"exp":   skill.Exp,
+		}
+	}
+
+	return skills
+}
+
+func DeserializeProfessions(data map[string]interface{}) Professions {
+	professions := NewProfessions()
+
+	for rawProfession, rawSkill := range data {
+		profession, err := strconv.Atoi(rawProfession)
+
+		if err != nil {
+			continue
+		}
+
+		skill := rawSkill.(map[string]interface{})
+
+		professions.Skills[gathering.Profession(profession)] = &ProfessionSkill{
+			Level: int(skill["level"].(float64)),
+			Exp:   int(skill["exp"].(float64)),
+		}
+	}
+
+	return professions
+}
@@ -0,0 +1,9 @@
+package player
+
+import "github.com/google/uuid"
+
+// Level is kept here so perks don't need world access
+type PartialGuild struct {
+	UUID  uuid.UUID
+	Level int
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"sao/data"
 	"sao/types"
+	"slices"
 	"strconv"
 
 	"github.com/google/uuid"
@@ -21,6 +22,8 @@ type PlayerInventory struct {
 	LevelSkillsUpgrades map[int]int
 	LevelSkillMeta      map[int]interface{}
 	FurySkillsCD        map[uuid.UUID]int
+	//Loadout name => item uuids
+	Loadouts map[string][]uuid.UUID
 }
 
 func (inv *PlayerInventory) AddTempSkill(skill types.WithExpire[types.PlayerSkill]) {
@@ -31,10 +34,7 @@ func (inv *PlayerInventory) Serialize() map[string]interface{} {
 	items := make([]map[string]interface{}, 0)
 
 	for _, item := range inv.Items {
-		items = append(items, map[string]interface{}{
-			"uuid":  item.UUID.String(),
-			"count": item.Count,
-		})
+		items = append(items, SerializeItem(item))
 	}
 
 	itemsCD := make(map[string]interface{})
@@ -75,6 +75,16 @@ func (inv *PlayerInventory) Serialize() map[string]interface{} {
 		furySkillsCD[key.String()] = value
 	}
 
+	loadouts := make(map[string][]string)
+
+	for name, loadout := range inv.Loadouts {
+		loadouts[name] = make([]string, 0)
+
+		for _, itemUuid := range loadout {
+			loadouts[name] = append(loadouts[name], itemUuid.String())
+		}
+	}
+
 	return map[string]interface{}{
 		"gold":           inv.Gold,
 		"items":          items,
@@ -83,7 +93,50 @@ func (inv *PlayerInventory) Serialize() map[string]interface{} {
 		"levelSkillsCDS": inv.LevelSkillsCDS,
 		"levelSkills":    lvlSkills,
 		"furySkillsCD":   furySkillsCD,
+		"loadouts":       loadouts,
+	}
+}
+
+func SerializeItem(item *types.PlayerItem) map[string]interface{} {
+	return map[string]interface{}{
+		"uuid":        item.UUID.String(),
+		"count":       item.Count,
+		"quality":     item.Quality,
+		"equipped":    item.Equipped,
+		"durability":  item.Durability,
+		"enhancement": item.Enhancement,
+	}
+}
+
+// Returns nil if item was removed from game data
+func DeserializeItem(item map[string]interface{}) *types.PlayerItem {
+	uuid, _ := uuid.Parse(item["uuid"].(string))
+
+	copy, exists := data.Items[uuid]
+
+	if !exists {
+		return nil
 	}
+
+	copy.Count = int(item["count"].(float64))
+
+	if quality, ok := item["quality"].(float64); ok {
+		copy.SetQuality(types.Quality(quality))
+	}
+
+	if equipped, ok := item["equipped"].(bool); ok {
+		copy.Equipped = equipped
+	}
+
+	if durability, ok := item["durability"].(float64); ok && copy.MaxDurability > 0 {
+		copy.Durability = min(int(durability), copy.MaxDurability)
+	}
+
+	if enhancement, ok := item["enhancement"].(float64); ok {
+		copy.SetEnhancement(int(enhancement))
+	}
+
+	return &copy
 }
 
 func DeserializeInventory(rawData map[string]interface{}) PlayerInventory {
@@ -91,30 +144,43 @@ func DeserializeInventory(rawData map[string]interface{}) PlayerInventory {
 
 	inv.Gold = int(rawData["gold"].(float64))
 
-	if rawItemData, okay := rawData["items"].([]map[string]interface{}); okay {
-		for _, item := range rawItemData {
-			uuid, _ := uuid.Parse(item["uuid"].(string))
-			copy := data.Items[uuid]
+	if rawItemData, okay := rawData["items"].([]interface{}); okay {
+		for _, rawItem := range rawItemData {
+			item := DeserializeItem(rawItem.(map[string]interface{}))
 
-			copy.Count = item["count"].(int)
+			//Removed from game data
+			if item == nil {
+				continue
+			}
 
-			inv.Items = append(inv.Items, &copy)
+			//Saves from before equipment slots applied unlock stats of every item, those stay equipped
+			if _, ok := rawItem.(map[string]interface{})["equipped"]; !ok {
+				item.Equipped = item.Slot != types.SLOT_NONE
+			}
+
+			inv.Items = append(inv.Items, item)
 		}
 	}
 
 	if rawItemCD, okay := rawData["itemSkillCD"].(map[string]interface{}); okay {
 		for key, value := range rawItemCD {
 			uuid, _ := uuid.Parse(key)
-			inv.ItemSkillCD[uuid] = value.(int)
+			inv.ItemSkillCD[uuid] = int(value.(float64))
 		}
 	}
 
-	if rawIngredientData, okay := rawData["ingredients"].([]map[string]interface{}); okay {
-		for _, ingredient := range rawIngredientData {
+	if rawIngredientData, okay := rawData["ingredients"].([]interface{}); okay {
+		for _, rawIngredient := range rawIngredientData {
+			ingredient := rawIngredient.(map[string]interface{})
 			uuid, _ := uuid.Parse(ingredient["uuid"].(string))
-			copy := data.Ingredients[uuid]
 
-			copy.Count = ingredient["count"].(int)
+			copy, exists := data.Ingredients[uuid]
+
+			if !exists {
+				continue
+			}
+
+			copy.Count = int(ingredient["count"].(float64))
 
 			inv.Ingredients[uuid] = &copy
 		}
@@ -133,6 +199,7 @@ func DeserializeInventory(rawData map[string]interface{}) PlayerInventory {
 			data := lvlData.(map[string]interface{})
 
 			inv.LevelSkills[parsed] = AVAILABLE_SKILLS[types.SkillPath(data["path"].(float64))][parsed][int(data["choice"].(float64))]
+			inv.LevelChoices[parsed] = int(data["choice"].(float64))
 
 			inv.LevelSkillsUpgrades[parsed] = int(data["upgrades"].(float64))
 		}
@@ -141,7 +208,19 @@ func DeserializeInventory(rawData map[string]interface{}) PlayerInventory {
 	if _, okay := rawData["furySkillsCD"].(map[string]interface{}); okay {
 		for key, value := range rawData["furySkillsCD"].(map[string]interface{}) {
 			uuid, _ := uuid.Parse(key)
-			inv.FurySkillsCD[uuid] = value.(int)
+			inv.FurySkillsCD[uuid] = int(value.(float64))
+		}
+	}
+
+	if rawLoadouts, okay := rawData["loadouts"].(map[string]interface{}); okay {
+		for name, rawLoadout := range rawLoadouts {
+			loadout := make([]uuid.UUID, 0)
+
+			for _, itemUuid := range rawLoadout.([]interface{}) {
+				loadout = append(loadout, uuid.MustParse(itemUuid.(string)))
+			}
+
+			inv.Loadouts[name] = loadout
 		}
 	}
 
@@ -157,28 +236,111 @@ func (inv *PlayerInventory) AddIngredient(ingredient *types.Ingredient) {
 	inv.Ingredients[ingredient.UUID] = ingredient
 }
 
-func (inv *PlayerInventory) Craft(recipe types.Recipe) error {
+func (inv PlayerInventory) EquippedItems() []*types.PlayerItem {
+	items := make([]*types.PlayerItem, 0)
+
+	for _, item := range inv.Items {
+		if item.Equipped {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// Equipped items that are not broken
+func (inv PlayerInventory) ActiveItems() []*types.PlayerItem {
+	items := make([]*types.PlayerItem, 0)
+
+	for _, item := range inv.Items {
+		if item.IsActive() {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+func (inv PlayerInventory) EquippedInSlot(slot types.ItemSlot) []*types.PlayerItem {
+	items := make([]*types.PlayerItem, 0)
+
+	for _, item := range inv.Items {
+		if item.Equipped && item.Slot == slot {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// Ingredients still needed for recipe with how many are missing
+func (inv PlayerInventory) MissingIngredients(recipe types.Recipe) []types.WithCount[uuid.UUID] {
+	missing := make([]types.WithCount[uuid.UUID], 0)
+
 	for _, ingredient := range recipe.Ingredients {
-		if inv.Ingredients[ingredient.Item].Count < ingredient.Count {
-			return errors.New("MISSING_INGREDIENT")
+		owned := 0
+
+		if entry, exists := inv.Ingredients[ingredient.Item]; exists {
+			owned = entry.Count
+		}
+
+		if owned < ingredient.Count {
+			missing = append(missing, types.WithCount[uuid.UUID]{Item: ingredient.Item, Count: ingredient.Count - owned})
 		}
 	}
 
+	return missing
+}
+
+func (inv *PlayerInventory) ConsumeIngredients(recipe types.Recipe) {
 	for _, ingredient := range recipe.Ingredients {
-		inv.Ingredients[ingredient.Item].Count -= ingredient.Count
+		inv.RemoveIngredients([]types.Ingredient{{UUID: ingredient.Item, Count: ingredient.Count}})
 	}
+}
 
-	newItem := data.Items[recipe.Product.UUID]
-	newItem.Count = recipe.Product.Count
+// How many items taking slot player can carry
+const MaxSlots = 10
 
-	inv.Items = append(inv.Items, &newItem)
+func (inv PlayerInventory) UsedSlots() int {
+	slots := 0
 
-	return nil
+	for _, item := range inv.Items {
+		if item.TakesSlot {
+			slots++
+		}
+	}
+
+	return slots
+}
+
+// Checks if amount of item fits in inventory without exceeding slots or stack limits
+func (inv PlayerInventory) CanAddItem(item *types.PlayerItem, amount int) bool {
+	newEntries := amount
+
+	if item.Stacks && item.MaxCount > 0 {
+		for _, invItem := range inv.Items {
+			if invItem.StacksWith(item) && invItem.Count < invItem.MaxCount {
+				amount -= invItem.MaxCount - invItem.Count
+			}
+		}
+
+		if amount <= 0 {
+			return true
+		}
+
+		newEntries = (amount + item.MaxCount - 1) / item.MaxCount
+	}
+
+	if !item.TakesSlot {
+		return true
+	}
+
+	return inv.UsedSlots()+newEntries <= MaxSlots
 }
 
 func (inv *PlayerInventory) AddItem(item *types.PlayerItem) {
 	for _, invItem := range inv.Items {
-		if invItem.UUID == item.UUID && invItem.Stacks && invItem.Count < invItem.MaxCount {
+		if invItem.StacksWith(item) && invItem.Stacks && invItem.Count < invItem.MaxCount {
 			if invItem.Count+item.Count > invItem.MaxCount {
 				item.Count -= invItem.MaxCount - invItem.Count
 				invItem.Count = invItem.MaxCount
@@ -189,10 +351,85 @@ func (inv *PlayerInventory) AddItem(item *types.PlayerItem) {
 		}
 	}
 
+	//Split leftovers into full stacks
+	for item.Stacks && item.MaxCount > 0 && item.Count > item.MaxCount {
+		stack := *item
+		stack.Count = item.MaxCount
+
+		inv.Items = append(inv.Items, &stack)
+
+		item.Count -= item.MaxCount
+	}
+
 	//This will bite me in the ass later
 	inv.Items = append(inv.Items, item)
 }
 
+func (inv PlayerInventory) CountItem(itemUuid uuid.UUID) int {
+	count := 0
+
+	//Equipped items can't be traded away
+	for _, item := range inv.Items {
+		if item.UUID == itemUuid && !item.Equipped {
+			count += item.Count
+		}
+	}
+
+	return count
+}
+
+// Any instance will do, so the least valuable ones are taken first
+func (inv *PlayerInventory) TakeItem(itemUuid uuid.UUID, amount int) {
+	candidates := make([]*types.PlayerItem, 0)
+
+	for _, item := range inv.Items {
+		if item.UUID == itemUuid && !item.Equipped {
+			candidates = append(candidates, item)
+		}
+	}
+
+	slices.SortStableFunc(candidates, func(a, b *types.PlayerItem) int {
+		return a.ValuePercent() - b.ValuePercent()
+	})
+
+	for _, item := range candidates {
+		taken := min(item.Count, amount)
+
+		item.Count -= taken
+		amount -= taken
+	}
+
+	inv.Items = slices.DeleteFunc(inv.Items, func(item *types.PlayerItem) bool {
+		return item.UUID == itemUuid && !item.Equipped && item.Count == 0
+	})
+}
+
+// Takes amount from stack at idx, the same instance is returned when the whole stack is taken
+func (inv *PlayerInventory) TakeItemAt(idx int, amount int) *types.PlayerItem {
+	if idx < 0 || idx >= len(inv.Items) || amount <= 0 {
+		return nil
+	}
+
+	item := inv.Items[idx]
+
+	if item.Equipped || item.Count < amount {
+		return nil
+	}
+
+	if item.Count == amount {
+		inv.Items = append(inv.Items[:idx], inv.Items[idx+1:]...)
+
+		return item
+	}
+
+	taken := *item
+	taken.Count = amount
+
+	item.Count -= amount
+
+	return &taken
+}
+
 func (inv PlayerInventory) HasIngredients(ingredients []types.Ingredient) bool {
 	for _, ingredient := range ingredients {
 		entry, exists := inv.Ingredients[ingredient.UUID]
@@ -228,7 +465,7 @@ func (inv *PlayerInventory) RemoveIngredients(ingredients []types.Ingredient) {
 func (inv PlayerInventory) GetStat(stat types.Stat) int {
 	value := 0
 
-	for _, item := range inv.Items {
+	for _, item := range inv.ActiveItems() {
 		val, exists := item.Stats[stat]
 
 		if exists {
@@ -319,5 +556,6 @@ func GetDefaultInventory() PlayerInventory {
 		LevelChoices:        make(map[int]int),
 		LevelSkillMeta:      make(map[int]interface{}),
 		FurySkillsCD:        make(map[uuid.UUID]int),
+		Loadouts:            make(map[string][]uuid.UUID),
 	}
 }
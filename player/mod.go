@@ -3,6 +3,7 @@ package player
 import (
 	"encoding/json"
 	"errors"
+	"maps"
 	"os"
 	"sao/base"
 	"sao/config"
@@ -11,7 +12,9 @@ import (
 	"sao/types"
 	"sao/utils"
 	"sao/world/fury"
+	"sao/world/guild"
 	"sao/world/party"
+	"sao/world/quest"
 	"strconv"
 
 	"github.com/google/uuid"
@@ -35,6 +38,41 @@ type PartialParty struct {
 	MembersCount int
 }
 
+// How many purchases are kept in history
+const MaxPurchaseHistory = 20
+
+type Purchase struct {
+	Store    uuid.UUID
+	Item     uuid.UUID
+	ItemType types.ItemType
+	Count    int
+	Price    int
+	//Calendar date of purchase
+	Date string
+}
+
+func (p Purchase) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"store": p.Store.String(),
+		"item":  p.Item.String(),
+		"type":  p.ItemType,
+		"count": p.Count,
+		"price": p.Price,
+		"date":  p.Date,
+	}
+}
+
+func DeserializePurchase(data map[string]interface{}) Purchase {
+	return Purchase{
+		Store:    uuid.MustParse(data["store"].(string)),
+		Item:     uuid.MustParse(data["item"].(string)),
+		ItemType: types.ItemType(data["type"].(float64)),
+		Count:    int(data["count"].(float64)),
+		Price:    int(data["price"].(float64)),
+		Date:     data["date"].(string),
+	}
+}
+
 type PlayerMeta struct {
 	Location       types.EntityLocation
 	OwnUUID        uuid.UUID
@@ -45,6 +83,32 @@ type PlayerMeta struct {
 	Fury           *fury.Fury
 	UnlockedFloors []string
 	WaitToHeal     bool
+	//Mob id => how many times player defeated it
+	Kills     map[string]int
+	Purchases []Purchase
+	Crafting  CraftingSkill
+	//Permadeath, character is removed on death
+	Hardcore bool
+	Quests   QuestJournal
+	Bounties BountyLog
+	//Nil when player is standing in place
+	Travel *Travel
+	//Hidden locations found by exploring, as "floor,location"
+	Discovered  []string
+	Professions Professions
+	//Dungeon id => absolute calendar hour
+	Lockouts map[string]int
+	PvP      PvPRecord
+	//Rebuilt from world guilds on load
+	Guild *PartialGuild
+}
+
+func (pM *PlayerMeta) AddPurchase(purchase Purchase) {
+	pM.Purchases = append(pM.Purchases, purchase)
+
+	if len(pM.Purchases) > MaxPurchaseHistory {
+		pM.Purchases = pM.Purchases[len(pM.Purchases)-MaxPurchaseHistory:]
+	}
 }
 
 func (pM *PlayerMeta) SerializeFuries() map[string]interface{} {
@@ -57,8 +121,22 @@ func (pM *PlayerMeta) SerializeFuries() map[string]interface{} {
 
 func (pM *PlayerMeta) Serialize() map[string]interface{} {
 	party := ""
+	partyRole := 0
 	if pM.Party != nil {
 		party = pM.Party.UUID.String()
+		partyRole = int(pM.Party.Role)
+	}
+
+	purchases := make([]map[string]interface{}, 0)
+
+	for _, purchase := range pM.Purchases {
+		purchases = append(purchases, purchase.Serialize())
+	}
+
+	var travel map[string]interface{}
+
+	if pM.Travel != nil {
+		travel = pM.Travel.Serialize()
 	}
 
 	return map[string]interface{}{
@@ -67,7 +145,19 @@ func (pM *PlayerMeta) Serialize() map[string]interface{} {
 		"uid":             pM.UserID,
 		"fury":            pM.SerializeFuries(),
 		"party":           party,
+		"party_role":      partyRole,
 		"unlocked_floors": pM.UnlockedFloors,
+		"kills":           pM.Kills,
+		"purchases":       purchases,
+		"crafting":        pM.Crafting.Serialize(),
+		"hardcore":        pM.Hardcore,
+		"quests":          pM.Quests.Serialize(),
+		"bounties":        pM.Bounties.Serialize(),
+		"discovered":      pM.Discovered,
+		"professions":     pM.Professions.Serialize(),
+		"lockouts":        pM.Lockouts,
+		"pvp":             pM.PvP.Serialize(),
+		"travel":          travel,
 	}
 }
 
@@ -80,6 +170,7 @@ type Player struct {
 	DynamicStats []types.DerivedStat
 	LevelStats   map[types.Stat]int
 	DefaultStats map[types.Stat]int
+	Attributes   PlayerAttributes
 }
 
 func (p *Player) Serialize() map[string]interface{} {
@@ -94,6 +185,7 @@ func (p *Player) Serialize() map[string]interface{} {
 		"dynamic_stats": p.DynamicStats,
 		"level_stats":   p.LevelStats,
 		"default_stats": p.DefaultStats,
+		"attributes":    p.Attributes.Serialize(),
 		"meta":          p.Meta.Serialize(),
 		"inventory":     p.Inventory.Serialize(),
 	}
@@ -143,7 +235,13 @@ func DeserializeDefaultStats(data map[string]interface{}) map[types.Stat]int {
 }
 
 func Deserialize(data map[string]interface{}) *Player {
-	return &Player{
+	attributes := PlayerAttributes{Archetype: "", Allocated: make(map[types.Stat]int)}
+
+	if rawData, exists := data["attributes"].(map[string]interface{}); exists {
+		attributes = DeserializeAttributes(rawData)
+	}
+
+	playerObj := &Player{
 		data["name"].(string),
 		PlayerXP{
 			Level: int(data["xp"].([]interface{})[0].(float64)),
@@ -160,7 +258,12 @@ func Deserialize(data map[string]interface{}) *Player {
 		DeserializeDerivedStats(data["dynamic_stats"].([]interface{})),
 		DeserializeLevelStats(data["level_stats"].(map[string]interface{})),
 		DeserializeDefaultStats(data["default_stats"].(map[string]interface{})),
+		attributes,
 	}
+
+	playerObj.fitEquipmentSlots()
+
+	return playerObj
 }
 
 func DeserializeEffects(data []interface{}) []types.ActionEffect {
@@ -183,6 +286,23 @@ func DeserializeEffects(data []interface{}) []types.ActionEffect {
 	return temp
 }
 
+// Fury definitions no longer present in game data are dropped
+func deserializeFury(rawFury map[string]interface{}) *fury.Fury {
+	rawUuid, exists := rawFury["uuid"].(string)
+
+	if !exists {
+		return nil
+	}
+
+	definition, exists := data.Furies[uuid.MustParse(rawUuid)]
+
+	if !exists {
+		return nil
+	}
+
+	return fury.Deserialize(rawFury, definition)
+}
+
 func DeserializeMeta(data map[string]interface{}) *PlayerMeta {
 
 	pLocation := types.EntityLocation{
@@ -191,15 +311,21 @@ func DeserializeMeta(data map[string]interface{}) *PlayerMeta {
 	}
 
 	var furyData *fury.Fury
-	if data["fury"] != nil {
-		furyData = fury.Deserialize(data["fury"].(map[string]interface{}))
+	if rawFury, exists := data["fury"].(map[string]interface{}); exists {
+		furyData = deserializeFury(rawFury)
 	}
 
 	var partyTemp *PartialParty = nil
 
 	if data["party"] != "" {
+		partyRole := party.None
+
+		if rawRole, exists := data["party_role"].(float64); exists {
+			partyRole = party.PartyRole(rawRole)
+		}
+
 		partyTemp = &PartialParty{
-			Role:         party.None,
+			Role:         partyRole,
 			UUID:         uuid.MustParse(data["party"].(string)),
 			MembersCount: 0,
 		}
@@ -212,6 +338,66 @@ func DeserializeMeta(data map[string]interface{}) *PlayerMeta {
 		}
 	}
 
+	kills := make(map[string]int)
+	if rawData, exists := data["kills"].(map[string]interface{}); exists {
+		for mobId, count := range rawData {
+			kills[mobId] = int(count.(float64))
+		}
+	}
+
+	purchases := make([]Purchase, 0)
+	if rawData, exists := data["purchases"].([]interface{}); exists {
+		for _, purchase := range rawData {
+			purchases = append(purchases, DeserializePurchase(purchase.(map[string]interface{})))
+		}
+	}
+
+	crafting := CraftingSkill{Level: 1, Exp: 0, Recipes: make([]uuid.UUID, 0)}
+	if rawData, exists := data["crafting"].(map[string]interface{}); exists {
+		crafting = DeserializeCraftingSkill(rawData)
+	}
+
+	hardcore, _ := data["hardcore"].(bool)
+
+	quests := QuestJournal{Active: make(map[uuid.UUID]*quest.Progress), Completed: make([]uuid.UUID, 0)}
+	if rawData, exists := data["quests"].(map[string]interface{}); exists {
+		quests = DeserializeQuestJournal(rawData)
+	}
+
+	bounties := NewBountyLog()
+	if rawData, exists := data["bounties"].(map[string]interface{}); exists {
+		bounties = DeserializeBountyLog(rawData)
+	}
+
+	discovered := make([]string, 0)
+	if rawData, exists := data["discovered"].([]interface{}); exists {
+		for _, location := range rawData {
+			discovered = append(discovered, location.(string))
+		}
+	}
+
+	professions := NewProfessions()
+	if rawData, exists := data["professions"].(map[string]interface{}); exists {
+		professions = DeserializeProfessions(rawData)
+	}
+
+	lockouts := make(map[string]int)
+	if rawData, exists := data["lockouts"].(map[string]interface{}); exists {
+		for dungeonId, end := range rawData {
+			lockouts[dungeonId] = int(end.(float64))
+		}
+	}
+
+	pvp := NewPvPRecord()
+	if rawData, exists := data["pvp"].(map[string]interface{}); exists {
+		pvp = DeserializePvPRecord(rawData)
+	}
+
+	var travel *Travel
+	if rawData, exists := data["travel"].(map[string]interface{}); exists {
+		travel = DeserializeTravel(rawData)
+	}
+
 	return &PlayerMeta{
 		pLocation,
 		uuid.MustParse(data["uuid"].(string)),
@@ -222,6 +408,18 @@ func DeserializeMeta(data map[string]interface{}) *PlayerMeta {
 		furyData,
 		unlockedFloors,
 		false,
+		kills,
+		purchases,
+		crafting,
+		hardcore,
+		quests,
+		bounties,
+		travel,
+		discovered,
+		professions,
+		lockouts,
+		pvp,
+		nil,
 	}
 }
 
@@ -364,6 +562,18 @@ func (p *Player) RemoveEffect(uuid uuid.UUID) {
 	p.Stats.Effects = base.RemoveEffect(uuid, p)
 }
 
+func (p *Player) RemoveEffectsFrom(source types.EffectSource) {
+	keep := make([]types.ActionEffect, 0)
+
+	for _, effect := range p.Stats.Effects {
+		if effect.Source != source {
+			keep = append(keep, effect)
+		}
+	}
+
+	p.Stats.Effects = keep
+}
+
 func (p *Player) GetAllEffects() []types.ActionEffect {
 	temporaryEffects := make([]types.ActionEffect, 0)
 
@@ -478,6 +688,21 @@ func (p *Player) GetAllEffects() []types.ActionEffect {
 		}
 	}
 
+	if p.Meta.Guild != nil {
+		for _, perk := range guild.PerksFor(p.Meta.Guild.Level) {
+			temporaryEffects = append(temporaryEffects, types.ActionEffect{
+				Effect:   types.EFFECT_STAT_INC,
+				Duration: -1,
+				Source:   types.SOURCE_GUILD,
+				Meta: types.ActionEffectStat{
+					Stat:      perk.Stat,
+					Value:     perk.Value,
+					IsPercent: perk.IsPercent,
+				},
+			})
+		}
+	}
+
 	return append(temporaryEffects, p.Stats.Effects...)
 }
 
@@ -553,16 +778,9 @@ func (p *Player) CanUseSkill(skill types.PlayerSkill) bool {
 	return true
 }
 
+// Unlock events are triggered once item gets equipped
 func (p *Player) AddItem(item *types.PlayerItem) {
-	for _, effect := range item.Effects {
-		effectEvents := effect.GetEvents()
-
-		if _, exists := effectEvents[types.CUSTOM_TRIGGER_UNLOCK]; exists {
-			effectEvents[types.CUSTOM_TRIGGER_UNLOCK](p)
-		}
-	}
-
-	p.Inventory.Items = append(p.Inventory.Items, item)
+	p.Inventory.AddItem(item)
 }
 
 func (p *Player) GetAllItems() []*types.PlayerItem {
@@ -586,10 +804,33 @@ func (p *Player) GetSkill(uuid uuid.UUID) types.PlayerSkill {
 		}
 	}
 
+	if p.Meta.Fury != nil {
+		return p.Meta.Fury.GetSkill(uuid)
+	}
+
 	return nil
 }
 
+// Cooldown of skill that isn't a level skill
+func (p *Player) SetSkillCD(skillUuid uuid.UUID, value int) {
+	cooldowns := p.Inventory.ItemSkillCD
+
+	if p.Meta.Fury != nil && p.Meta.Fury.GetSkill(skillUuid) != nil {
+		cooldowns = p.Inventory.FurySkillsCD
+	}
+
+	if value == 0 {
+		delete(cooldowns, skillUuid)
+	} else {
+		cooldowns[skillUuid] = value
+	}
+}
+
 func (p *Player) RemoveItem(item int) {
+	if p.Inventory.Items[item].Equipped {
+		p.unequip(p.Inventory.Items[item])
+	}
+
 	p.Inventory.Items = append(p.Inventory.Items[:item], p.Inventory.Items[item+1:]...)
 }
 
@@ -655,6 +896,7 @@ func (p *Player) GetStat(stat types.Stat) int {
 		statValue += ((p.XP.Level - 1) * value)
 	}
 
+	statValue += p.GetAllocatedStat(stat)
 	statValue += p.Inventory.GetStat(stat)
 
 	if p.Meta.Fury != nil {
@@ -754,6 +996,7 @@ func (p *Player) GetRawStat(stat types.Stat) int {
 		statValue += ((p.XP.Level - 1) * value)
 	}
 
+	statValue += p.GetAllocatedStat(stat)
 	statValue += p.Inventory.GetStat(stat)
 
 	if p.Meta.Fury != nil {
@@ -870,22 +1113,25 @@ func (p *Player) ReduceCooldowns(event types.SkillTrigger) {
 		}
 	}
 
-	for skillUuid := range p.Inventory.FurySkillsCD {
-		if p.Meta.Fury == nil {
-			break
+	for skillUuid, cd := range p.Inventory.FurySkillsCD {
+		var skill types.PlayerSkill
+
+		if p.Meta.Fury != nil {
+			skill = p.Meta.Fury.GetSkill(skillUuid)
 		}
 
-		for _, skillStruct := range p.Meta.Fury.GetSkills() {
-			if skillUuid == skillStruct.GetUUID() {
-				cdMeta := skillStruct.GetTrigger().Cooldown
+		if skill == nil {
+			delete(p.Inventory.FurySkillsCD, skillUuid)
+			continue
+		}
 
-				if cdMeta == nil && event == types.TRIGGER_TURN {
-					p.Inventory.FurySkillsCD[skillUuid]--
-				}
+		cdMeta := skill.GetTrigger().Cooldown
 
-				if cdMeta != nil && event != cdMeta.PassEvent {
-					continue
-				}
+		if (cdMeta == nil && event == types.TRIGGER_TURN) || (cdMeta != nil && event == cdMeta.PassEvent) {
+			if cd <= 1 {
+				delete(p.Inventory.FurySkillsCD, skillUuid)
+			} else {
+				p.Inventory.FurySkillsCD[skillUuid] = cd - 1
 			}
 		}
 	}
@@ -898,7 +1144,7 @@ func (p *Player) GetLvl() int {
 func (p *Player) TriggerEvent(event types.SkillTrigger, data types.EventData, meta interface{}) []interface{} {
 	returnMeta := make([]interface{}, 0)
 
-	for _, item := range p.Inventory.Items {
+	for _, item := range p.Inventory.ActiveItems() {
 		for _, effect := range item.Effects {
 			trigger := effect.GetTrigger()
 
@@ -968,32 +1214,30 @@ func (p *Player) TriggerEvent(event types.SkillTrigger, data types.EventData, me
 		for _, skill := range p.Meta.Fury.GetSkills() {
 			trigger := skill.GetTrigger()
 
-			if cd := skill.GetCost(); cd != 0 {
-				currentCD, onCooldown := p.Inventory.FurySkillsCD[skill.GetUUID()]
+			if trigger.Type != types.TRIGGER_PASSIVE || trigger.Event != event {
+				continue
+			}
 
-				if onCooldown {
-					if currentCD == 0 {
-						p.Inventory.FurySkillsCD[skill.GetUUID()] = cd
-					} else {
-						continue
-					}
-				}
+			if _, onCooldown := p.Inventory.FurySkillsCD[skill.GetUUID()]; onCooldown {
+				continue
 			}
 
-			if trigger.Type == types.TRIGGER_PASSIVE && trigger.Event == event {
-				if cost := skill.GetCost(); cost != 0 {
-					if cost > p.GetCurrentMana() {
-						continue
-					} else {
-						p.Stats.CurrentMana -= cost
-					}
+			if cost := skill.GetCost(); cost != 0 {
+				if cost > p.GetCurrentMana() {
+					continue
+				} else {
+					p.Stats.CurrentMana -= cost
 				}
+			}
 
-				temp := skill.Execute(p, data.Target, data.Fight, meta)
+			temp := skill.Execute(p, data.Target, data.Fight, meta)
 
-				if temp != nil {
-					returnMeta = append(returnMeta, temp)
-				}
+			if temp != nil {
+				returnMeta = append(returnMeta, temp)
+			}
+
+			if cd := skill.GetCD(); cd != 0 {
+				p.Inventory.FurySkillsCD[skill.GetUUID()] = cd
 			}
 		}
 	}
@@ -1045,6 +1289,7 @@ func (p *Player) UnlockSkill(path types.SkillPath, lvl, choice int) error {
 	}
 
 	p.Inventory.LevelSkills[lvl] = skill[choice]
+	p.Inventory.LevelChoices[lvl] = choice
 
 	skillEvents := p.Inventory.LevelSkills[lvl].GetEvents()
 
@@ -1117,6 +1362,8 @@ func (p *Player) TriggerTempSkills() {
 
 func (p *Player) ClearFight() {
 	p.Meta.FightInstance = nil
+
+	p.RemoveEffectsFrom(types.SOURCE_LOCATION)
 }
 
 func (p *Player) GetAvailableSkillActions() int {
@@ -1147,7 +1394,7 @@ func (p *Player) GetAvailableSkillActions() int {
 		skillUpgrades := skill.GetUpgrades()
 
 		for i := 0; i < len(skillUpgrades); i++ {
-			if upgrades&i != 0 {
+			if upgrades&(1<<i) != 0 {
 				used++
 			}
 		}
@@ -1192,11 +1439,12 @@ func NewPlayer(name string, uid string) Player {
 			false,
 			Default.StartingStats[types.STAT_MANA],
 		},
-		PlayerMeta{Default.Location, uuid.New(), uid, nil, nil, nil, nil, make([]string, 0), false},
+		PlayerMeta{Default.Location, uuid.New(), uid, nil, nil, nil, nil, make([]string, 0), false, make(map[string]int), make([]Purchase, 0), CraftingSkill{Level: 1, Exp: 0, Recipes: make([]uuid.UUID, 0)}, false, QuestJournal{Active: make(map[uuid.UUID]*quest.Progress), Completed: make([]uuid.UUID, 0)}, NewBountyLog(), nil, make([]string, 0), NewProfessions(), make(map[string]int), NewPvPRecord(), nil},
 		inventory.GetDefaultInventory(),
 		make([]types.DerivedStat, 0),
-		Default.LevelStats,
-		Default.StartingStats,
+		maps.Clone(Default.LevelStats),
+		maps.Clone(Default.StartingStats),
+		PlayerAttributes{Archetype: "", Allocated: make(map[types.Stat]int)},
 	}
 }
 
@@ -1206,6 +1454,13 @@ type PlayerDefaults struct {
 	StartingStats map[types.Stat]int
 	LevelStats    map[types.Stat]int
 	Location      types.EntityLocation
+	//Free stat points gained with every level
+	PointsPerLevel int
+	//Stat value given by single point, stats missing here can't be raised
+	PointValues map[types.Stat]int
+	//Max points spent in single stat
+	StatCaps     map[types.Stat]int
+	DeathPenalty DeathPenalty
 }
 
 func GetPlayerDefaults() PlayerDefaults {
@@ -1238,5 +1493,34 @@ func GetPlayerDefaults() PlayerDefaults {
 		pDefaults.LevelStats[utils.StringToStat[key]] = int(value.(float64))
 	}
 
+	if rawPoints, ok := parsedData["PointsPerLevel"].(float64); ok {
+		pDefaults.PointsPerLevel = int(rawPoints)
+	}
+
+	pDefaults.PointValues = make(map[types.Stat]int, 0)
+
+	if rawValues, ok := parsedData["PointValues"].(map[string]interface{}); ok {
+		for key, value := range rawValues {
+			pDefaults.PointValues[utils.StringToStat[key]] = int(value.(float64))
+		}
+	}
+
+	if rawPenalty, ok := parsedData["DeathPenalty"].(map[string]interface{}); ok {
+		pDefaults.DeathPenalty = DeathPenalty{
+			XPPercent:      int(rawPenalty["XPPercent"].(float64)),
+			GoldPercent:    int(rawPenalty["GoldPercent"].(float64)),
+			ItemDropChance: int(rawPenalty["ItemDropChance"].(float64)),
+			DropDays:       int(rawPenalty["DropDays"].(float64)),
+		}
+	}
+
+	pDefaults.StatCaps = make(map[types.Stat]int, 0)
+
+	if rawCaps, ok := parsedData["StatCaps"].(map[string]interface{}); ok {
+		for key, value := range rawCaps {
+			pDefaults.StatCaps[utils.StringToStat[key]] = int(value.(float64))
+		}
+	}
+
 	return pDefaults
 }
@@ -0,0 +1,61 @@
+package player
+
+import "sao/world/duel"
+
+type PvPRecord struct {
+	Rating  int
+	Wins    int
+	Losses  int
+	History []duel.Result
+}
+
+func NewPvPRecord() PvPRecord {
+	return PvPRecord{Rating: duel.StartingRating, Wins: 0, Losses: 0, History: make([]duel.Result, 0)}
+}
+
+// Newest result is last
+func (r *PvPRecord) AddResult(result duel.Result) {
+	r.Rating += result.RatingChange
+
+	if result.Won {
+		r.Wins++
+	} else {
+		r.Losses++
+	}
+
+	r.History = append(r.History, result)
+
+	if len(r.History) > duel.MaxHistory {
+		r.History = r.History[len(r.History)-duel.MaxHistory:]
+	}
+}
+
+func (r *PvPRecord) Serialize() map[string]interface{} {
+	history := make([]map[string]interface{}, 0)
+
+	for _, result := range r.History {
+		history = append(history, result.Serialize())
+	}
+
+	return map[string]interface{}{
+		"rating":  r.Rating,
+		"wins":    r.Wins,
+		"losses":  r.Losses,
+		"history": history,
+	}
+}
+
+func DeserializePvPRecord(data map[string]interface{}) PvPRecord {
+	record := PvPRecord{
+		Rating:  int(data["rating"].(float64)),
+		Wins:    int(data["wins"].(float64)),
+		Losses:  int(data["losses"].(float64)),
+		History: make([]duel.Result, 0),
+	}
+
+	for _, result := range data["history"].([]interface{}) {
+		record.History = append(record.History, duel.DeserializeResult(result.(map[string]interface{})))
+	}
+
+	return record
+}
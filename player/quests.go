@@ -0,0 +1,206 @@
+package player
+
+import (
+	"errors"
+	"sao/data"
+	"sao/types"
+	"sao/world/quest"
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+type QuestJournal struct {
+	Active    map[uuid.UUID]*quest.Progress
+	Completed []uuid.UUID
+}
+
+func (j *QuestJournal) Serialize() map[string]interface{} {
+	active := make([]map[string]interface{}, 0)
+
+	for _, progress := range j.Active {
+		active = append(active, progress.Serialize())
+	}
+
+	completed := make([]string, 0)
+
+	for _, questUuid := range j.Completed {
+		completed = append(completed, questUuid.String())
+	}
+
+	return map[string]interface{}{
+		"active":    active,
+		"completed": completed,
+	}
+}
+
+// Quests removed from game data are dropped from journal
+func DeserializeQuestJournal(rawData map[string]interface{}) QuestJournal {
+	journal := QuestJournal{
+		Active:    make(map[uuid.UUID]*quest.Progress),
+		Completed: make([]uuid.UUID, 0),
+	}
+
+	for _, rawProgress := range rawData["active"].([]interface{}) {
+		progress := quest.DeserializeProgress(rawProgress.(map[string]interface{}))
+
+		questData, exists := data.Quests[progress.Quest]
+
+		if !exists {
+			continue
+		}
+
+		//Objectives could have changed since save
+		for len(progress.Objectives) < len(questData.Objectives) {
+			progress.Objectives = append(progress.Objectives, 0)
+		}
+
+		progress.Objectives = progress.Objectives[:len(questData.Objectives)]
+
+		journal.Active[progress.Quest] = progress
+	}
+
+	for _, rawUuid := range rawData["completed"].([]interface{}) {
+		journal.Completed = append(journal.Completed, uuid.MustParse(rawUuid.(string)))
+	}
+
+	return journal
+}
+
+func (p *Player) HasCompletedQuest(questUuid uuid.UUID) bool {
+	return slices.Contains(p.Meta.Quests.Completed, questUuid)
+}
+
+func (p *Player) CanAcceptQuest(questData quest.Quest) error {
+	if _, active := p.Meta.Quests.Active[questData.UUID]; active {
+		return errors.New("ALREADY_ACTIVE")
+	}
+
+	if p.HasCompletedQuest(questData.UUID) {
+		return errors.New("ALREADY_COMPLETED")
+	}
+
+	for _, prerequisite := range questData.Prerequisites {
+		if !p.HasCompletedQuest(prerequisite) {
+			return errors.New("PREREQUISITES_MISSING")
+		}
+	}
+
+	if p.XP.Level < questData.MinLevel {
+		return errors.New("LEVEL_TOO_LOW")
+	}
+
+	if len(p.Meta.Quests.Active) >= quest.MaxActive {
+		return errors.New("TOO_MANY_QUESTS")
+	}
+
+	return nil
+}
+
+func (p *Player) AcceptQuest(questData quest.Quest) error {
+	if err := p.CanAcceptQuest(questData); err != nil {
+		return err
+	}
+
+	p.Meta.Quests.Active[questData.UUID] = quest.NewProgress(questData)
+
+	p.RefreshCollectQuests()
+
+	return nil
+}
+
+// Returns quests that became ready to turn in
+func (p *Player) ProgressQuests(objectiveType quest.ObjectiveType, target string, amount int) []quest.Quest {
+	ready := make([]quest.Quest, 0)
+
+	for questUuid, progress := range p.Meta.Quests.Active {
+		questData, exists := data.Quests[questUuid]
+
+		if !exists {
+			continue
+		}
+
+		wasDone := progress.Done(questData)
+
+		if progress.Advance(questData, objectiveType, target, amount) && !wasDone && progress.Done(questData) {
+			ready = append(ready, questData)
+		}
+	}
+
+	return ready
+}
+
+// Syncs collect objectives with ingredients in inventory, returns quests that became ready to turn in
+func (p *Player) RefreshCollectQuests() []quest.Quest {
+	ready := make([]quest.Quest, 0)
+
+	for questUuid, progress := range p.Meta.Quests.Active {
+		questData, exists := data.Quests[questUuid]
+
+		if !exists {
+			continue
+		}
+
+		wasDone := progress.Done(questData)
+		changed := false
+
+		for _, objective := range questData.Objectives {
+			if objective.Type != quest.OBJECTIVE_COLLECT {
+				continue
+			}
+
+			count := 0
+
+			if ingredient, ok := p.Inventory.Ingredients[uuid.MustParse(objective.Target)]; ok {
+				count = ingredient.Count
+			}
+
+			if progress.SetCollected(questData, objective.Target, count) {
+				changed = true
+			}
+		}
+
+		if changed && !wasDone && progress.Done(questData) {
+			ready = append(ready, questData)
+		}
+	}
+
+	return ready
+}
+
+// Takes collected ingredients and moves quest to completed, rewards are handed out by world
+func (p *Player) TurnInQuest(questData quest.Quest) error {
+	progress, active := p.Meta.Quests.Active[questData.UUID]
+
+	if !active {
+		return errors.New("QUEST_NOT_ACTIVE")
+	}
+
+	p.RefreshCollectQuests()
+
+	if !progress.Done(questData) {
+		return errors.New("OBJECTIVES_INCOMPLETE")
+	}
+
+	for _, objective := range questData.Objectives {
+		if objective.Type == quest.OBJECTIVE_COLLECT {
+			p.Inventory.RemoveIngredients([]types.Ingredient{{UUID: uuid.MustParse(objective.Target), Count: objective.Count}})
+		}
+	}
+
+	delete(p.Meta.Quests.Active, questData.UUID)
+
+	p.Meta.Quests.Completed = append(p.Meta.Quests.Completed, questData.UUID)
+
+	return nil
+}
+
+func (p *Player) AbandonQuest(questUuid uuid.UUID) error {
+	if _, active := p.Meta.Quests.Active[questUuid]; !active {
+		return errors.New("QUEST_NOT_ACTIVE")
+	}
+
+	delete(p.Meta.Quests.Active, questUuid)
+
+	return nil
+}
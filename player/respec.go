@@ -0,0 +1,166 @@
+package player
+
+import (
+	"maps"
+	"sao/types"
+	"slices"
+)
+
+// Stats compared in respec preview
+var RespecPreviewStats = []types.Stat{
+	types.STAT_HP,
+	types.STAT_AD,
+	types.STAT_AP,
+	types.STAT_DEF,
+	types.STAT_MR,
+	types.STAT_SPD,
+	types.STAT_AGL,
+	types.STAT_MANA,
+}
+
+type RespecPreview struct {
+	//Levels of skills that will be refunded
+	Levels   []int
+	Refunded int
+	Before   map[types.Stat]int
+	After    map[types.Stat]int
+}
+
+// Unlock events don't return anything, so they are replayed and whatever they added is taken away
+func (p *Player) revertUnlock(unlock func(owner types.PlayerEntity)) {
+	before := len(p.DynamicStats)
+	levelStats := maps.Clone(p.LevelStats)
+
+	unlock(p)
+
+	added := slices.Clone(p.DynamicStats[before:])
+	p.DynamicStats = p.DynamicStats[:before]
+
+	for _, stat := range added {
+		for idx, current := range p.DynamicStats {
+			if current == stat {
+				p.DynamicStats = append(p.DynamicStats[:idx], p.DynamicStats[idx+1:]...)
+				break
+			}
+		}
+	}
+
+	for stat, value := range p.LevelStats {
+		p.LevelStats[stat] = levelStats[stat]*2 - value
+	}
+}
+
+// Skill levels at or above fromLvl, highest first
+func (p *Player) respecLevels(fromLvl int) []int {
+	levels := make([]int, 0)
+
+	for lvl := range p.Inventory.LevelSkills {
+		if lvl >= fromLvl {
+			levels = append(levels, lvl)
+		}
+	}
+
+	slices.Sort(levels)
+	slices.Reverse(levels)
+
+	return levels
+}
+
+func (p *Player) unlockedUpgrades(lvl int) []int {
+	upgrades := make([]int, 0)
+
+	for idx := range p.Inventory.LevelSkills[lvl].GetUpgrades() {
+		if p.Inventory.LevelSkillsUpgrades[lvl]&(1<<idx) != 0 {
+			upgrades = append(upgrades, idx)
+		}
+	}
+
+	return upgrades
+}
+
+// Skill and upgrade points that would be returned
+func (p *Player) RespecPoints(fromLvl int) int {
+	points := 0
+
+	for _, lvl := range p.respecLevels(fromLvl) {
+		points += 1 + len(p.unlockedUpgrades(lvl))
+	}
+
+	return points
+}
+
+// Removes skills unlocked at fromLvl or above, undoing unlock events from the newest. Returns refunded points
+func (p *Player) ResetSkills(fromLvl int) int {
+	refunded := 0
+
+	for _, lvl := range p.respecLevels(fromLvl) {
+		skill := p.Inventory.LevelSkills[lvl]
+		skillUpgrades := skill.GetUpgrades()
+		unlocked := p.unlockedUpgrades(lvl)
+
+		slices.Reverse(unlocked)
+
+		for _, idx := range unlocked {
+			if events := skillUpgrades[idx].Events; events != nil {
+				if effect, exists := (*events)[types.CUSTOM_TRIGGER_UNLOCK]; exists {
+					p.revertUnlock(effect)
+				}
+			}
+
+			refunded++
+		}
+
+		if effect, exists := skill.GetEvents()[types.CUSTOM_TRIGGER_UNLOCK]; exists {
+			p.revertUnlock(effect)
+		}
+
+		refunded++
+
+		delete(p.Inventory.LevelSkills, lvl)
+		delete(p.Inventory.LevelChoices, lvl)
+		delete(p.Inventory.LevelSkillsUpgrades, lvl)
+		delete(p.Inventory.LevelSkillsCDS, lvl)
+		delete(p.Inventory.LevelSkillMeta, lvl)
+	}
+
+	return refunded
+}
+
+// Runs reset on the real build and puts everything back, so stats are exactly what respec would give
+func (p *Player) PreviewRespec(fromLvl int) RespecPreview {
+	preview := RespecPreview{
+		Levels: p.respecLevels(fromLvl),
+		Before: make(map[types.Stat]int),
+		After:  make(map[types.Stat]int),
+	}
+
+	slices.Reverse(preview.Levels)
+
+	for _, stat := range RespecPreviewStats {
+		preview.Before[stat] = p.GetStat(stat)
+	}
+
+	dynamicStats := slices.Clone(p.DynamicStats)
+	levelStats := maps.Clone(p.LevelStats)
+	levelSkills := maps.Clone(p.Inventory.LevelSkills)
+	levelChoices := maps.Clone(p.Inventory.LevelChoices)
+	levelUpgrades := maps.Clone(p.Inventory.LevelSkillsUpgrades)
+	levelCDs := maps.Clone(p.Inventory.LevelSkillsCDS)
+	levelMeta := maps.Clone(p.Inventory.LevelSkillMeta)
+
+	preview.Refunded = p.ResetSkills(fromLvl)
+
+	for _, stat := range RespecPreviewStats {
+		preview.After[stat] = p.GetStat(stat)
+	}
+
+	p.DynamicStats = dynamicStats
+	p.LevelStats = levelStats
+	p.Inventory.LevelSkills = levelSkills
+	p.Inventory.LevelChoices = levelChoices
+	p.Inventory.LevelSkillsUpgrades = levelUpgrades
+	p.Inventory.LevelSkillsCDS = levelCDs
+	p.Inventory.LevelSkillMeta = levelMeta
+
+	return preview
+}
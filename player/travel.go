@@ -0,0 +1,54 @@
+package player
+
+import (
+	"sao/types"
+	"sao/world/quest"
+	"slices"
+)
+
+// Clock ticks spent exploring current location
+const ExploreTicks = 3
+
+type Travel struct {
+	Destination types.EntityLocation
+	TicksLeft   int
+	//Exploring stays in place, only discoveries and encounters are rolled
+	Explore bool
+}
+
+func (t *Travel) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"destination": []string{t.Destination.Floor, t.Destination.Location},
+		"ticks_left":  t.TicksLeft,
+		"explore":     t.Explore,
+	}
+}
+
+func DeserializeTravel(data map[string]interface{}) *Travel {
+	destination := data["destination"].([]interface{})
+
+	return &Travel{
+		Destination: types.EntityLocation{Floor: destination[0].(string), Location: destination[1].(string)},
+		TicksLeft:   int(data["ticks_left"].(float64)),
+		Explore:     data["explore"].(bool),
+	}
+}
+
+func (p *Player) IsTraveling() bool {
+	return p.Meta.Travel != nil
+}
+
+func (p *Player) HasDiscovered(location types.EntityLocation) bool {
+	return slices.Contains(p.Meta.Discovered, quest.LocationKey(location))
+}
+
+// Returns false if location was already discovered
+func (p *Player) Discover(location types.EntityLocation) bool {
+	if p.HasDiscovered(location) {
+		return false
+	}
+
+	p.Meta.Discovered = append(p.Meta.Discovered, quest.LocationKey(location))
+
+	return true
+}
@@ -112,6 +112,7 @@ const (
 	SOURCE_PARTY
 	SOURCE_LOCATION
 	SOURCE_ITEM
+	SOURCE_GUILD
 )
 
 type EntityFlag int
@@ -136,6 +137,9 @@ const (
 	DMG_TRUE
 )
 
+// Resists of this type apply to every damage type
+const DMG_ALL DamageType = 4
+
 type EntityLocation struct {
 	Floor    string
 	Location string
@@ -187,6 +191,7 @@ const (
 	LOOT_ITEM LootType = iota
 	LOOT_EXP
 	LOOT_GOLD
+	LOOT_FURY
 )
 
 type Loot struct {
@@ -195,10 +200,45 @@ type Loot struct {
 	Meta  *LootMeta
 }
 
-// Only for items
+// Only for items and furies
 type LootMeta struct {
-	Type ItemType
-	Uuid uuid.UUID
+	Type   ItemType
+	Uuid   uuid.UUID
+	Rarity Rarity
+}
+
+type Rarity int
+
+const (
+	RARITY_COMMON Rarity = iota
+	RARITY_UNCOMMON
+	RARITY_RARE
+	RARITY_EPIC
+	RARITY_LEGENDARY
+)
+
+var RarityToString = map[Rarity]string{
+	RARITY_COMMON:    "Pospolity",
+	RARITY_UNCOMMON:  "Niepospolity",
+	RARITY_RARE:      "Rzadki",
+	RARITY_EPIC:      "Epicki",
+	RARITY_LEGENDARY: "Legendarny",
+}
+
+// Entry of mob loot table, rolled when mob is defeated
+type LootEntry struct {
+	Loot Loot
+	Min  int
+	Max  int
+	//Percent, 100 is always
+	Chance int
+	//Only used inside of Pool
+	Weight int
+	//Dropped only for players that never defeated this mob
+	FirstKill bool
+	//Weighted pool, Rolls entries are picked from it
+	Pool  []LootEntry
+	Rolls int
 }
 
 type ActionEnum int
@@ -338,6 +378,8 @@ type PlayerEntity interface {
 
 	SetLvlCD(int, int)
 	GetLvlCD(int) int
+	GetSkill(uuid.UUID) PlayerSkill
+	SetSkillCD(uuid.UUID, int)
 
 	SetDefendingState(bool)
 	GetDefendingState() bool
@@ -360,6 +402,7 @@ type PlayerEntity interface {
 
 	UnlockFloor(string)
 	UseItem(uuid.UUID, Entity, FightInstance)
+	WearEquipment(attacking bool) []*PlayerItem
 }
 
 type NPCStore struct {
@@ -367,10 +410,147 @@ type NPCStore struct {
 	Name     string
 	Location EntityLocation
 	Stock    []*Stock
+	//Every how many calendar days stock is refilled, 0 never
+	RestockDays int
+	//Calendar day number of last delivery, -1 if none
+	LastDelivery int
+	//Gold per durability point, 0 if store doesn't repair items
+	RepairPrice int
+	//Gold per refunded skill point, 0 if store doesn't reset skills
+	RespecPrice int
 }
 
 type Stock struct {
 	ItemType ItemType
 	ItemUUID uuid.UUID
 	Price    int
+	//Price the stock drifts back to
+	BasePrice int
+	//-1 for unlimited stock
+	Quantity    int
+	MaxQuantity int
+}
+
+const (
+	//Price change per unit bought or sold in percent of base price
+	STORE_DEMAND_STEP = 5
+	STORE_MAX_PRICE   = 200
+	STORE_MIN_PRICE   = 50
+	//Part of current price paid when player sells item to store
+	STORE_SELL_RATIO = 40
+)
+
+func (s *NPCStore) FindStock(itemUuid uuid.UUID) *Stock {
+	for _, stock := range s.Stock {
+		if stock.ItemUUID == itemUuid {
+			return stock
+		}
+	}
+
+	return nil
+}
+
+func (s *NPCStore) NeedsRestock(day int) bool {
+	if s.LastDelivery == -1 {
+		return true
+	}
+
+	return s.RestockDays > 0 && day-s.LastDelivery >= s.RestockDays
+}
+
+func (s *NPCStore) Restock(day int) {
+	for _, stock := range s.Stock {
+		if stock.MaxQuantity != -1 {
+			stock.Quantity = stock.MaxQuantity
+		}
+	}
+
+	s.LastDelivery = day
+}
+
+// Prices slowly return to base price, called once a day
+func (s *NPCStore) DriftPrices() {
+	for _, stock := range s.Stock {
+		diff := stock.BasePrice - stock.Price
+
+		if diff == 0 {
+			continue
+		}
+
+		change := diff / 4
+
+		if change == 0 {
+			if diff > 0 {
+				change = 1
+			} else {
+				change = -1
+			}
+		}
+
+		stock.Price += change
+	}
+}
+
+func (s *Stock) Available(amount int) bool {
+	return s.Quantity == -1 || s.Quantity >= amount
+}
+
+func (s *Stock) SoldOut() bool {
+	return s.Quantity == 0
+}
+
+func (s *Stock) Take(amount int) {
+	if s.Quantity != -1 {
+		s.Quantity -= amount
+	}
+
+	s.setPrice(s.Price + s.BasePrice*STORE_DEMAND_STEP*amount/100)
+}
+
+func (s *Stock) SellPrice() int {
+	price := s.Price * STORE_SELL_RATIO / 100
+
+	if price < 1 {
+		return 1
+	}
+
+	return price
+}
+
+// Sell price of given instance, quality, enhancement and wear change it
+func (s *Stock) ItemSellPrice(item *PlayerItem) int {
+	price := s.SellPrice() * item.ValuePercent() / 100
+
+	if price < 1 {
+		return 1
+	}
+
+	return price
+}
+
+func (s *Stock) Return(amount int) {
+	if s.Quantity != -1 {
+		s.Quantity += amount
+
+		if s.Quantity > s.MaxQuantity {
+			s.Quantity = s.MaxQuantity
+		}
+	}
+
+	s.setPrice(s.Price - s.BasePrice*STORE_DEMAND_STEP*amount/100)
+}
+
+func (s *Stock) setPrice(price int) {
+	maxPrice := s.BasePrice * STORE_MAX_PRICE / 100
+	minPrice := s.BasePrice * STORE_MIN_PRICE / 100
+
+	if price > maxPrice {
+		price = maxPrice
+	}
+
+	if price < minPrice {
+		price = minPrice
+	}
+
+	s.Price = price
 }
@@ -153,6 +153,159 @@ type PlayerItem struct {
 	Hidden      bool
 	Stats       map[Stat]int
 	Effects     []PlayerSkill
+	Quality     Quality
+	Slot        ItemSlot
+	Equipped    bool
+	Rarity      Rarity
+	//0 for items that never break
+	MaxDurability int
+	Durability    int
+	Enhancement   int
+	//Stats before quality and enhancement
+	BaseStats map[Stat]int
+	//Fury awakened by using this item, uuid.Nil if none
+	Fury uuid.UUID
+}
+
+const MAX_ENHANCEMENT = 10
+
+// Stats bonus per enhancement level in percent
+const ENHANCEMENT_STAT_BONUS = 10
+
+func (item *PlayerItem) Broken() bool {
+	return item.MaxDurability > 0 && item.Durability <= 0
+}
+
+// Equipped and not broken
+func (item *PlayerItem) IsActive() bool {
+	return item.Equipped && !item.Broken()
+}
+
+// Returns true if item broke
+func (item *PlayerItem) Wear(value int) bool {
+	if item.MaxDurability == 0 || item.Broken() {
+		return false
+	}
+
+	item.Durability -= value
+
+	if item.Durability < 0 {
+		item.Durability = 0
+	}
+
+	return item.Broken()
+}
+
+// Stats map is rebuilt so base item from game data stays intact
+func (item *PlayerItem) RecalculateStats() {
+	if item.BaseStats == nil {
+		item.BaseStats = item.Stats
+	}
+
+	stats := make(map[Stat]int)
+
+	for stat, value := range item.BaseStats {
+		value = value * QualityMultiplier[item.Quality] / 100
+		stats[stat] = value * (100 + item.Enhancement*ENHANCEMENT_STAT_BONUS) / 100
+	}
+
+	item.Stats = stats
+}
+
+// Gold and enhancement stones needed for next level
+func (item *PlayerItem) EnhancementCost() (int, int) {
+	return 100 * (item.Enhancement + 1) * (int(item.Rarity) + 1), item.Enhancement + 1
+}
+
+func (item *PlayerItem) EnhancementChance() int {
+	return 100 - item.Enhancement*10
+}
+
+func (item *PlayerItem) RepairCost(pricePerPoint int) int {
+	return (item.MaxDurability - item.Durability) * pricePerPoint * (int(item.Rarity) + 1)
+}
+
+// Instances merge into one stack only when nothing tells them apart
+func (item *PlayerItem) StacksWith(other *PlayerItem) bool {
+	return item.UUID == other.UUID &&
+		item.Quality == other.Quality &&
+		item.Enhancement == other.Enhancement &&
+		item.Durability == other.Durability
+}
+
+// Worth of this instance in percent of base price, broken items are still worth half
+func (item *PlayerItem) ValuePercent() int {
+	value := QualityMultiplier[item.Quality] * (100 + item.Enhancement*ENHANCEMENT_STAT_BONUS) / 100
+
+	if item.MaxDurability > 0 {
+		value = value * (100 + item.Durability*100/item.MaxDurability) / 200
+	}
+
+	return value
+}
+
+func (item *PlayerItem) SetEnhancement(level int) {
+	item.Enhancement = level
+	item.RecalculateStats()
+}
+
+type ItemSlot int
+
+const (
+	//Can't be equipped
+	SLOT_NONE ItemSlot = iota
+	SLOT_WEAPON
+	SLOT_HEAD
+	SLOT_ARMOUR
+	SLOT_ACCESSORY
+	SLOT_RUNE
+)
+
+var ItemSlotToString = map[ItemSlot]string{
+	SLOT_NONE:      "Brak",
+	SLOT_WEAPON:    "Broń",
+	SLOT_HEAD:      "Głowa",
+	SLOT_ARMOUR:    "Zbroja",
+	SLOT_ACCESSORY: "Akcesorium",
+	SLOT_RUNE:      "Runa",
+}
+
+// How many items can be equipped in slot at once
+var SlotCapacity = map[ItemSlot]int{
+	SLOT_WEAPON:    1,
+	SLOT_HEAD:      1,
+	SLOT_ARMOUR:    1,
+	SLOT_ACCESSORY: 2,
+	SLOT_RUNE:      1,
+}
+
+type Quality int
+
+const (
+	QUALITY_NORMAL Quality = iota
+	QUALITY_GOOD
+	QUALITY_EXCELLENT
+	QUALITY_MASTERWORK
+)
+
+var QualityToString = map[Quality]string{
+	QUALITY_NORMAL:     "Zwykła",
+	QUALITY_GOOD:       "Dobra",
+	QUALITY_EXCELLENT:  "Doskonała",
+	QUALITY_MASTERWORK: "Mistrzowska",
+}
+
+// Item stats multiplier in percent
+var QualityMultiplier = map[Quality]int{
+	QUALITY_NORMAL:     100,
+	QUALITY_GOOD:       110,
+	QUALITY_EXCELLENT:  125,
+	QUALITY_MASTERWORK: 150,
+}
+
+func (item *PlayerItem) SetQuality(quality Quality) {
+	item.Quality = quality
+	item.RecalculateStats()
 }
 
 type SkillPath int
@@ -202,6 +355,15 @@ type Recipe struct {
 	Ingredients []WithCount[uuid.UUID]
 	Cost        int
 	Product     ResultItem
+	//Empty when recipe can be crafted anywhere
+	Station EntityLocation
+	//Has to be discovered before it can be crafted
+	Hidden bool
+	//Required crafting level
+	Level int
+	//Base success chance in percent
+	Chance int
+	Exp    int
 }
 
 type ResultItem struct {
@@ -88,3 +88,19 @@ var StringToStat = map[string]types.Stat{
 	"OMNI_VAMP":  types.STAT_OMNI_VAMP,
 	"ATK_VAMP":   types.STAT_ATK_VAMP,
 }
+
+var StringToItemSlot = map[string]types.ItemSlot{
+	"WEAPON":    types.SLOT_WEAPON,
+	"HEAD":      types.SLOT_HEAD,
+	"ARMOUR":    types.SLOT_ARMOUR,
+	"ACCESSORY": types.SLOT_ACCESSORY,
+	"RUNE":      types.SLOT_RUNE,
+}
+
+var StringToRarity = map[string]types.Rarity{
+	"COMMON":    types.RARITY_COMMON,
+	"UNCOMMON":  types.RARITY_UNCOMMON,
+	"RARE":      types.RARITY_RARE,
+	"EPIC":      types.RARITY_EPIC,
+	"LEGENDARY": types.RARITY_LEGENDARY,
+}
@@ -71,6 +71,20 @@ func GetNullableString(state *lua.State, str string) (string, bool) {
 	return value, true
 }
 
+func GetNullableInt(state *lua.State, str string) (int, bool) {
+	state.Global(str)
+
+	value, ok := state.ToInteger(-1)
+
+	if !ok || state.IsNil(-1) {
+		state.Pop(1)
+		return 0, false
+	}
+
+	state.Pop(1)
+	return value, true
+}
+
 func GetTableAsMap(state *lua.State) (map[string]interface{}, error) {
 	if !state.IsTable(-1) {
 		return nil, errors.New("expected table at -1")
@@ -0,0 +1,116 @@
+package auction
+
+import (
+	"sao/player/inventory"
+	"sao/types"
+
+	"github.com/google/uuid"
+)
+
+// Auction length limit in calendar days
+const MaxDuration = 7
+
+// Fee taken from seller when listing, in percent of starting price
+const ListingFeePercent = 5
+const MinListingFee = 10
+
+// Next bid has to be at least this percent higher than current one
+const MinBidStepPercent = 5
+
+type Auction struct {
+	Uuid     uuid.UUID
+	Seller   uuid.UUID
+	Item     uuid.UUID
+	ItemType types.ItemType
+	Count    int
+	//Item in escrow with its quality, enhancement and durability, nil for materials
+	Instance *types.PlayerItem
+	//Minimal first bid
+	StartPrice int
+	//0 when auction can't be bought out
+	Buyout int
+	//Gold held in escrow from highest bidder
+	Bid    int
+	Bidder uuid.UUID
+	//Calendar day numbers
+	Created int
+	Ends    int
+}
+
+func ListingFee(startPrice int) int {
+	fee := startPrice * ListingFeePercent / 100
+
+	if fee < MinListingFee {
+		return MinListingFee
+	}
+
+	return fee
+}
+
+func (a *Auction) HasBids() bool {
+	return a.Bidder != uuid.Nil
+}
+
+func (a *Auction) MinBid() int {
+	if !a.HasBids() {
+		return a.StartPrice
+	}
+
+	step := a.Bid * MinBidStepPercent / 100
+
+	if step < 1 {
+		step = 1
+	}
+
+	return a.Bid + step
+}
+
+func (a *Auction) Expired(day int) bool {
+	return day >= a.Ends
+}
+
+func (a *Auction) Serialize() map[string]interface{} {
+	var instance map[string]interface{}
+
+	if a.Instance != nil {
+		instance = inventory.SerializeItem(a.Instance)
+	}
+
+	return map[string]interface{}{
+		"uuid":        a.Uuid.String(),
+		"seller":      a.Seller.String(),
+		"item":        a.Item.String(),
+		"item_type":   a.ItemType,
+		"count":       a.Count,
+		"instance":    instance,
+		"start_price": a.StartPrice,
+		"buyout":      a.Buyout,
+		"bid":         a.Bid,
+		"bidder":      a.Bidder.String(),
+		"created":     a.Created,
+		"ends":        a.Ends,
+	}
+}
+
+func Deserialize(data map[string]interface{}) *Auction {
+	auctionObj := &Auction{
+		Uuid:       uuid.MustParse(data["uuid"].(string)),
+		Seller:     uuid.MustParse(data["seller"].(string)),
+		Item:       uuid.MustParse(data["item"].(string)),
+		ItemType:   types.ItemType(data["item_type"].(float64)),
+		Count:      int(data["count"].(float64)),
+		StartPrice: int(data["start_price"].(float64)),
+		Buyout:     int(data["buyout"].(float64)),
+		Bid:        int(data["bid"].(float64)),
+		Bidder:     uuid.MustParse(data["bidder"].(string)),
+		Created:    int(data["created"].(float64)),
+		Ends:       int(data["ends"].(float64)),
+	}
+
+	//Materials have no instance
+	if rawInstance, ok := data["instance"].(map[string]interface{}); ok {
+		auctionObj.Instance = inventory.DeserializeItem(rawInstance)
+	}
+
+	return auctionObj
+}
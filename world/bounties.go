@@ -0,0 +1,240 @@
+package world
+
+import (
+	"errors"
+	"sao/types"
+	"sao/utils"
+	"sao/world/bounty"
+	"sao/world/location"
+	"sort"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Enemies from unlocked locations on floor
+func (w *World) floorEnemies(floorName string) []location.EnemyMeta {
+	enemies := make([]location.EnemyMeta, 0)
+
+	for _, loc := range w.Floors[floorName].Locations {
+		if !loc.Unlocked {
+			continue
+		}
+
+		//Bounties last all day, so only the season matters
+		for _, enemy := range loc.Enemies {
+			if (location.Schedule{Seasons: enemy.Schedule.Seasons}).Active(w.Time) {
+				enemies = append(enemies, enemy)
+			}
+		}
+	}
+
+	return enemies
+}
+
+// Rerolls boards on calendar boundaries and drops stale bounties from players
+func (w *World) RefreshBounties() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	day := w.Time.DayNumber()
+	refreshed := false
+
+	for floorName := range w.Floors {
+		enemies := w.floorEnemies(floorName)
+
+		if len(enemies) == 0 {
+			continue
+		}
+
+		board, exists := w.Bounties[floorName]
+
+		if !exists {
+			board = &bounty.Board{Floor: floorName, Day: -1, Week: -1, Daily: make([]*bounty.Bounty, 0)}
+
+			w.Bounties[floorName] = board
+		}
+
+		if board.Refresh(enemies, day) {
+			refreshed = true
+		}
+	}
+
+	if !refreshed {
+		return
+	}
+
+	for _, playerObj := range w.Players {
+		playerObj.PruneBounties(func(bountyUuid uuid.UUID) bool {
+			return w.findBounty(bountyUuid) != nil
+		})
+	}
+}
+
+func (w *World) findBounty(bountyUuid uuid.UUID) *bounty.Bounty {
+	for _, board := range w.Bounties {
+		if bountyData := board.Find(bountyUuid); bountyData != nil {
+			return bountyData
+		}
+	}
+
+	return nil
+}
+
+// Boards sorted by floor name
+func (w *World) BountyBoards() []*bounty.Board {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	boards := make([]*bounty.Board, 0)
+
+	for _, board := range w.Bounties {
+		boards = append(boards, board)
+	}
+
+	sort.Slice(boards, func(i, j int) bool { return boards[i].Floor < boards[j].Floor })
+
+	return boards
+}
+
+func (w *World) GetBounty(bountyUuid uuid.UUID) *bounty.Bounty {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	return w.findBounty(bountyUuid)
+}
+
+func (w *World) AcceptBounty(pUuid, bountyUuid uuid.UUID) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	playerObj := w.Players[pUuid]
+
+	if !w.inCity(playerObj) {
+		return errors.New("NOT_IN_CITY")
+	}
+
+	bountyData := w.findBounty(bountyUuid)
+
+	if bountyData == nil {
+		return errors.New("BOUNTY_NOT_FOUND")
+	}
+
+	return playerObj.AcceptBounty(bountyData)
+}
+
+// Target is mob id for kills and ingredient uuid for gathering
+func (w *World) ProgressBounties(pUuid uuid.UUID, bountyType bounty.BountyType, target string, amount int) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	w.progressBounties(pUuid, bountyType, target, amount)
+}
+
+// Caller has to hold StateLock
+func (w *World) progressBounties(pUuid uuid.UUID, bountyType bounty.BountyType, target string, amount int) {
+	playerObj, exists := w.Players[pUuid]
+
+	if !exists {
+		return
+	}
+
+	for bountyUuid, progress := range playerObj.Meta.Bounties.Active {
+		bountyData := w.findBounty(bountyUuid)
+
+		if bountyData == nil || bountyData.Type != bountyType || progress >= bountyData.Count {
+			continue
+		}
+
+		if (bountyType == bounty.BOUNTY_KILL && bountyData.Mob != target) || (bountyType == bounty.BOUNTY_GATHER && bountyData.Item.String() != target) {
+			continue
+		}
+
+		progress = min(progress+amount, bountyData.Count)
+
+		playerObj.Meta.Bounties.Active[bountyUuid] = progress
+
+		if progress == bountyData.Count {
+			w.BufferChannel <- types.DiscordMessageStruct{
+				ChannelID: playerObj.Meta.UserID,
+				MessageContent: discord.NewMessageCreateBuilder().
+					SetContent("Zlecenie wykonane, odbierz nagrodę przy tablicy w mieście").
+					Build(),
+				DM: true,
+			}
+		}
+	}
+}
+
+// Progress of accepted bounty, deliveries are counted from inventory
+func (w *World) BountyProgress(pUuid uuid.UUID, bountyData *bounty.Bounty) int {
+	playerObj := w.Players[pUuid]
+
+	if bountyData.Type != bounty.BOUNTY_DELIVER {
+		return playerObj.Meta.Bounties.Active[bountyData.Uuid]
+	}
+
+	if bountyData.ItemType == types.ITEM_MATERIAL {
+		if ingredient, ok := playerObj.Inventory.Ingredients[bountyData.Item]; ok {
+			return min(ingredient.Count, bountyData.Count)
+		}
+
+		return 0
+	}
+
+	return min(playerObj.Inventory.CountItem(bountyData.Item), bountyData.Count)
+}
+
+// Returns exp and gold granted with streak bonus
+func (w *World) ClaimBounty(pUuid, bountyUuid uuid.UUID) (int, int, error) {
+	w.StateLock.Lock()
+
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.FightInstance != nil {
+		w.StateLock.Unlock()
+		return 0, 0, errors.New("IN_FIGHT")
+	}
+
+	if !w.inCity(playerObj) {
+		w.StateLock.Unlock()
+		return 0, 0, errors.New("NOT_IN_CITY")
+	}
+
+	if _, active := playerObj.Meta.Bounties.Active[bountyUuid]; !active {
+		w.StateLock.Unlock()
+		return 0, 0, errors.New("BOUNTY_NOT_ACCEPTED")
+	}
+
+	bountyData := w.findBounty(bountyUuid)
+
+	if bountyData == nil {
+		w.StateLock.Unlock()
+		return 0, 0, errors.New("BOUNTY_NOT_FOUND")
+	}
+
+	if w.BountyProgress(pUuid, bountyData) < bountyData.Count {
+		w.StateLock.Unlock()
+		return 0, 0, errors.New("BOUNTY_INCOMPLETE")
+	}
+
+	if bountyData.Type == bounty.BOUNTY_DELIVER {
+		if bountyData.ItemType == types.ITEM_MATERIAL {
+			playerObj.Inventory.RemoveIngredients([]types.Ingredient{{UUID: bountyData.Item, Count: bountyData.Count}})
+		} else {
+			playerObj.Inventory.TakeItem(bountyData.Item, bountyData.Count)
+		}
+	}
+
+	bonus := playerObj.ClaimBounty(bountyData, w.Time.DayNumber())
+
+	exp := bountyData.Exp + utils.PercentOf(bountyData.Exp, bonus)
+	gold := bountyData.Gold + utils.PercentOf(bountyData.Gold, bonus)
+
+	w.StateLock.Unlock()
+
+	w.GiveLoot(playerObj, types.Loot{Type: types.LOOT_EXP, Count: exp})
+	w.GiveLoot(playerObj, types.Loot{Type: types.LOOT_GOLD, Count: gold})
+
+	return exp, gold, nil
+}
@@ -0,0 +1,292 @@
+package bounty
+
+import (
+	"sao/battle/mobs"
+	"sao/types"
+	"sao/utils"
+	"sao/world/location"
+
+	"github.com/google/uuid"
+)
+
+// Daily bounties rolled per board
+const DailyCount = 3
+
+// Calendar days in a week, weekly bounty is rolled on week change
+const WeekLength = 7
+
+// Reward bonus per streak day in percent
+const StreakBonusPercent = 10
+const MaxStreakBonus = 50
+
+// Weekly bounty counts and rewards are multiplied by this
+const WeeklyMultiplier = 3
+
+type BountyType int
+
+const (
+	BOUNTY_KILL BountyType = iota
+	//Ingredient has to be looted after accepting
+	BOUNTY_GATHER
+	//Item or ingredient is handed in at the board
+	BOUNTY_DELIVER
+)
+
+type Bounty struct {
+	Uuid uuid.UUID
+	Type BountyType
+	//Mob id the bounty comes from, killed mob for kill bounties
+	Mob string
+	//Item for gather and deliver bounties
+	ItemType types.ItemType
+	Item     uuid.UUID
+	Count    int
+	Exp      int
+	Gold     int
+	Weekly   bool
+}
+
+type Board struct {
+	Floor string
+	//Day and week numbers bounties were rolled for
+	Day    int
+	Week   int
+	Daily  []*Bounty
+	Weekly *Bounty
+}
+
+func WeekNumber(day int) int {
+	return day / WeekLength
+}
+
+func StreakBonus(streak int) int {
+	return min(streak*StreakBonusPercent, MaxStreakBonus)
+}
+
+func (b *Board) All() []*Bounty {
+	bounties := make([]*Bounty, 0, len(b.Daily)+1)
+
+	bounties = append(bounties, b.Daily...)
+
+	if b.Weekly != nil {
+		bounties = append(bounties, b.Weekly)
+	}
+
+	return bounties
+}
+
+func (b *Board) Find(bountyUuid uuid.UUID) *Bounty {
+	for _, bounty := range b.All() {
+		if bounty.Uuid == bountyUuid {
+			return bounty
+		}
+	}
+
+	return nil
+}
+
+// Rolls bounties again if day or week changed, returns true if anything was rolled
+func (b *Board) Refresh(enemies []location.EnemyMeta, day int) bool {
+	refreshed := false
+
+	if b.Day != day {
+		b.Day = day
+		b.Daily = make([]*Bounty, 0)
+
+		for i := 0; i < DailyCount; i++ {
+			if bounty := Roll(enemies, false); bounty != nil {
+				b.Daily = append(b.Daily, bounty)
+			}
+		}
+
+		refreshed = true
+	}
+
+	if b.Week != WeekNumber(day) {
+		b.Week = WeekNumber(day)
+		b.Weekly = Roll(enemies, true)
+
+		refreshed = true
+	}
+
+	return refreshed
+}
+
+// Materials and items mob can drop outside of first kill loot
+func mobDrops(mob mobs.MobEntity) []types.LootMeta {
+	drops := make([]types.LootMeta, 0)
+
+	var collect func(entries []types.LootEntry)
+
+	collect = func(entries []types.LootEntry) {
+		for _, entry := range entries {
+			if entry.FirstKill {
+				continue
+			}
+
+			if len(entry.Pool) > 0 {
+				collect(entry.Pool)
+				continue
+			}
+
+			if entry.Loot.Type == types.LOOT_ITEM && entry.Loot.Meta != nil {
+				drops = append(drops, *entry.Loot.Meta)
+			}
+		}
+	}
+
+	collect(mob.LootTable)
+
+	return drops
+}
+
+// Exp and gold mob gives on average
+func mobRewards(mob mobs.MobEntity) (int, int) {
+	exp, gold := 0, 0
+
+	for _, entry := range mob.LootTable {
+		switch entry.Loot.Type {
+		case types.LOOT_EXP:
+			exp += (entry.Min + entry.Max) / 2
+		case types.LOOT_GOLD:
+			gold += (entry.Min + entry.Max) / 2
+		}
+	}
+
+	return exp, gold
+}
+
+// Returns nil if there is nothing to hunt
+func Roll(enemies []location.EnemyMeta, weekly bool) *Bounty {
+	if len(enemies) == 0 {
+		return nil
+	}
+
+	enemy := enemies[utils.RandomNumber(0, len(enemies)-1)]
+
+	mob, exists := mobs.Mobs[enemy.Enemy]
+
+	if !exists {
+		return nil
+	}
+
+	bounty := &Bounty{
+		Uuid:   uuid.New(),
+		Type:   BOUNTY_KILL,
+		Mob:    mob.Id,
+		Count:  utils.RandomNumber(3, 6),
+		Weekly: weekly,
+	}
+
+	exp, gold := mobRewards(mob)
+
+	drops := mobDrops(mob)
+
+	if len(drops) > 0 {
+		switch utils.RandomNumber(0, 2) {
+		case 1:
+			drop := drops[utils.RandomNumber(0, len(drops)-1)]
+
+			if drop.Type == types.ITEM_MATERIAL {
+				bounty.Type = BOUNTY_GATHER
+				bounty.ItemType = drop.Type
+				bounty.Item = drop.Uuid
+				bounty.Count = utils.RandomNumber(2, 4)
+			}
+		case 2:
+			drop := drops[utils.RandomNumber(0, len(drops)-1)]
+
+			bounty.Type = BOUNTY_DELIVER
+			bounty.ItemType = drop.Type
+			bounty.Item = drop.Uuid
+			bounty.Count = utils.RandomNumber(1, 3)
+		}
+	}
+
+	//Drops are rarer than kills, so every item is worth few kills
+	killsWorth := bounty.Count
+
+	if bounty.Type != BOUNTY_KILL {
+		killsWorth *= 2
+	}
+
+	bounty.Exp = exp * killsWorth / 2
+	bounty.Gold = gold * killsWorth / 2
+
+	if weekly {
+		bounty.Count *= WeeklyMultiplier
+		bounty.Exp *= WeeklyMultiplier
+		bounty.Gold *= WeeklyMultiplier
+	}
+
+	return bounty
+}
+
+func (b *Bounty) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"uuid":      b.Uuid.String(),
+		"type":      b.Type,
+		"mob":       b.Mob,
+		"item_type": b.ItemType,
+		"item":      b.Item.String(),
+		"count":     b.Count,
+		"exp":       b.Exp,
+		"gold":      b.Gold,
+		"weekly":    b.Weekly,
+	}
+}
+
+func DeserializeBounty(data map[string]interface{}) *Bounty {
+	return &Bounty{
+		Uuid:     uuid.MustParse(data["uuid"].(string)),
+		Type:     BountyType(data["type"].(float64)),
+		Mob:      data["mob"].(string),
+		ItemType: types.ItemType(data["item_type"].(float64)),
+		Item:     uuid.MustParse(data["item"].(string)),
+		Count:    int(data["count"].(float64)),
+		Exp:      int(data["exp"].(float64)),
+		Gold:     int(data["gold"].(float64)),
+		Weekly:   data["weekly"].(bool),
+	}
+}
+
+func (b *Board) Serialize() map[string]interface{} {
+	daily := make([]map[string]interface{}, 0)
+
+	for _, bounty := range b.Daily {
+		daily = append(daily, bounty.Serialize())
+	}
+
+	var weekly map[string]interface{}
+
+	if b.Weekly != nil {
+		weekly = b.Weekly.Serialize()
+	}
+
+	return map[string]interface{}{
+		"floor":  b.Floor,
+		"day":    b.Day,
+		"week":   b.Week,
+		"daily":  daily,
+		"weekly": weekly,
+	}
+}
+
+func DeserializeBoard(data map[string]interface{}) *Board {
+	board := &Board{
+		Floor: data["floor"].(string),
+		Day:   int(data["day"].(float64)),
+		Week:  int(data["week"].(float64)),
+		Daily: make([]*Bounty, 0),
+	}
+
+	for _, rawBounty := range data["daily"].([]interface{}) {
+		board.Daily = append(board.Daily, DeserializeBounty(rawBounty.(map[string]interface{})))
+	}
+
+	if rawWeekly, ok := data["weekly"].(map[string]interface{}); ok {
+		board.Weekly = DeserializeBounty(rawWeekly)
+	}
+
+	return board
+}
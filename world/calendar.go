@@ -0,0 +1,68 @@
+package world
+
+import (
+	"sao/battle"
+	"sao/types"
+	"sao/world/calendar"
+	"sao/world/location"
+
+	"github.com/google/uuid"
+)
+
+type CalendarHook func(w *World)
+
+func defaultCalendarHooks() map[calendar.Boundary][]CalendarHook {
+	return map[calendar.Boundary][]CalendarHook{
+		calendar.NEW_HOUR: {
+			(*World).ExpireEvents,
+			(*World).RunScheduledEvents,
+		},
+		calendar.NEW_DAY: {
+			func(w *World) { w.UpdateStores(true) },
+			(*World).CleanupAuctions,
+			(*World).CleanupDroppedItems,
+			(*World).RefreshBounties,
+		},
+	}
+}
+
+// Hooks run from the clock goroutine, in registration order
+func (w *World) OnCalendar(boundary calendar.Boundary, hook CalendarHook) {
+	w.CalendarHooks[boundary] = append(w.CalendarHooks[boundary], hook)
+}
+
+func (w *World) RunCalendarHooks(boundary calendar.Boundary) {
+	for _, hook := range w.CalendarHooks[boundary] {
+		hook(w)
+	}
+}
+
+// Stat effects last for the whole fight, players lose them on deregister
+func applyLocationEffects(entities battle.EntityMap, effects []location.LocationEffect) {
+	for _, effect := range effects {
+		if types.Effect(effect.Effect) != types.EFFECT_STAT_INC && types.Effect(effect.Effect) != types.EFFECT_STAT_DEC {
+			continue
+		}
+
+		for _, entry := range entities {
+			isMob := entry.Entity.GetFlags()&types.ENTITY_AUTO != 0
+
+			if (effect.Target == location.TARGET_PLAYERS && isMob) || (effect.Target == location.TARGET_MOBS && !isMob) {
+				continue
+			}
+
+			entry.Entity.ApplyEffect(types.ActionEffect{
+				Effect:   types.Effect(effect.Effect),
+				Value:    effect.Value,
+				Duration: -1,
+				Uuid:     uuid.New(),
+				Meta: types.ActionEffectStat{
+					Stat:      effect.Stat,
+					Value:     effect.Value,
+					IsPercent: effect.IsPercent,
+				},
+				Source: types.SOURCE_LOCATION,
+			})
+		}
+	}
+}
@@ -20,6 +20,21 @@ const (
 	WINTER
 )
 
+// Boundary marks a calendar transition other systems can hook into
+type Boundary int
+
+const (
+	NEW_HOUR Boundary = iota
+	NEW_DAY
+	NEW_SEASON
+)
+
+const (
+	HoursInDay = 24
+	NightStart = 20
+	NightEnd   = 6
+)
+
 type Calendar struct {
 	Day   int
 	Month Month
@@ -66,18 +81,68 @@ func (c *Calendar) GetSeason() Season {
 	return -1
 }
 
-func (c *Calendar) Tick() {
+func SeasonToString(season Season) string {
+	switch season {
+	case SPRING:
+		return "Wiosna"
+	case SUMMER:
+		return "Lato"
+	case AUTUMN:
+		return "Jesień"
+	case WINTER:
+		return "Zima"
+	}
+
+	return "Nieznana"
+}
+
+var StringToSeason = map[string]Season{
+	"SPRING": SPRING,
+	"SUMMER": SUMMER,
+	"AUTUMN": AUTUMN,
+	"WINTER": WINTER,
+}
+
+func (c *Calendar) IsNight() bool {
+	return c.InHours(NightStart, NightEnd)
+}
+
+// Range is [from, to), wraps around midnight when from > to
+func (c *Calendar) InHours(from, to int) bool {
+	if from <= to {
+		return c.Time.Hour >= from && c.Time.Hour < to
+	}
+
+	return c.Time.Hour >= from || c.Time.Hour < to
+}
+
+// Returns boundaries crossed by this tick
+func (c *Calendar) Tick() []Boundary {
+	crossed := make([]Boundary, 0)
+
 	c.Time.Tick++
 
 	if c.Time.Tick > 12 {
 		c.Time.Tick = 0
 		c.Time.Hour++
+
+		crossed = append(crossed, NEW_HOUR)
 	}
 
-	if c.Time.Hour > 24 {
+	if c.Time.Hour >= HoursInDay {
+		season := c.GetSeason()
+
 		c.Time.Hour = 0
 		c.AddDay()
+
+		crossed = append(crossed, NEW_DAY)
+
+		if season != c.GetSeason() {
+			crossed = append(crossed, NEW_SEASON)
+		}
 	}
+
+	return crossed
 }
 
 func StartCalendar() *Calendar {
@@ -102,6 +167,15 @@ func (c *Calendar) String() string {
 	return fmt.Sprintf("%d/%d/%d %d:%d", c.Day, c.Month+1, c.Year, c.Time.Hour, c.Time.Tick)
 }
 
+// Number of days since start of the calendar
+func (c *Calendar) DayNumber() int {
+	return (c.Year-1)*120 + int(c.Month)*30 + c.Day - 1
+}
+
+func DateFromDayNumber(day int) string {
+	return fmt.Sprintf("%d/%d/%d", day%30+1, (day/30)%4+1, day/120+1)
+}
+
 func (c *Calendar) Serialize() map[string]interface{} {
 	return map[string]interface{}{
 		"day":   c.Day,
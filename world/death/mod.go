@@ -0,0 +1,90 @@
+package death
+
+import (
+	"sao/player/inventory"
+	"sao/types"
+
+	"github.com/google/uuid"
+)
+
+// Item lost on death, anyone can pick it up at location
+type DroppedItem struct {
+	Uuid     uuid.UUID
+	Owner    uuid.UUID
+	Item     *types.PlayerItem
+	Location types.EntityLocation
+	//Calendar day after which item disappears
+	Expires int
+}
+
+// Hardcore character that died for good
+type Fallen struct {
+	Name     string
+	UserID   string
+	Level    int
+	Kills    int
+	Location types.EntityLocation
+	//Calendar day of death
+	Day int
+}
+
+func (d *DroppedItem) Expired(day int) bool {
+	return day > d.Expires
+}
+
+func (d *DroppedItem) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"uuid":     d.Uuid.String(),
+		"owner":    d.Owner.String(),
+		"item":     inventory.SerializeItem(d.Item),
+		"location": []string{d.Location.Floor, d.Location.Location},
+		"expires":  d.Expires,
+	}
+}
+
+// Returns nil if item was removed from game data
+func DeserializeDroppedItem(data map[string]interface{}) *DroppedItem {
+	item := inventory.DeserializeItem(data["item"].(map[string]interface{}))
+
+	if item == nil {
+		return nil
+	}
+
+	item.Equipped = false
+
+	return &DroppedItem{
+		Uuid:  uuid.MustParse(data["uuid"].(string)),
+		Owner: uuid.MustParse(data["owner"].(string)),
+		Item:  item,
+		Location: types.EntityLocation{
+			Floor:    data["location"].([]interface{})[0].(string),
+			Location: data["location"].([]interface{})[1].(string),
+		},
+		Expires: int(data["expires"].(float64)),
+	}
+}
+
+func (f *Fallen) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"name":     f.Name,
+		"uid":      f.UserID,
+		"level":    f.Level,
+		"kills":    f.Kills,
+		"location": []string{f.Location.Floor, f.Location.Location},
+		"day":      f.Day,
+	}
+}
+
+func DeserializeFallen(data map[string]interface{}) *Fallen {
+	return &Fallen{
+		Name:   data["name"].(string),
+		UserID: data["uid"].(string),
+		Level:  int(data["level"].(float64)),
+		Kills:  int(data["kills"].(float64)),
+		Location: types.EntityLocation{
+			Floor:    data["location"].([]interface{})[0].(string),
+			Location: data["location"].([]interface{})[1].(string),
+		},
+		Day: int(data["day"].(float64)),
+	}
+}
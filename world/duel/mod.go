@@ -0,0 +1,90 @@
+package duel
+
+import (
+	"math"
+	"sao/types"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ChallengeDuration = 5 * time.Minute
+
+const StartingRating = 1000
+
+// Max rating change from single fight
+const KFactor = 32
+
+// Results kept in player history
+const MaxHistory = 10
+
+type Challenge struct {
+	Uuid       uuid.UUID
+	Challenger uuid.UUID
+	Opponent   uuid.UUID
+	//Gold put up by each side, winner takes both
+	Stake int
+	//HP and mana go back to values from before the duel
+	RestoreHP bool
+	Location  types.EntityLocation
+	Expires   time.Time
+	//Set once accepted, stakes are held until duel ends
+	Fight *uuid.UUID
+	//Taken when duel starts, used to restore HP
+	Snapshots map[uuid.UUID]Snapshot
+}
+
+type Snapshot struct {
+	HP   int
+	Mana int
+}
+
+func (c *Challenge) Expired() bool {
+	return c.Fight == nil && time.Now().After(c.Expires)
+}
+
+func (c *Challenge) Involves(pUuid uuid.UUID) bool {
+	return c.Challenger == pUuid || c.Opponent == pUuid
+}
+
+type Result struct {
+	Opponent string
+	Won      bool
+	Stake    int
+	//Rating change for the player this result belongs to
+	RatingChange int
+	//Calendar day number
+	Day int
+}
+
+// Chance of player with rating a beating player with rating b
+func Expected(a, b int) float64 {
+	return 1 / (1 + math.Pow(10, float64(b-a)/400))
+}
+
+// Points moved from loser to winner
+func RatingChange(winner, loser int) int {
+	change := int(math.Round(KFactor * (1 - Expected(winner, loser))))
+
+	return max(change, 1)
+}
+
+func (r Result) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"opponent": r.Opponent,
+		"won":      r.Won,
+		"stake":    r.Stake,
+		"rating":   r.RatingChange,
+		"day":      r.Day,
+	}
+}
+
+func DeserializeResult(data map[string]interface{}) Result {
+	return Result{
+		Opponent:     data["opponent"].(string),
+		Won:          data["won"].(bool),
+		Stake:        int(data["stake"].(float64)),
+		RatingChange: int(data["rating"].(float64)),
+		Day:          int(data["day"].(float64)),
+	}
+}
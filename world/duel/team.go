@@ -0,0 +1,122 @@
+package duel
+
+import (
+	"sao/types"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Team results kept in world history
+const MaxTeamResults = 20
+
+// Party against party, HP is always restored afterwards
+type TeamChallenge struct {
+	Uuid uuid.UUID
+	//Party uuids
+	Challenger uuid.UUID
+	Opponent   uuid.UUID
+	Location   types.EntityLocation
+	Expires    time.Time
+	//Set once accepted
+	Fight     *uuid.UUID
+	Snapshots map[uuid.UUID]Snapshot
+	//Members at the moment of accepting, side 0 and side 1
+	Teams [2][]uuid.UUID
+}
+
+func (c *TeamChallenge) Expired() bool {
+	return c.Fight == nil && time.Now().After(c.Expires)
+}
+
+func (c *TeamChallenge) Involves(partyUuid uuid.UUID) bool {
+	return c.Challenger == partyUuid || c.Opponent == partyUuid
+}
+
+// Same players make the same team no matter the party they fight in
+func TeamKey(players []uuid.UUID) string {
+	keys := make([]string, 0)
+
+	for _, player := range players {
+		keys = append(keys, player.String())
+	}
+
+	slices.Sort(keys)
+
+	return strings.Join(keys, ",")
+}
+
+type TeamRating struct {
+	//Names from the last fight
+	Players []string
+	Rating  int
+	Wins    int
+	Losses  int
+}
+
+func NewTeamRating(players []string) *TeamRating {
+	return &TeamRating{Players: players, Rating: StartingRating, Wins: 0, Losses: 0}
+}
+
+type TeamResult struct {
+	Winners []string
+	Losers  []string
+	//Rating moved from losers to winners
+	RatingChange int
+	//Calendar day number
+	Day int
+}
+
+func (r *TeamRating) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"players": r.Players,
+		"rating":  r.Rating,
+		"wins":    r.Wins,
+		"losses":  r.Losses,
+	}
+}
+
+func DeserializeTeamRating(data map[string]interface{}) *TeamRating {
+	rating := &TeamRating{
+		Players: make([]string, 0),
+		Rating:  int(data["rating"].(float64)),
+		Wins:    int(data["wins"].(float64)),
+		Losses:  int(data["losses"].(float64)),
+	}
+
+	for _, name := range data["players"].([]interface{}) {
+		rating.Players = append(rating.Players, name.(string))
+	}
+
+	return rating
+}
+
+func (r TeamResult) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"winners": r.Winners,
+		"losers":  r.Losers,
+		"rating":  r.RatingChange,
+		"day":     r.Day,
+	}
+}
+
+func DeserializeTeamResult(data map[string]interface{}) TeamResult {
+	result := TeamResult{
+		Winners:      make([]string, 0),
+		Losers:       make([]string, 0),
+		RatingChange: int(data["rating"].(float64)),
+		Day:          int(data["day"].(float64)),
+	}
+
+	for _, name := range data["winners"].([]interface{}) {
+		result.Winners = append(result.Winners, name.(string))
+	}
+
+	for _, name := range data["losers"].([]interface{}) {
+		result.Losers = append(result.Losers, name.(string))
+	}
+
+	return result
+}
@@ -0,0 +1,300 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/battle"
+	"sao/player"
+	"sao/types"
+	"sao/world/duel"
+	"slices"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Duels are fought in arenas and in the city
+func (w *World) canDuelAt(location types.EntityLocation) bool {
+	loc := w.Floors[location.Floor].FindLocation(location.Location)
+
+	return loc != nil && (loc.CityPart || slices.Contains(loc.Flags, "arena"))
+}
+
+func (w *World) checkDuelist(playerObj *player.Player, location types.EntityLocation, stake int) error {
+	if playerObj.Meta.FightInstance != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	if playerObj.IsTraveling() {
+		return errors.New("TRAVELING")
+	}
+
+	if playerObj.GetCurrentHP() <= 0 {
+		return errors.New("PLAYER_DEAD")
+	}
+
+	if playerObj.Meta.Location != location {
+		return errors.New("NOT_HERE")
+	}
+
+	if playerObj.Inventory.Gold < stake {
+		return errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	return nil
+}
+
+func (w *World) ChallengeDuel(challengerUuid, opponentUuid uuid.UUID, stake int, restoreHP bool) (*duel.Challenge, error) {
+	if challengerUuid == opponentUuid {
+		return nil, errors.New("SELF_CHALLENGE")
+	}
+
+	if stake < 0 {
+		return nil, errors.New("INVALID_STAKE")
+	}
+
+	challenger := w.Players[challengerUuid]
+	opponent := w.Players[opponentUuid]
+
+	if !w.canDuelAt(challenger.Meta.Location) {
+		return nil, errors.New("LOCATION_NOT_ALLOWED")
+	}
+
+	if err := w.checkDuelist(challenger, challenger.Meta.Location, stake); err != nil {
+		return nil, err
+	}
+
+	if err := w.checkDuelist(opponent, challenger.Meta.Location, stake); err != nil {
+		return nil, errors.New("OPPONENT_" + err.Error())
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	for challengeUuid, challenge := range w.Duels {
+		if challenge.Expired() {
+			delete(w.Duels, challengeUuid)
+			continue
+		}
+
+		if challenge.Involves(challengerUuid) && challenge.Involves(opponentUuid) {
+			return nil, errors.New("ALREADY_CHALLENGED")
+		}
+	}
+
+	challenge := &duel.Challenge{
+		Uuid:       uuid.New(),
+		Challenger: challengerUuid,
+		Opponent:   opponentUuid,
+		Stake:      stake,
+		RestoreHP:  restoreHP,
+		Location:   challenger.Meta.Location,
+		Expires:    time.Now().Add(duel.ChallengeDuration),
+		Fight:      nil,
+	}
+
+	w.Duels[challenge.Uuid] = challenge
+
+	return challenge, nil
+}
+
+// Either side can call off a challenge before it starts
+func (w *World) DeclineDuel(challengeUuid, pUuid uuid.UUID) (*duel.Challenge, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	challenge, exists := w.Duels[challengeUuid]
+
+	if !exists || challenge.Fight != nil {
+		return nil, errors.New("CHALLENGE_NOT_FOUND")
+	}
+
+	if !challenge.Involves(pUuid) {
+		return nil, errors.New("NOT_ALLOWED")
+	}
+
+	delete(w.Duels, challengeUuid)
+
+	return challenge, nil
+}
+
+// Stakes of both players are taken into escrow when the duel starts
+func (w *World) AcceptDuel(challengeUuid, pUuid uuid.UUID) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	challenge, exists := w.Duels[challengeUuid]
+
+	if !exists || challenge.Fight != nil {
+		return errors.New("CHALLENGE_NOT_FOUND")
+	}
+
+	if challenge.Expired() {
+		delete(w.Duels, challengeUuid)
+
+		return errors.New("CHALLENGE_EXPIRED")
+	}
+
+	if challenge.Opponent != pUuid {
+		return errors.New("NOT_ALLOWED")
+	}
+
+	challenger, exists := w.Players[challenge.Challenger]
+
+	//Challenger could have died for good since
+	if !exists {
+		delete(w.Duels, challengeUuid)
+
+		return errors.New("CHALLENGE_NOT_FOUND")
+	}
+
+	opponent := w.Players[challenge.Opponent]
+
+	if err := w.checkDuelist(opponent, challenge.Location, challenge.Stake); err != nil {
+		return err
+	}
+
+	if err := w.checkDuelist(challenger, challenge.Location, challenge.Stake); err != nil {
+		return errors.New("OPPONENT_" + err.Error())
+	}
+
+	challenger.Inventory.Gold -= challenge.Stake
+	opponent.Inventory.Gold -= challenge.Stake
+
+	challenge.Snapshots = map[uuid.UUID]duel.Snapshot{
+		challenger.GetUUID(): {HP: challenger.GetCurrentHP(), Mana: challenger.GetCurrentMana()},
+		opponent.GetUUID():   {HP: opponent.GetCurrentHP(), Mana: opponent.GetCurrentMana()},
+	}
+
+	arena := w.Floors[challenge.Location.Floor].FindLocation(challenge.Location.Location)
+
+	fight := battle.Fight{
+		Entities: battle.EntityMap{
+			challenger.GetUUID(): {Entity: challenger, Side: 0},
+			opponent.GetUUID():   {Entity: opponent, Side: 1},
+		},
+		DiscordChannel: w.BufferChannel,
+		Location:       arena,
+		Meta: &battle.FightMeta{
+			Duel: &battle.DuelData{Challenge: challenge.Uuid},
+		},
+	}
+
+	fight.Init()
+
+	fightUuid := w.RegisterFight(&fight)
+
+	challenger.Meta.FightInstance = &fightUuid
+	opponent.Meta.FightInstance = &fightUuid
+
+	challenge.Fight = &fightUuid
+
+	go w.ListenForFight(fightUuid)
+
+	return nil
+}
+
+// Runaway forfeits, the player left standing wins
+func (w *World) FinishDuel(challengeUuid uuid.UUID, fight *battle.Fight) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	challenge, exists := w.Duels[challengeUuid]
+
+	delete(w.Duels, challengeUuid)
+
+	if !exists {
+		return
+	}
+
+	challenger, challengerExists := w.Players[challenge.Challenger]
+	opponent, opponentExists := w.Players[challenge.Opponent]
+
+	//Player still in the world gets their stake back
+	if !challengerExists || !opponentExists {
+		if challengerExists {
+			challenger.AddGold(challenge.Stake)
+		}
+
+		if opponentExists {
+			opponent.AddGold(challenge.Stake)
+		}
+
+		return
+	}
+
+	var winner, loser *player.Player
+
+	//Summons stand on their owner's side
+	if sides := fight.SidesLeft(); len(sides) == 1 {
+		for _, entity := range fight.FromSide(sides[0]) {
+			switch entity.GetUUID() {
+			case challenge.Challenger:
+				winner, loser = challenger, opponent
+			case challenge.Opponent:
+				winner, loser = opponent, challenger
+			}
+		}
+	}
+
+	for _, duelist := range []*player.Player{challenger, opponent} {
+		if challenge.RestoreHP {
+			snapshot := challenge.Snapshots[duelist.GetUUID()]
+
+			duelist.Stats.HP = snapshot.HP
+			duelist.Stats.CurrentMana = snapshot.Mana
+		}
+
+		//Duels are never lethal
+		if duelist.Stats.HP <= 0 {
+			duelist.Stats.HP = 1
+		}
+	}
+
+	if winner == nil {
+		challenger.AddGold(challenge.Stake)
+		opponent.AddGold(challenge.Stake)
+
+		w.sendDuelMessage(fight, "Pojedynek nierozstrzygnięty", fmt.Sprintf("%s i %s odzyskują stawki", challenger.GetName(), opponent.GetName()))
+
+		return
+	}
+
+	winner.AddGold(challenge.Stake * 2)
+
+	change := duel.RatingChange(winner.Meta.PvP.Rating, loser.Meta.PvP.Rating)
+	day := w.Time.DayNumber()
+
+	winner.Meta.PvP.AddResult(duel.Result{Opponent: loser.GetName(), Won: true, Stake: challenge.Stake, RatingChange: change, Day: day})
+	loser.Meta.PvP.AddResult(duel.Result{Opponent: winner.GetName(), Won: false, Stake: challenge.Stake, RatingChange: -change, Day: day})
+
+	description := fmt.Sprintf(
+		"Zwycięzca: %s (<@%s>)\nRanking: %s %d (+%d), %s %d (-%d)",
+		winner.GetName(), winner.Meta.UserID,
+		winner.GetName(), winner.Meta.PvP.Rating, change,
+		loser.GetName(), loser.Meta.PvP.Rating, change,
+	)
+
+	if challenge.Stake > 0 {
+		description += fmt.Sprintf("\nWygrana: %d golda", challenge.Stake*2)
+	}
+
+	w.sendDuelMessage(fight, "Pojedynek zakończony", description)
+}
+
+func (w *World) sendDuelMessage(fight *battle.Fight, title, description string) {
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: fight.GetChannelId(),
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.
+					NewEmbedBuilder().
+					SetTitle(title).
+					SetDescription(description).
+					Build(),
+			).
+			Build(),
+	}
+}
@@ -0,0 +1,156 @@
+package dungeon
+
+import (
+	"sao/types"
+	"sao/utils"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+type RoomType int
+
+const (
+	ROOM_COMBAT RoomType = iota
+	ROOM_TREASURE
+	ROOM_REST
+	ROOM_BOSS
+)
+
+// Chance in percent for rooms between entrance and boss, the rest is combat
+const (
+	TreasureChance = 20
+	RestChance     = 15
+)
+
+// Leaderboard entries kept per dungeon
+const MaxRecords = 10
+
+type PoolEnemy struct {
+	Enemy  string
+	MinNum int
+	MaxNum int
+}
+
+type BossEnemy struct {
+	Enemy string
+	Count int
+	//Scales base HP, 100 keeps it unchanged
+	HPPercent int
+}
+
+type Definition struct {
+	Id          string
+	Name        string
+	Description string
+	//Entrance, parties have to stand here
+	Location types.EntityLocation
+	//Rooms before the boss
+	MinRooms int
+	MaxRooms int
+	//Empty pool takes enemies from all locations on entrance floor
+	Pool []PoolEnemy
+	Boss []BossEnemy
+	//Given to every player in treasure room
+	Treasure []types.Loot
+	//Percent of max HP restored in rest room
+	RestPercent int
+	//Calendar hours before player can enter again
+	Lockout int
+	//Given to every player after the boss
+	Rewards []types.Loot
+}
+
+type Room struct {
+	Type RoomType
+	//Only for combat rooms
+	Enemy PoolEnemy
+}
+
+// First room is always a fight and rest rooms never follow each other
+func Generate(definition Definition, pool []PoolEnemy) []Room {
+	count := utils.RandomNumber(definition.MinRooms, definition.MaxRooms)
+
+	rooms := make([]Room, 0)
+
+	for i := 0; i < count; i++ {
+		roll := utils.RandomNumber(0, 99)
+
+		switch {
+		case i > 0 && roll < TreasureChance:
+			rooms = append(rooms, Room{Type: ROOM_TREASURE})
+		case i > 0 && roll < TreasureChance+RestChance && rooms[i-1].Type != ROOM_REST:
+			rooms = append(rooms, Room{Type: ROOM_REST})
+		default:
+			rooms = append(rooms, Room{Type: ROOM_COMBAT, Enemy: utils.RandomElement(pool)})
+		}
+	}
+
+	return append(rooms, Room{Type: ROOM_BOSS})
+}
+
+type Run struct {
+	Uuid     uuid.UUID
+	Dungeon  string
+	Players  []uuid.UUID
+	ThreadId string
+	Rooms    []Room
+	//Index of room players are in
+	Current int
+	//Summed over every fight
+	Turns int
+	//Nil between rooms
+	Fight *uuid.UUID
+}
+
+func (r *Run) Room() Room {
+	return r.Rooms[r.Current]
+}
+
+func (r *Run) IsLastRoom() bool {
+	return r.Current == len(r.Rooms)-1
+}
+
+type Record struct {
+	Players []string
+	Turns   int
+	//Calendar day number
+	Day int
+}
+
+// Fewest turns first, ties keep the older run
+func AddRecord(records []Record, record Record) []Record {
+	records = append(records, record)
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Turns < records[j].Turns
+	})
+
+	if len(records) > MaxRecords {
+		records = records[:MaxRecords]
+	}
+
+	return records
+}
+
+func (r Record) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"players": r.Players,
+		"turns":   r.Turns,
+		"day":     r.Day,
+	}
+}
+
+func DeserializeRecord(data map[string]interface{}) Record {
+	record := Record{
+		Players: make([]string, 0),
+		Turns:   int(data["turns"].(float64)),
+		Day:     int(data["day"].(float64)),
+	}
+
+	for _, name := range data["players"].([]interface{}) {
+		record.Players = append(record.Players, name.(string))
+	}
+
+	return record
+}
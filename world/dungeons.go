@@ -0,0 +1,466 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/battle"
+	"sao/battle/mobs"
+	"sao/data"
+	"sao/player"
+	"sao/types"
+	"sao/utils"
+	"sao/world/dungeon"
+	"sao/world/event"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Caller has to hold StateLock
+func (w *World) playerRun(pUuid uuid.UUID) *dungeon.Run {
+	for _, run := range w.Dungeons {
+		for _, member := range run.Players {
+			if member == pUuid {
+				return run
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w *World) PlayerDungeon(pUuid uuid.UUID) *dungeon.Run {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	return w.playerRun(pUuid)
+}
+
+// Definition pool or every enemy found on entrance floor
+func (w *World) dungeonPool(definition dungeon.Definition) []dungeon.PoolEnemy {
+	if len(definition.Pool) > 0 {
+		return definition.Pool
+	}
+
+	pool := make([]dungeon.PoolEnemy, 0)
+
+	for _, loc := range w.Floors[definition.Location.Floor].Locations {
+		for _, enemy := range loc.Enemies {
+			pool = append(pool, dungeon.PoolEnemy{Enemy: enemy.Enemy, MinNum: enemy.MinNum, MaxNum: enemy.MaxNum})
+		}
+	}
+
+	return pool
+}
+
+// Caller has to hold StateLock
+func (w *World) checkDungeonParty(members []*player.Player, dungeonId string, now int) error {
+	definition := data.Dungeons[dungeonId]
+
+	for _, member := range members {
+		if member.Meta.FightInstance != nil {
+			return errors.New("IN_FIGHT")
+		}
+
+		if member.IsTraveling() || member.Meta.Location != definition.Location || member.GetCurrentHP() <= 0 {
+			return errors.New("PARTY_NOT_READY")
+		}
+
+		if w.playerRun(member.GetUUID()) != nil {
+			return errors.New("IN_DUNGEON")
+		}
+
+		if _, locked := member.LockoutEnd(dungeonId, now); locked {
+			return errors.New("LOCKED_OUT")
+		}
+	}
+
+	return nil
+}
+
+// Whole party enters with the leader, thread is created only when everyone can go
+func (w *World) EnterDungeon(pUuid uuid.UUID, dungeonId string, createThread func(name string) (string, error)) (*dungeon.Run, error) {
+	definition, exists := data.Dungeons[dungeonId]
+
+	if !exists {
+		return nil, errors.New("DUNGEON_NOT_FOUND")
+	}
+
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.Location != definition.Location {
+		return nil, errors.New("NOT_HERE")
+	}
+
+	members := []*player.Player{playerObj}
+
+	if playerObj.Meta.Party != nil {
+		partyData := w.Parties[playerObj.Meta.Party.UUID]
+
+		if partyData.Leader != pUuid {
+			return nil, errors.New("NOT_LEADER")
+		}
+
+		members = make([]*player.Player, 0)
+
+		for _, member := range partyData.Players {
+			members = append(members, w.Players[member.PlayerUuid])
+		}
+	}
+
+	now := event.AbsoluteHour(w.Time)
+	pool := w.dungeonPool(definition)
+
+	if len(pool) == 0 {
+		return nil, errors.New("DUNGEON_NOT_FOUND")
+	}
+
+	w.StateLock.Lock()
+
+	err := w.checkDungeonParty(members, dungeonId, now)
+
+	w.StateLock.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	//Thread is created without the lock, so the party is checked again afterwards
+	threadId, err := createThread(fmt.Sprintf("%s - %s", definition.Name, playerObj.GetName()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	if err := w.checkDungeonParty(members, dungeonId, now); err != nil {
+		return nil, err
+	}
+
+	run := &dungeon.Run{
+		Uuid:     uuid.New(),
+		Dungeon:  dungeonId,
+		Players:  make([]uuid.UUID, 0),
+		ThreadId: threadId,
+		Rooms:    dungeon.Generate(definition, pool),
+		Current:  0,
+		Turns:    0,
+		Fight:    nil,
+	}
+
+	mentions := make([]string, 0)
+
+	for _, member := range members {
+		member.LockOut(dungeonId, now+definition.Lockout)
+
+		run.Players = append(run.Players, member.GetUUID())
+		mentions = append(mentions, fmt.Sprintf("<@%s>", member.Meta.UserID))
+	}
+
+	w.Dungeons[run.Uuid] = run
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: threadId,
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			SetContent(strings.Join(mentions, " ")).
+			AddEmbeds(
+				discord.
+					NewEmbedBuilder().
+					SetTitle(definition.Name).
+					SetDescription(definition.Description).
+					AddField("Komnaty", fmt.Sprintf("%d, na końcu czeka boss", len(run.Rooms)), false).
+					Build(),
+			).
+			Build(),
+	}
+
+	w.enterRoom(run)
+
+	return run, nil
+}
+
+// Players still able to fight, the rest sits the run out
+func (w *World) runParticipants(run *dungeon.Run) []*player.Player {
+	participants := make([]*player.Player, 0)
+
+	for _, member := range run.Players {
+		playerObj, exists := w.Players[member]
+
+		if !exists || playerObj.GetCurrentHP() <= 0 {
+			continue
+		}
+
+		participants = append(participants, playerObj)
+	}
+
+	return participants
+}
+
+func (w *World) sendRunMessage(run *dungeon.Run, title, description string) {
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: run.ThreadId,
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.
+					NewEmbedBuilder().
+					SetTitle(title).
+					SetDescription(description).
+					Build(),
+			).
+			Build(),
+	}
+}
+
+// Caller has to hold StateLock
+func (w *World) enterRoom(run *dungeon.Run) {
+	definition := data.Dungeons[run.Dungeon]
+	participants := w.runParticipants(run)
+
+	if len(participants) == 0 {
+		w.finishDungeon(run, false)
+		return
+	}
+
+	roomTitle := fmt.Sprintf("Komnata %d/%d", run.Current+1, len(run.Rooms))
+
+	switch run.Room().Type {
+	case dungeon.ROOM_TREASURE:
+		lootNames := make([]string, 0)
+
+		for _, loot := range definition.Treasure {
+			for _, participant := range participants {
+				w.giveLoot(participant, loot)
+			}
+
+			lootNames = append(lootNames, w.dungeonLootName(loot))
+		}
+
+		w.sendRunMessage(run, roomTitle+" - Skarbiec", "Każdy z was znajduje: "+strings.Join(lootNames, ", ")+"\n\n/loch dalej - idźcie dalej")
+	case dungeon.ROOM_REST:
+		hpText := ""
+
+		for _, participant := range participants {
+			participant.Heal(utils.PercentOf(participant.GetStat(types.STAT_HP), definition.RestPercent))
+
+			hpText += fmt.Sprintf("%s - %d/%d HP\n", participant.GetName(), participant.GetCurrentHP(), participant.GetStat(types.STAT_HP))
+		}
+
+		w.sendRunMessage(run, roomTitle+" - Odpoczynek", hpText+"\n/loch dalej - idźcie dalej")
+	case dungeon.ROOM_COMBAT, dungeon.ROOM_BOSS:
+		entityMap := make(battle.EntityMap)
+
+		for _, participant := range participants {
+			entityMap[participant.GetUUID()] = battle.EntityEntry{Entity: participant, Side: 0}
+		}
+
+		if run.Room().Type == dungeon.ROOM_BOSS {
+			for _, boss := range definition.Boss {
+				w.spawnDungeonEnemies(entityMap, boss.Enemy, boss.Count, boss.HPPercent)
+			}
+
+			w.sendRunMessage(run, roomTitle+" - Boss", "Ostatnia komnata, nie ma odwrotu!")
+		} else {
+			room := run.Room()
+
+			w.spawnDungeonEnemies(entityMap, room.Enemy.Enemy, utils.RandomNumber(room.Enemy.MinNum, room.Enemy.MaxNum), 100)
+
+			w.sendRunMessage(run, roomTitle, "Droga jest zablokowana przez przeciwników!")
+		}
+
+		floor := w.Floors[definition.Location.Floor]
+		entrance := floor.FindLocation(definition.Location.Location)
+
+		applyLocationEffects(entityMap, floor.ActiveEffects(*entrance, w.Time))
+
+		fight := battle.Fight{
+			Entities:       entityMap,
+			DiscordChannel: w.BufferChannel,
+			Location:       entrance,
+			Meta: &battle.FightMeta{
+				ThreadId: run.ThreadId,
+				Dungeon:  &battle.DungeonData{Run: run.Uuid},
+			},
+		}
+
+		fight.Init()
+
+		fightUuid := w.RegisterFight(&fight)
+
+		for _, participant := range participants {
+			participant.Meta.FightInstance = &fightUuid
+		}
+
+		run.Fight = &fightUuid
+
+		go w.ListenForFight(fightUuid)
+	}
+}
+
+func (w *World) spawnDungeonEnemies(entityMap battle.EntityMap, enemyId string, count int, hpPercent int) {
+	for i := 0; i < count; i++ {
+		mob := mobs.Spawn(enemyId)
+
+		if mob == nil {
+			continue
+		}
+
+		if hpPercent != 100 {
+			mob.Stats[types.STAT_HP] = utils.PercentOf(mob.Stats[types.STAT_HP], hpPercent)
+			mob.HP = mob.GetStat(types.STAT_HP)
+		}
+
+		entityMap[mob.GetUUID()] = battle.EntityEntry{Entity: mob, Side: 1}
+	}
+}
+
+// LootName doesn't know about exp and gold
+func (w *World) dungeonLootName(loot types.Loot) string {
+	switch loot.Type {
+	case types.LOOT_EXP:
+		return fmt.Sprintf("%d XP", loot.Count)
+	case types.LOOT_GOLD:
+		return fmt.Sprintf("%d golda", loot.Count)
+	}
+
+	return w.LootName(loot)
+}
+
+func (w *World) NextDungeonRoom(pUuid uuid.UUID) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	run := w.playerRun(pUuid)
+
+	if run == nil {
+		return errors.New("NOT_IN_DUNGEON")
+	}
+
+	if run.Fight != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	run.Current++
+
+	w.enterRoom(run)
+
+	return nil
+}
+
+// Player leaves alone, run ends when nobody is left
+func (w *World) AbandonDungeon(pUuid uuid.UUID) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	run := w.playerRun(pUuid)
+
+	if run == nil {
+		return errors.New("NOT_IN_DUNGEON")
+	}
+
+	if run.Fight != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	remaining := make([]uuid.UUID, 0)
+
+	for _, member := range run.Players {
+		if member != pUuid {
+			remaining = append(remaining, member)
+		}
+	}
+
+	run.Players = remaining
+
+	w.sendRunMessage(run, "Odwrót", fmt.Sprintf("%s opuszcza loch", w.Players[pUuid].GetName()))
+
+	if len(w.runParticipants(run)) == 0 {
+		w.finishDungeon(run, false)
+	}
+
+	return nil
+}
+
+// Called by fight listener once the fight is deregistered
+func (w *World) FinishDungeonFight(runUuid uuid.UUID, fight *battle.Fight, won bool) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	run, exists := w.Dungeons[runUuid]
+
+	if !exists {
+		return
+	}
+
+	run.Fight = nil
+
+	turns := 0
+
+	for _, count := range fight.TurnCounter {
+		turns = max(turns, count)
+	}
+
+	run.Turns += turns
+
+	if !won || len(w.runParticipants(run)) == 0 {
+		w.finishDungeon(run, false)
+		return
+	}
+
+	if run.IsLastRoom() {
+		w.finishDungeon(run, true)
+		return
+	}
+
+	w.sendRunMessage(run, "Komnata oczyszczona", fmt.Sprintf("Tury do tej pory: %d\n\n/loch dalej - idźcie dalej\n/loch opuść - wycofaj się", run.Turns))
+}
+
+// Caller has to hold StateLock
+func (w *World) finishDungeon(run *dungeon.Run, success bool) {
+	delete(w.Dungeons, run.Uuid)
+
+	definition := data.Dungeons[run.Dungeon]
+
+	if !success {
+		w.sendRunMessage(run, "Wyprawa nieudana", fmt.Sprintf("%s pokonał drużynę w komnacie %d/%d", definition.Name, run.Current+1, len(run.Rooms)))
+		return
+	}
+
+	names := make([]string, 0)
+
+	for _, member := range run.Players {
+		playerObj, exists := w.Players[member]
+
+		if !exists {
+			continue
+		}
+
+		for _, loot := range definition.Rewards {
+			w.giveLoot(playerObj, loot)
+		}
+
+		names = append(names, playerObj.GetName())
+	}
+
+	lootNames := make([]string, 0)
+
+	for _, loot := range definition.Rewards {
+		lootNames = append(lootNames, w.dungeonLootName(loot))
+	}
+
+	w.DungeonRecords[run.Dungeon] = dungeon.AddRecord(w.DungeonRecords[run.Dungeon], dungeon.Record{
+		Players: names,
+		Turns:   run.Turns,
+		Day:     w.Time.DayNumber(),
+	})
+
+	w.sendRunMessage(
+		run,
+		"Loch ukończony: "+definition.Name,
+		fmt.Sprintf("Tury: %d\nNagroda dla każdego: %s", run.Turns, strings.Join(lootNames, ", ")),
+	)
+}
@@ -0,0 +1,126 @@
+package event
+
+import (
+	"sao/battle"
+	"sao/types"
+	"sao/world/calendar"
+	"sort"
+
+	"github.com/google/uuid"
+)
+
+type EventType int
+
+const (
+	//Single strong enemy
+	EVENT_BOSS EventType = iota
+	//Waves of enemies fought one after another
+	EVENT_INVASION
+)
+
+var StringToEventType = map[string]EventType{
+	"BOSS":     EVENT_BOSS,
+	"INVASION": EVENT_INVASION,
+}
+
+type WaveEnemy struct {
+	Enemy string
+	Count int
+	//Scales base HP, 100 keeps it unchanged
+	HPPercent int
+}
+
+// Fires on every EveryDays-th calendar day at Hour
+type Trigger struct {
+	EveryDays int
+	Hour      int
+}
+
+func (t Trigger) Matches(c *calendar.Calendar) bool {
+	return t.EveryDays > 0 && (c.DayNumber()+1)%t.EveryDays == 0 && c.Time.Hour == t.Hour
+}
+
+// Contributors ranked up to MaxRank get Loot, MaxRank 0 rewards everyone else
+type RankReward struct {
+	MaxRank int
+	Loot    []types.Loot
+}
+
+type Definition struct {
+	Id          string
+	Name        string
+	Description string
+	Type        EventType
+	Location    types.EntityLocation
+	Waves       [][]WaveEnemy
+	//Nil when event is started only by admins
+	Trigger *Trigger
+	//In calendar hours, joining is closed afterwards
+	Duration int
+	//Sorted by MaxRank, participation reward last
+	Rewards []RankReward
+}
+
+// Rank starts from 1
+func (d Definition) RewardFor(rank int) []types.Loot {
+	for _, reward := range d.Rewards {
+		if reward.MaxRank == 0 || rank <= reward.MaxRank {
+			return reward.Loot
+		}
+	}
+
+	return []types.Loot{}
+}
+
+type Event struct {
+	Uuid       uuid.UUID
+	Definition string
+	Fight      uuid.UUID
+	//Absolute calendar hour
+	Expires int
+	Open    bool
+}
+
+type Scheduled struct {
+	Event string
+	//Absolute calendar hour
+	At int
+}
+
+type Contributor struct {
+	Player       uuid.UUID
+	Contribution battle.Contribution
+}
+
+func AbsoluteHour(c *calendar.Calendar) int {
+	return c.DayNumber()*calendar.HoursInDay + c.Time.Hour
+}
+
+// Highest damage and healing combined first
+func Rank(contribution map[uuid.UUID]*battle.Contribution) []Contributor {
+	ranking := make([]Contributor, 0)
+
+	for playerUuid, value := range contribution {
+		ranking = append(ranking, Contributor{Player: playerUuid, Contribution: *value})
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].Contribution.Total() > ranking[j].Contribution.Total()
+	})
+
+	return ranking
+}
+
+func (s Scheduled) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"event": s.Event,
+		"at":    s.At,
+	}
+}
+
+func DeserializeScheduled(data map[string]interface{}) Scheduled {
+	return Scheduled{
+		Event: data["event"].(string),
+		At:    int(data["at"].(float64)),
+	}
+}
@@ -0,0 +1,435 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/battle"
+	"sao/battle/mobs"
+	"sao/data"
+	"sao/player"
+	"sao/types"
+	"sao/utils"
+	"sao/world/bounty"
+	"sao/world/event"
+	"sao/world/quest"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+func (w *World) spawnEventWave(wave []event.WaveEnemy) battle.EntityMap {
+	entityMap := make(battle.EntityMap)
+
+	for _, enemy := range wave {
+		for i := 0; i < enemy.Count; i++ {
+			mob := mobs.Spawn(enemy.Enemy)
+
+			if mob == nil {
+				continue
+			}
+
+			if enemy.HPPercent != 100 {
+				mob.Stats[types.STAT_HP] = utils.PercentOf(mob.Stats[types.STAT_HP], enemy.HPPercent)
+				mob.HP = mob.GetStat(types.STAT_HP)
+			}
+
+			entityMap[mob.GetUUID()] = battle.EntityEntry{Entity: mob, Side: 1}
+		}
+	}
+
+	return entityMap
+}
+
+func (w *World) StartEvent(eventId string) (*event.Event, error) {
+	definition, exists := data.Events[eventId]
+
+	if !exists {
+		return nil, errors.New("EVENT_NOT_FOUND")
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	for _, active := range w.Events {
+		if active.Definition == eventId {
+			return nil, errors.New("EVENT_ALREADY_ACTIVE")
+		}
+	}
+
+	floor := w.Floors[definition.Location.Floor]
+	eventLocation := floor.FindLocation(definition.Location.Location)
+
+	if eventLocation == nil {
+		return nil, errors.New("LOCATION_NOT_FOUND")
+	}
+
+	effects := floor.ActiveEffects(*eventLocation, w.Time)
+
+	waves := make([]battle.EntityMap, len(definition.Waves))
+
+	for idx, wave := range definition.Waves {
+		waves[idx] = w.spawnEventWave(wave)
+
+		applyLocationEffects(waves[idx], effects)
+	}
+
+	reinforcements := make([][]battle.EntityEntry, 0)
+
+	for _, wave := range waves[1:] {
+		entries := make([]battle.EntityEntry, 0)
+
+		for _, entry := range wave {
+			entries = append(entries, entry)
+		}
+
+		reinforcements = append(reinforcements, entries)
+	}
+
+	eventObj := &event.Event{
+		Uuid:       uuid.New(),
+		Definition: eventId,
+		Expires:    event.AbsoluteHour(w.Time) + definition.Duration,
+		Open:       true,
+	}
+
+	fight := battle.Fight{
+		Entities:       waves[0],
+		DiscordChannel: w.BufferChannel,
+		Location:       eventLocation,
+		Meta: &battle.FightMeta{
+			Tournament: nil,
+			Event:      &battle.EventData{Event: eventObj.Uuid},
+		},
+	}
+
+	fight.Init()
+
+	fight.Joins = make(chan battle.EntityEntry, 32)
+	fight.Reinforcements = reinforcements
+
+	eventObj.Fight = w.RegisterFight(&fight)
+	w.Events[eventObj.Uuid] = eventObj
+
+	enemyText := ""
+
+	for _, entry := range fight.Entities {
+		enemyText += fmt.Sprintf("%s (%d HP)\n", entry.Entity.GetName(), entry.Entity.GetCurrentHP())
+	}
+
+	if len(reinforcements) > 0 {
+		enemyText += fmt.Sprintf("...i %d kolejnych fal", len(reinforcements))
+	}
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: eventLocation.CID,
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.
+					NewEmbedBuilder().
+					SetTitle("Wydarzenie: "+definition.Name).
+					SetDescription(definition.Description).
+					AddField("Przeciwnicy", strings.TrimSuffix(enemyText, "\n"), false).
+					AddField("Dołączanie", fmt.Sprintf("Przez %d godz. w lokacji %s - /wydarzenie dołącz", definition.Duration, eventLocation.Name), false).
+					Build(),
+			).
+			Build(),
+	}
+
+	go w.ListenForFight(eventObj.Fight)
+
+	return eventObj, nil
+}
+
+// Starts right away when inHours is not positive
+func (w *World) ScheduleEvent(eventId string, inHours int) error {
+	if _, exists := data.Events[eventId]; !exists {
+		return errors.New("EVENT_NOT_FOUND")
+	}
+
+	if inHours <= 0 {
+		_, err := w.StartEvent(eventId)
+
+		return err
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	w.ScheduledEvents = append(w.ScheduledEvents, event.Scheduled{Event: eventId, At: event.AbsoluteHour(w.Time) + inHours})
+
+	return nil
+}
+
+func (w *World) RunScheduledEvents() {
+	for eventId, definition := range data.Events {
+		if definition.Trigger != nil && definition.Trigger.Matches(w.Time) {
+			w.StartEvent(eventId)
+		}
+	}
+
+	now := event.AbsoluteHour(w.Time)
+	due := make([]string, 0)
+
+	w.StateLock.Lock()
+
+	remaining := make([]event.Scheduled, 0)
+
+	for _, scheduled := range w.ScheduledEvents {
+		if scheduled.At <= now {
+			due = append(due, scheduled.Event)
+		} else {
+			remaining = append(remaining, scheduled)
+		}
+	}
+
+	w.ScheduledEvents = remaining
+
+	w.StateLock.Unlock()
+
+	for _, eventId := range due {
+		w.StartEvent(eventId)
+	}
+}
+
+// Closes joining, players already fighting can still finish the event
+func (w *World) ExpireEvents() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	now := event.AbsoluteHour(w.Time)
+
+	for _, eventObj := range w.Events {
+		if !eventObj.Open || eventObj.Expires > now {
+			continue
+		}
+
+		eventObj.Open = false
+
+		fight := w.Fights[eventObj.Fight]
+
+		close(fight.Joins)
+
+		w.BufferChannel <- types.DiscordMessageStruct{
+			ChannelID: fight.GetChannelId(),
+			MessageContent: discord.
+				NewMessageCreateBuilder().
+				SetContentf("Czas na dołączenie do wydarzenia %s minął!", data.Events[eventObj.Definition].Name).
+				Build(),
+		}
+	}
+}
+
+func (w *World) ActiveEvents() []*event.Event {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	events := make([]*event.Event, 0)
+
+	for _, eventObj := range w.Events {
+		events = append(events, eventObj)
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Expires < events[j].Expires
+	})
+
+	return events
+}
+
+// Party members standing with the player join too
+func (w *World) JoinEvent(pUuid uuid.UUID, eventUuid uuid.UUID) error {
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.FightInstance != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	if playerObj.GetCurrentHP() <= 0 {
+		return errors.New("PLAYER_DEAD")
+	}
+
+	if playerObj.IsTraveling() {
+		return errors.New("TRAVELING")
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	eventObj, exists := w.Events[eventUuid]
+
+	if !exists {
+		return errors.New("EVENT_NOT_FOUND")
+	}
+
+	if !eventObj.Open {
+		return errors.New("EVENT_CLOSED")
+	}
+
+	definition := data.Events[eventObj.Definition]
+
+	if playerObj.Meta.Location != definition.Location {
+		return errors.New("NOT_HERE")
+	}
+
+	joiners := []*player.Player{playerObj}
+
+	if playerObj.Meta.Party != nil {
+		for _, member := range w.Parties[playerObj.Meta.Party.UUID].Players {
+			memberObj := w.Players[member.PlayerUuid]
+
+			if member.PlayerUuid == pUuid || memberObj.Meta.Location != playerObj.Meta.Location || memberObj.Meta.FightInstance != nil || memberObj.IsTraveling() || memberObj.GetCurrentHP() <= 0 {
+				continue
+			}
+
+			joiners = append(joiners, memberObj)
+		}
+	}
+
+	fight := w.Fights[eventObj.Fight]
+	floor := w.Floors[definition.Location.Floor]
+
+	entityMap := make(battle.EntityMap)
+
+	for _, joiner := range joiners {
+		entityMap[joiner.GetUUID()] = battle.EntityEntry{Entity: joiner, Side: 0}
+	}
+
+	applyLocationEffects(entityMap, floor.ActiveEffects(*fight.Location, w.Time))
+
+	mentions := make([]string, 0)
+
+	for _, entry := range entityMap {
+		joiner := entry.Entity.(*player.Player)
+
+		joiner.Meta.FightInstance = &eventObj.Fight
+
+		fight.Joins <- entry
+
+		mentions = append(mentions, fmt.Sprintf("<@%s>", joiner.Meta.UserID))
+	}
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: fight.GetChannelId(),
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			SetContentf("%s dołącza do wydarzenia %s!", strings.Join(mentions, ", "), definition.Name).
+			Build(),
+	}
+
+	return nil
+}
+
+// Rewards are granted by contribution rank, only when every wave was defeated
+func (w *World) FinishEvent(eventUuid uuid.UUID, fight *battle.Fight) {
+	w.StateLock.Lock()
+
+	eventObj, exists := w.Events[eventUuid]
+
+	if exists {
+		if eventObj.Open {
+			eventObj.Open = false
+
+			close(fight.Joins)
+		}
+
+		delete(w.Events, eventUuid)
+	}
+
+	w.StateLock.Unlock()
+
+	if !exists {
+		return
+	}
+
+	//Players who joined after the last round never got into the fight
+	for entry := range fight.Joins {
+		entry.Entity.(types.PlayerEntity).ClearFight()
+	}
+
+	definition := data.Events[eventObj.Definition]
+	success := !slices.Contains(fight.SidesLeft(), 1)
+	ranking := event.Rank(fight.Contribution)
+
+	rankingText := ""
+
+	for idx, contributor := range ranking {
+		playerObj, exists := w.Players[contributor.Player]
+
+		if !exists {
+			continue
+		}
+
+		line := fmt.Sprintf("%d. %s - %d obrażeń, %d leczenia", idx+1, playerObj.GetName(), contributor.Contribution.Damage, contributor.Contribution.Healing)
+
+		if success {
+			lootNames := make([]string, 0)
+
+			for _, loot := range definition.RewardFor(idx + 1) {
+				w.GiveLoot(playerObj, loot)
+
+				switch loot.Type {
+				case types.LOOT_EXP:
+					lootNames = append(lootNames, fmt.Sprintf("%d XP", loot.Count))
+				case types.LOOT_GOLD:
+					lootNames = append(lootNames, fmt.Sprintf("%d golda", loot.Count))
+				default:
+					lootNames = append(lootNames, w.LootName(loot))
+				}
+			}
+
+			if len(lootNames) > 0 {
+				line += " - " + strings.Join(lootNames, ", ")
+			}
+
+			for _, entry := range fight.Entities {
+				mob, ok := entry.Entity.(*mobs.MobEntity)
+
+				if !ok || entry.Side != 1 {
+					continue
+				}
+
+				if playerObj.Meta.Kills == nil {
+					playerObj.Meta.Kills = make(map[string]int)
+				}
+
+				playerObj.Meta.Kills[mob.Id]++
+
+				w.UpdateQuests(playerObj.GetUUID(), quest.OBJECTIVE_KILL, mob.Id, 1)
+				w.ProgressBounties(playerObj.GetUUID(), bounty.BOUNTY_KILL, mob.Id, 1)
+			}
+		}
+
+		//Everyone is rewarded, but only the top fits in the embed
+		if idx < 10 {
+			rankingText += line + "\n"
+		}
+	}
+
+	if rankingText == "" {
+		rankingText = "Nikt nie wziął udziału"
+	}
+
+	title := "Wydarzenie zakończone: " + definition.Name
+
+	if !success {
+		title = "Wydarzenie nieudane: " + definition.Name
+	}
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: fight.GetChannelId(),
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.
+					NewEmbedBuilder().
+					SetTitle(title).
+					AddField("Ranking", rankingText, false).
+					Build(),
+			).
+			Build(),
+	}
+}
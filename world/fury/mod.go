@@ -6,13 +6,18 @@ import (
 	"github.com/google/uuid"
 )
 
+const MaxLevel = 10
+
 type Fury struct {
+	UUID        uuid.UUID
 	Name        string
+	Description string
 	Master      *uuid.UUID
 	Tiers       []FuryTier
 	CurrentTier int
 	XP          FuryXP
-	LvlStats    func(lvl int, tier int) map[types.Stat]int
+	//Stats gained with every level, multiplied by current tier
+	LvlStats map[types.Stat]int
 }
 
 type FuryTier struct {
@@ -26,37 +31,47 @@ type FuryXP struct {
 	LVL int
 }
 
+// Copy of definition from game data, first tier is unlocked right away
+func New(definition Fury, master uuid.UUID) *Fury {
+	fury := definition
+
+	fury.Master = &master
+	fury.CurrentTier = 1
+	fury.XP = FuryXP{XP: 0, LVL: 1}
+
+	return &fury
+}
+
 func (f *Fury) NextLvlXPGauge() int {
 	return f.CurrentTier*1000 + f.XP.LVL*100
 }
 
 func (f *Fury) AddXP(xp int) {
-	if f.XP.LVL == 10 {
+	if f.XP.LVL == MaxLevel {
 		f.XP.XP = 0
 	} else {
 		f.XP.XP += xp
 	}
 
-	for f.XP.XP >= f.NextLvlXPGauge() && f.XP.LVL < 10 {
+	for f.XP.XP >= f.NextLvlXPGauge() && f.XP.LVL < MaxLevel {
 		f.XP.LVL++
 		f.XP.XP -= f.NextLvlXPGauge()
 	}
 
-	if f.XP.LVL == 10 {
+	if f.XP.LVL == MaxLevel {
 		f.XP.XP = 0
 	}
 }
 
 func (f *Fury) GetStats() map[types.Stat]int {
-	baseStats := f.LvlStats(f.XP.LVL, f.CurrentTier)
+	baseStats := make(map[types.Stat]int)
+
+	for k, v := range f.LvlStats {
+		baseStats[k] = v * f.XP.LVL * f.CurrentTier
+	}
 
 	for i := 0; i < f.CurrentTier; i++ {
 		for k, v := range f.Tiers[i].Stats {
-
-			if _, ok := baseStats[k]; !ok {
-				baseStats[k] = 0
-			}
-
 			baseStats[k] += v
 		}
 	}
@@ -82,22 +97,56 @@ func (f *Fury) GetSkills() []types.PlayerSkill {
 	return skills
 }
 
+func (f *Fury) GetSkill(skillUuid uuid.UUID) types.PlayerSkill {
+	for _, skill := range f.GetSkills() {
+		if skill.GetUUID() == skillUuid {
+			return skill
+		}
+	}
+
+	return nil
+}
+
+// Only progress is saved, rest comes from fury definition
 func (f *Fury) Serialize() map[string]interface{} {
+	master := ""
+
+	if f.Master != nil {
+		master = f.Master.String()
+	}
+
 	return map[string]interface{}{
-		"name":        f.Name,
-		"master":      f.Master,
-		"tiers":       f.Tiers,
+		"uuid":        f.UUID.String(),
+		"master":      master,
 		"currentTier": f.CurrentTier,
-		"xp":          f.XP,
+		"xp": map[string]interface{}{
+			"xp":  f.XP.XP,
+			"lvl": f.XP.LVL,
+		},
 	}
 }
 
-func Deserialize(data map[string]interface{}) *Fury {
-	return &Fury{
-		Name:        data["name"].(string),
-		Master:      data["master"].(*uuid.UUID),
-		Tiers:       data["tiers"].([]FuryTier),
-		CurrentTier: data["currentTier"].(int),
-		XP:          data["xp"].(FuryXP),
+func Deserialize(data map[string]interface{}, definition Fury) *Fury {
+	fury := definition
+
+	if rawMaster, ok := data["master"].(string); ok && rawMaster != "" {
+		master := uuid.MustParse(rawMaster)
+
+		fury.Master = &master
 	}
+
+	fury.CurrentTier = int(data["currentTier"].(float64))
+
+	if fury.CurrentTier > len(fury.Tiers) {
+		fury.CurrentTier = len(fury.Tiers)
+	}
+
+	rawXP := data["xp"].(map[string]interface{})
+
+	fury.XP = FuryXP{
+		XP:  int(rawXP["xp"].(float64)),
+		LVL: int(rawXP["lvl"].(float64)),
+	}
+
+	return &fury
 }
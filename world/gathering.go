@@ -0,0 +1,120 @@
+package world
+
+import (
+	"errors"
+	"sao/types"
+	"sao/utils"
+	"sao/world/gathering"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Charges left in node, full nodes have no state
+func (w *World) NodeCharges(location types.EntityLocation, node gathering.Node) int {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	if state, exists := w.Nodes[gathering.NodeKey(location, node.Id)]; exists {
+		return state.Charges
+	}
+
+	return node.Charges
+}
+
+func (w *World) Gather(pUuid uuid.UUID, nodeId string) (gathering.GatherResult, error) {
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.FightInstance != nil {
+		return gathering.GatherResult{}, errors.New("IN_FIGHT")
+	}
+
+	if playerObj.IsTraveling() {
+		return gathering.GatherResult{}, errors.New("TRAVELING")
+	}
+
+	if playerObj.Meta.Professions.OnCooldown() {
+		return gathering.GatherResult{}, errors.New("GATHER_COOLDOWN")
+	}
+
+	currentLocation := w.Floors[playerObj.Meta.Location.Floor].FindLocation(playerObj.Meta.Location.Location)
+
+	if currentLocation == nil {
+		return gathering.GatherResult{}, errors.New("NODE_NOT_FOUND")
+	}
+
+	node := currentLocation.FindNode(nodeId)
+
+	if node == nil {
+		return gathering.GatherResult{}, errors.New("NODE_NOT_FOUND")
+	}
+
+	skill := playerObj.Meta.Professions.Get(node.Type)
+
+	if skill.Level < node.Level {
+		return gathering.GatherResult{}, errors.New("LEVEL_TOO_LOW")
+	}
+
+	w.StateLock.Lock()
+
+	key := gathering.NodeKey(playerObj.Meta.Location, node.Id)
+	state, exists := w.Nodes[key]
+
+	if !exists {
+		state = &gathering.NodeState{Charges: node.Charges, RespawnIn: 0}
+		w.Nodes[key] = state
+	}
+
+	if state.Depleted() {
+		w.StateLock.Unlock()
+
+		return gathering.GatherResult{}, errors.New("NODE_DEPLETED")
+	}
+
+	state.Charges--
+
+	depleted := state.Depleted()
+
+	if depleted {
+		state.RespawnIn = node.Respawn
+	}
+
+	w.StateLock.Unlock()
+
+	playerObj.Meta.Professions.NextGather = time.Now().Add(gathering.Cooldown)
+
+	result := gathering.GatherResult{
+		Ingredient: utils.RandomElement(node.Ingredients),
+		Count:      gathering.Yield(skill.Level, *node),
+		Exp:        gathering.ExpFor(*node),
+		Depleted:   depleted,
+	}
+
+	result.LevelUp = skill.AddExp(result.Exp)
+
+	w.GiveLoot(playerObj, types.Loot{
+		Type:  types.LOOT_ITEM,
+		Count: result.Count,
+		Meta:  &types.LootMeta{Type: types.ITEM_MATERIAL, Uuid: result.Ingredient},
+	})
+
+	return result, nil
+}
+
+// Called on every clock tick
+func (w *World) RespawnNodes() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	for key, state := range w.Nodes {
+		if !state.Depleted() {
+			continue
+		}
+
+		state.RespawnIn--
+
+		if state.RespawnIn <= 0 {
+			delete(w.Nodes, key)
+		}
+	}
+}
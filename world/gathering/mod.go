@@ -0,0 +1,81 @@
+package gathering
+
+import (
+	"sao/types"
+	"sao/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Every node type has its own profession
+type Profession int
+
+const (
+	PROFESSION_HERBALISM Profession = iota
+	PROFESSION_MINING
+	PROFESSION_FISHING
+)
+
+var StringToProfession = map[string]Profession{
+	"HERB": PROFESSION_HERBALISM,
+	"ORE":  PROFESSION_MINING,
+	"FISH": PROFESSION_FISHING,
+}
+
+// Time between two gathers of a single player
+const Cooldown = 30 * time.Second
+
+// Profession levels above node level needed for one more ingredient
+const YieldLevelStep = 5
+
+type Node struct {
+	//Unique within location
+	Id          string
+	Type        Profession
+	Ingredients []uuid.UUID
+	Level       int
+	//Gathers before node is depleted
+	Charges int
+	//Clock ticks until depleted node comes back
+	Respawn int
+}
+
+// Depletion is shared by everyone in location, nodes without state are full
+type NodeState struct {
+	Charges   int
+	RespawnIn int
+}
+
+func (s NodeState) Depleted() bool {
+	return s.Charges <= 0
+}
+
+type GatherResult struct {
+	Ingredient uuid.UUID
+	Count      int
+	Exp        int
+	LevelUp    bool
+	Depleted   bool
+}
+
+func NodeKey(location types.EntityLocation, nodeId string) string {
+	return location.Floor + "," + location.Location + "," + nodeId
+}
+
+// Last extra ingredient is a chance, the rest is guaranteed
+func Yield(level int, node Node) int {
+	bonus := (level - node.Level) * 100 / YieldLevelStep
+
+	count := 1 + bonus/100
+
+	if utils.RandomNumber(0, 99) < bonus%100 {
+		count++
+	}
+
+	return count
+}
+
+func ExpFor(node Node) int {
+	return 10 + node.Level*5
+}
@@ -0,0 +1,324 @@
+package guild
+
+import (
+	"sao/player/inventory"
+	"sao/types"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const CreationCost = 5000
+const MaxMembers = 30
+const MaxNameLength = 32
+const InviteDuration = 15 * time.Minute
+
+// Bank log entries kept per guild
+const MaxLogs = 50
+
+// Percent of members' fight XP that goes to the guild
+const ExpSharePercent = 10
+const MaxLevel = 10
+
+type Rank int
+
+const (
+	RANK_MEMBER Rank = iota
+	RANK_OFFICER
+	RANK_LEADER
+)
+
+type Permission int
+
+const (
+	PERM_INVITE Permission = 1 << iota
+	PERM_KICK
+	PERM_WITHDRAW
+	PERM_RANKS
+)
+
+// Leader always has every permission
+func DefaultPermissions() map[Rank]Permission {
+	return map[Rank]Permission{
+		RANK_MEMBER:  0,
+		RANK_OFFICER: PERM_INVITE | PERM_KICK | PERM_WITHDRAW,
+	}
+}
+
+// Passive stat bonus for every member once guild reaches Level
+type Perk struct {
+	Level     int
+	Stat      types.Stat
+	Value     int
+	IsPercent bool
+}
+
+var Perks = []Perk{
+	{Level: 2, Stat: types.STAT_HP, Value: 3, IsPercent: true},
+	{Level: 3, Stat: types.STAT_DEF, Value: 5, IsPercent: false},
+	{Level: 4, Stat: types.STAT_MR, Value: 5, IsPercent: false},
+	{Level: 5, Stat: types.STAT_ADAPTIVE, Value: 3, IsPercent: true},
+	{Level: 7, Stat: types.STAT_HEAL_POWER, Value: 5, IsPercent: true},
+	{Level: 10, Stat: types.STAT_HP, Value: 5, IsPercent: true},
+}
+
+func PerksFor(level int) []Perk {
+	perks := make([]Perk, 0)
+
+	for _, perk := range Perks {
+		if perk.Level <= level {
+			perks = append(perks, perk)
+		}
+	}
+
+	return perks
+}
+
+// Guild XP needed to advance from level
+func ExpForLevel(level int) int {
+	return 1000 * level
+}
+
+type Member struct {
+	Player uuid.UUID
+	Rank   Rank
+	//Calendar day number
+	Joined int
+	//Guild XP earned by member
+	Contributed int
+}
+
+type BankItem struct {
+	//Key in bank, same as Item for materials
+	Uuid     uuid.UUID
+	Item     uuid.UUID
+	ItemType types.ItemType
+	Count    int
+	//Deposited item with its quality, enhancement and durability, nil for materials
+	Instance *types.PlayerItem
+}
+
+type LogEntry struct {
+	Player  string
+	Deposit bool
+	Gold    int
+	//Nil uuid when only gold was moved
+	Item     uuid.UUID
+	ItemType types.ItemType
+	Count    int
+	Day      int
+}
+
+type Invite struct {
+	Uuid    uuid.UUID
+	Guild   uuid.UUID
+	Player  uuid.UUID
+	Expires time.Time
+}
+
+func (i *Invite) Expired() bool {
+	return time.Now().After(i.Expires)
+}
+
+type Guild struct {
+	Uuid        uuid.UUID
+	Name        string
+	Leader      uuid.UUID
+	Members     map[uuid.UUID]*Member
+	Permissions map[Rank]Permission
+	Gold        int
+	Items       map[uuid.UUID]*BankItem
+	//Oldest first
+	Logs  []LogEntry
+	Level int
+	Exp   int
+	//Discord channel and role visible only to members
+	ChannelID string
+	RoleID    string
+	Created   int
+}
+
+func (g *Guild) Can(pUuid uuid.UUID, permission Permission) bool {
+	member, exists := g.Members[pUuid]
+
+	if !exists {
+		return false
+	}
+
+	if member.Rank == RANK_LEADER {
+		return true
+	}
+
+	return g.Permissions[member.Rank]&permission != 0
+}
+
+func (g *Guild) AddLog(entry LogEntry) {
+	g.Logs = append(g.Logs, entry)
+
+	if len(g.Logs) > MaxLogs {
+		g.Logs = g.Logs[len(g.Logs)-MaxLogs:]
+	}
+}
+
+// Returns true when guild leveled up
+func (g *Guild) AddExp(value int) bool {
+	if g.Level >= MaxLevel {
+		return false
+	}
+
+	g.Exp += value
+
+	leveled := false
+
+	for g.Level < MaxLevel && g.Exp >= ExpForLevel(g.Level) {
+		g.Exp -= ExpForLevel(g.Level)
+		g.Level++
+
+		leveled = true
+	}
+
+	if g.Level >= MaxLevel {
+		g.Exp = 0
+	}
+
+	return leveled
+}
+
+func (g *Guild) Serialize() map[string]interface{} {
+	members := make([]map[string]interface{}, 0)
+
+	for _, member := range g.Members {
+		members = append(members, map[string]interface{}{
+			"player":      member.Player.String(),
+			"rank":        member.Rank,
+			"joined":      member.Joined,
+			"contributed": member.Contributed,
+		})
+	}
+
+	permissions := make(map[string]interface{})
+
+	for rank, permission := range g.Permissions {
+		permissions[strconv.Itoa(int(rank))] = permission
+	}
+
+	items := make([]map[string]interface{}, 0)
+
+	for _, item := range g.Items {
+		var instance map[string]interface{}
+
+		if item.Instance != nil {
+			instance = inventory.SerializeItem(item.Instance)
+		}
+
+		items = append(items, map[string]interface{}{
+			"uuid":     item.Uuid.String(),
+			"item":     item.Item.String(),
+			"type":     item.ItemType,
+			"count":    item.Count,
+			"instance": instance,
+		})
+	}
+
+	logs := make([]map[string]interface{}, 0)
+
+	for _, entry := range g.Logs {
+		logs = append(logs, map[string]interface{}{
+			"player":  entry.Player,
+			"deposit": entry.Deposit,
+			"gold":    entry.Gold,
+			"item":    entry.Item.String(),
+			"type":    entry.ItemType,
+			"count":   entry.Count,
+			"day":     entry.Day,
+		})
+	}
+
+	return map[string]interface{}{
+		"uuid":        g.Uuid.String(),
+		"name":        g.Name,
+		"leader":      g.Leader.String(),
+		"members":     members,
+		"permissions": permissions,
+		"gold":        g.Gold,
+		"items":       items,
+		"logs":        logs,
+		"level":       g.Level,
+		"exp":         g.Exp,
+		"channel":     g.ChannelID,
+		"role":        g.RoleID,
+		"created":     g.Created,
+	}
+}
+
+func Deserialize(data map[string]interface{}) *Guild {
+	guild := &Guild{
+		Uuid:        uuid.MustParse(data["uuid"].(string)),
+		Name:        data["name"].(string),
+		Leader:      uuid.MustParse(data["leader"].(string)),
+		Members:     make(map[uuid.UUID]*Member),
+		Permissions: DefaultPermissions(),
+		Gold:        int(data["gold"].(float64)),
+		Items:       make(map[uuid.UUID]*BankItem),
+		Logs:        make([]LogEntry, 0),
+		Level:       int(data["level"].(float64)),
+		Exp:         int(data["exp"].(float64)),
+		ChannelID:   data["channel"].(string),
+		RoleID:      data["role"].(string),
+		Created:     int(data["created"].(float64)),
+	}
+
+	for _, rawMember := range data["members"].([]interface{}) {
+		memberData := rawMember.(map[string]interface{})
+
+		member := &Member{
+			Player:      uuid.MustParse(memberData["player"].(string)),
+			Rank:        Rank(memberData["rank"].(float64)),
+			Joined:      int(memberData["joined"].(float64)),
+			Contributed: int(memberData["contributed"].(float64)),
+		}
+
+		guild.Members[member.Player] = member
+	}
+
+	for rawRank, permission := range data["permissions"].(map[string]interface{}) {
+		rank, _ := strconv.Atoi(rawRank)
+
+		guild.Permissions[Rank(rank)] = Permission(permission.(float64))
+	}
+
+	for _, rawItem := range data["items"].([]interface{}) {
+		itemData := rawItem.(map[string]interface{})
+
+		item := &BankItem{
+			Uuid:     uuid.MustParse(itemData["uuid"].(string)),
+			Item:     uuid.MustParse(itemData["item"].(string)),
+			ItemType: types.ItemType(itemData["type"].(float64)),
+			Count:    int(itemData["count"].(float64)),
+		}
+
+		//Only materials are kept without an instance
+		if rawInstance, ok := itemData["instance"].(map[string]interface{}); ok {
+			item.Instance = inventory.DeserializeItem(rawInstance)
+		}
+
+		guild.Items[item.Uuid] = item
+	}
+
+	for _, rawLog := range data["logs"].([]interface{}) {
+		logData := rawLog.(map[string]interface{})
+
+		guild.Logs = append(guild.Logs, LogEntry{
+			Player:   logData["player"].(string),
+			Deposit:  logData["deposit"].(bool),
+			Gold:     int(logData["gold"].(float64)),
+			Item:     uuid.MustParse(logData["item"].(string)),
+			ItemType: types.ItemType(logData["type"].(float64)),
+			Count:    int(logData["count"].(float64)),
+			Day:      int(logData["day"].(float64)),
+		})
+	}
+
+	return guild
+}
@@ -0,0 +1,677 @@
+package world
+
+import (
+	"errors"
+	"sao/data"
+	"sao/player"
+	"sao/types"
+	"sao/utils"
+	"sao/world/guild"
+	"strings"
+	"time"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+func (w *World) PlayerGuild(pUuid uuid.UUID) *guild.Guild {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	return w.playerGuild(pUuid)
+}
+
+// Caller has to hold StateLock
+func (w *World) playerGuild(pUuid uuid.UUID) *guild.Guild {
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.Guild == nil {
+		return nil
+	}
+
+	return w.Guilds[playerObj.Meta.Guild.UUID]
+}
+
+func (w *World) syncGuildMeta(guildObj *guild.Guild) {
+	for memberUuid := range guildObj.Members {
+		if memberObj, exists := w.Players[memberUuid]; exists {
+			memberObj.Meta.Guild = &player.PartialGuild{UUID: guildObj.Uuid, Level: guildObj.Level}
+		}
+	}
+}
+
+// Returns guild and acting member, fails when player has no guild or lacks permission
+func (w *World) guildMember(pUuid uuid.UUID, permission guild.Permission) (*guild.Guild, *guild.Member, error) {
+	guildObj := w.playerGuild(pUuid)
+
+	if guildObj == nil {
+		return nil, nil, errors.New("NOT_IN_GUILD")
+	}
+
+	if permission != 0 && !guildObj.Can(pUuid, permission) {
+		return nil, nil, errors.New("NO_PERMISSION")
+	}
+
+	return guildObj, guildObj.Members[pUuid], nil
+}
+
+// Caller has to hold StateLock
+func (w *World) checkGuildCreation(playerObj *player.Player, name string) error {
+	if playerObj.Meta.Guild != nil {
+		return errors.New("IN_GUILD")
+	}
+
+	if name == "" || len([]rune(name)) > guild.MaxNameLength {
+		return errors.New("INVALID_NAME")
+	}
+
+	for _, guildObj := range w.Guilds {
+		if strings.EqualFold(guildObj.Name, name) {
+			return errors.New("NAME_TAKEN")
+		}
+	}
+
+	if !w.inCity(playerObj) {
+		return errors.New("NOT_IN_CITY")
+	}
+
+	if playerObj.Inventory.Gold < guild.CreationCost {
+		return errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	return nil
+}
+
+// Discord channel and role are created before gold is taken, caller removes them if creation fails afterwards
+func (w *World) CreateGuild(pUuid uuid.UUID, name string, createChannel func(name string) (string, string, error)) (*guild.Guild, error) {
+	playerObj := w.Players[pUuid]
+	name = strings.TrimSpace(name)
+
+	w.StateLock.Lock()
+
+	err := w.checkGuildCreation(playerObj, name)
+
+	w.StateLock.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+
+	//Channel is created without the lock, so everything is checked again afterwards
+	channelId, roleId, err := createChannel(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	if err := w.checkGuildCreation(playerObj, name); err != nil {
+		return nil, err
+	}
+
+	playerObj.Inventory.Gold -= guild.CreationCost
+
+	day := w.Time.DayNumber()
+
+	guildObj := &guild.Guild{
+		Uuid:   uuid.New(),
+		Name:   name,
+		Leader: pUuid,
+		Members: map[uuid.UUID]*guild.Member{
+			pUuid: {Player: pUuid, Rank: guild.RANK_LEADER, Joined: day, Contributed: 0},
+		},
+		Permissions: guild.DefaultPermissions(),
+		Gold:        0,
+		Items:       make(map[uuid.UUID]*guild.BankItem),
+		Logs:        make([]guild.LogEntry, 0),
+		Level:       1,
+		Exp:         0,
+		ChannelID:   channelId,
+		RoleID:      roleId,
+		Created:     day,
+	}
+
+	w.Guilds[guildObj.Uuid] = guildObj
+
+	w.syncGuildMeta(guildObj)
+
+	return guildObj, nil
+}
+
+func (w *World) InviteToGuild(pUuid, targetUuid uuid.UUID) (*guild.Invite, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj, _, err := w.guildMember(pUuid, guild.PERM_INVITE)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if w.Players[targetUuid].Meta.Guild != nil {
+		return nil, errors.New("TARGET_IN_GUILD")
+	}
+
+	if len(guildObj.Members) >= guild.MaxMembers {
+		return nil, errors.New("GUILD_FULL")
+	}
+
+	for inviteUuid, invite := range w.GuildInvites {
+		if invite.Expired() {
+			delete(w.GuildInvites, inviteUuid)
+			continue
+		}
+
+		if invite.Guild == guildObj.Uuid && invite.Player == targetUuid {
+			return nil, errors.New("ALREADY_INVITED")
+		}
+	}
+
+	invite := &guild.Invite{
+		Uuid:    uuid.New(),
+		Guild:   guildObj.Uuid,
+		Player:  targetUuid,
+		Expires: time.Now().Add(guild.InviteDuration),
+	}
+
+	w.GuildInvites[invite.Uuid] = invite
+
+	return invite, nil
+}
+
+func (w *World) AcceptGuildInvite(inviteUuid, pUuid uuid.UUID) (*guild.Guild, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	invite, exists := w.GuildInvites[inviteUuid]
+
+	if !exists {
+		return nil, errors.New("INVITE_NOT_FOUND")
+	}
+
+	if invite.Player != pUuid {
+		return nil, errors.New("NOT_ALLOWED")
+	}
+
+	delete(w.GuildInvites, inviteUuid)
+
+	if invite.Expired() {
+		return nil, errors.New("INVITE_EXPIRED")
+	}
+
+	guildObj, exists := w.Guilds[invite.Guild]
+
+	if !exists {
+		return nil, errors.New("GUILD_NOT_FOUND")
+	}
+
+	if w.Players[pUuid].Meta.Guild != nil {
+		return nil, errors.New("IN_GUILD")
+	}
+
+	if len(guildObj.Members) >= guild.MaxMembers {
+		return nil, errors.New("GUILD_FULL")
+	}
+
+	guildObj.Members[pUuid] = &guild.Member{Player: pUuid, Rank: guild.RANK_MEMBER, Joined: w.Time.DayNumber(), Contributed: 0}
+
+	w.syncGuildMeta(guildObj)
+
+	return guildObj, nil
+}
+
+func (w *World) DeclineGuildInvite(inviteUuid, pUuid uuid.UUID) (*guild.Invite, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	invite, exists := w.GuildInvites[inviteUuid]
+
+	if !exists {
+		return nil, errors.New("INVITE_NOT_FOUND")
+	}
+
+	if invite.Player != pUuid {
+		return nil, errors.New("NOT_ALLOWED")
+	}
+
+	delete(w.GuildInvites, inviteUuid)
+
+	return invite, nil
+}
+
+func (w *World) removeGuildMember(guildObj *guild.Guild, memberUuid uuid.UUID) {
+	delete(guildObj.Members, memberUuid)
+
+	if memberObj, exists := w.Players[memberUuid]; exists {
+		memberObj.Meta.Guild = nil
+	}
+
+	if len(guildObj.Members) == 0 {
+		delete(w.Guilds, guildObj.Uuid)
+
+		return
+	}
+
+	//Leader only goes away by dying, highest rank takes over and the longest serving wins ties
+	if guildObj.Leader == memberUuid {
+		var successor *guild.Member
+
+		for _, member := range guildObj.Members {
+			if successor == nil ||
+				member.Rank > successor.Rank ||
+				(member.Rank == successor.Rank && member.Joined < successor.Joined) ||
+				(member.Rank == successor.Rank && member.Joined == successor.Joined && member.Player.String() < successor.Player.String()) {
+				successor = member
+			}
+		}
+
+		successor.Rank = guild.RANK_LEADER
+		guildObj.Leader = successor.Player
+	}
+}
+
+// Leader has to hand over the guild first, last member leaving disbands it
+func (w *World) LeaveGuild(pUuid uuid.UUID) (*guild.Guild, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj, _, err := w.guildMember(pUuid, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if guildObj.Leader == pUuid && len(guildObj.Members) > 1 {
+		return nil, errors.New("LEADER_CANNOT_LEAVE")
+	}
+
+	w.removeGuildMember(guildObj, pUuid)
+
+	return guildObj, nil
+}
+
+// Only members of lower rank can be kicked
+func (w *World) KickFromGuild(pUuid, targetUuid uuid.UUID) (*guild.Guild, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj, member, err := w.guildMember(pUuid, guild.PERM_KICK)
+
+	if err != nil {
+		return nil, err
+	}
+
+	target, exists := guildObj.Members[targetUuid]
+
+	if !exists {
+		return nil, errors.New("NOT_MEMBER")
+	}
+
+	if target.Rank >= member.Rank {
+		return nil, errors.New("RANK_TOO_HIGH")
+	}
+
+	w.removeGuildMember(guildObj, targetUuid)
+
+	return guildObj, nil
+}
+
+// Giving leader rank hands over the guild, previous leader becomes an officer
+func (w *World) SetGuildRank(pUuid, targetUuid uuid.UUID, rank guild.Rank) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj, member, err := w.guildMember(pUuid, guild.PERM_RANKS)
+
+	if err != nil {
+		return err
+	}
+
+	target, exists := guildObj.Members[targetUuid]
+
+	if !exists {
+		return errors.New("NOT_MEMBER")
+	}
+
+	if targetUuid == pUuid {
+		return errors.New("SELF_RANK")
+	}
+
+	if rank == guild.RANK_LEADER {
+		if member.Rank != guild.RANK_LEADER {
+			return errors.New("NO_PERMISSION")
+		}
+
+		member.Rank = guild.RANK_OFFICER
+		target.Rank = guild.RANK_LEADER
+		guildObj.Leader = targetUuid
+
+		return nil
+	}
+
+	if target.Rank >= member.Rank || rank >= member.Rank {
+		return errors.New("RANK_TOO_HIGH")
+	}
+
+	target.Rank = rank
+
+	return nil
+}
+
+// Leader permissions can't be changed
+func (w *World) SetGuildPermission(pUuid uuid.UUID, rank guild.Rank, permission guild.Permission, enabled bool) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj, member, err := w.guildMember(pUuid, 0)
+
+	if err != nil {
+		return err
+	}
+
+	if member.Rank != guild.RANK_LEADER {
+		return errors.New("NO_PERMISSION")
+	}
+
+	if rank == guild.RANK_LEADER {
+		return errors.New("RANK_TOO_HIGH")
+	}
+
+	if enabled {
+		guildObj.Permissions[rank] |= permission
+	} else {
+		guildObj.Permissions[rank] &^= permission
+	}
+
+	return nil
+}
+
+func (w *World) checkBankAccess(playerObj *player.Player) error {
+	if playerObj.Meta.FightInstance != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	if !w.inCity(playerObj) {
+		return errors.New("NOT_IN_CITY")
+	}
+
+	return nil
+}
+
+// Withdrawing needs permission, anyone can deposit
+func (w *World) GuildBankGold(pUuid uuid.UUID, amount int, deposit bool) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	if amount <= 0 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	playerObj := w.Players[pUuid]
+	permission := guild.Permission(0)
+
+	if !deposit {
+		permission = guild.PERM_WITHDRAW
+	}
+
+	guildObj, _, err := w.guildMember(pUuid, permission)
+
+	if err != nil {
+		return err
+	}
+
+	if err := w.checkBankAccess(playerObj); err != nil {
+		return err
+	}
+
+	if deposit {
+		if playerObj.Inventory.Gold < amount {
+			return errors.New("NOT_ENOUGH_GOLD")
+		}
+
+		playerObj.Inventory.Gold -= amount
+		guildObj.Gold += amount
+	} else {
+		if guildObj.Gold < amount {
+			return errors.New("NOT_ENOUGH_GOLD")
+		}
+
+		guildObj.Gold -= amount
+		playerObj.AddGold(amount)
+	}
+
+	guildObj.AddLog(guild.LogEntry{
+		Player:  playerObj.GetName(),
+		Deposit: deposit,
+		Gold:    amount,
+		Item:    uuid.Nil,
+		Day:     w.Time.DayNumber(),
+	})
+
+	return nil
+}
+
+// Caller has to hold StateLock
+func (w *World) checkGuildDeposit(pUuid uuid.UUID, count int) (*guild.Guild, error) {
+	if count <= 0 {
+		return nil, errors.New("INVALID_AMOUNT")
+	}
+
+	guildObj, _, err := w.guildMember(pUuid, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := w.checkBankAccess(w.Players[pUuid]); err != nil {
+		return nil, err
+	}
+
+	return guildObj, nil
+}
+
+// Materials are deposited by uuid, items through DepositGuildItemAt
+func (w *World) DepositGuildItem(pUuid, itemUuid uuid.UUID, count int) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj, err := w.checkGuildDeposit(pUuid, count)
+
+	if err != nil {
+		return err
+	}
+
+	playerObj := w.Players[pUuid]
+
+	ingredient, exists := playerObj.Inventory.Ingredients[itemUuid]
+
+	if !exists || ingredient.Count < count {
+		return errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	playerObj.Inventory.RemoveIngredients([]types.Ingredient{{UUID: itemUuid, Count: count}})
+
+	bankItem, exists := guildObj.Items[itemUuid]
+
+	if !exists {
+		bankItem = &guild.BankItem{Uuid: itemUuid, Item: itemUuid, ItemType: types.ITEM_MATERIAL, Count: 0}
+		guildObj.Items[itemUuid] = bankItem
+	}
+
+	bankItem.Count += count
+
+	guildObj.AddLog(guild.LogEntry{
+		Player:   playerObj.GetName(),
+		Deposit:  true,
+		Item:     itemUuid,
+		ItemType: types.ITEM_MATERIAL,
+		Count:    count,
+		Day:      w.Time.DayNumber(),
+	})
+
+	return nil
+}
+
+// Item is picked by inventory index, bank keeps the instance, equipped items are never taken
+func (w *World) DepositGuildItemAt(pUuid uuid.UUID, itemIdx int, count int) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj, err := w.checkGuildDeposit(pUuid, count)
+
+	if err != nil {
+		return err
+	}
+
+	playerObj := w.Players[pUuid]
+
+	if itemIdx < 0 || itemIdx >= len(playerObj.Inventory.Items) {
+		return errors.New("ITEM_NOT_FOUND")
+	}
+
+	if playerObj.Inventory.Items[itemIdx].Equipped {
+		return errors.New("ITEM_EQUIPPED")
+	}
+
+	if playerObj.Inventory.Items[itemIdx].Count < count {
+		return errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	item := playerObj.Inventory.TakeItemAt(itemIdx, count)
+
+	var bankItem *guild.BankItem
+
+	for _, stored := range guildObj.Items {
+		if stored.Instance != nil && stored.Instance.StacksWith(item) {
+			bankItem = stored
+			break
+		}
+	}
+
+	if bankItem == nil {
+		bankItem = &guild.BankItem{Uuid: uuid.New(), Item: item.UUID, ItemType: types.ITEM_OTHER, Count: 0, Instance: item}
+		guildObj.Items[bankItem.Uuid] = bankItem
+	}
+
+	bankItem.Count += count
+
+	guildObj.AddLog(guild.LogEntry{
+		Player:   playerObj.GetName(),
+		Deposit:  true,
+		Item:     item.UUID,
+		ItemType: types.ITEM_OTHER,
+		Count:    count,
+		Day:      w.Time.DayNumber(),
+	})
+
+	return nil
+}
+
+// Takes entry out of guild bank by its key
+func (w *World) WithdrawGuildItem(pUuid, bankUuid uuid.UUID, count int) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	if count <= 0 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	playerObj := w.Players[pUuid]
+
+	guildObj, _, err := w.guildMember(pUuid, guild.PERM_WITHDRAW)
+
+	if err != nil {
+		return err
+	}
+
+	if err := w.checkBankAccess(playerObj); err != nil {
+		return err
+	}
+
+	bankItem, exists := guildObj.Items[bankUuid]
+
+	if !exists || bankItem.Count < count {
+		return errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	ingredient, isIngredient := data.Ingredients[bankItem.Item]
+	itemObj, isItem := data.Items[bankItem.Item]
+
+	if !isIngredient && !isItem {
+		return errors.New("ITEM_NOT_FOUND")
+	}
+
+	bankItem.Count -= count
+
+	if bankItem.Count == 0 {
+		delete(guildObj.Items, bankUuid)
+	}
+
+	//Not through GiveLoot, taking items back must not count as gathering
+	if bankItem.ItemType == types.ITEM_MATERIAL {
+		ingredient.Count = count
+
+		playerObj.Inventory.AddIngredient(&ingredient)
+	} else {
+		//Items deposited before instances were kept come back as new ones
+		if bankItem.Instance != nil {
+			itemObj = *bankItem.Instance
+		}
+
+		itemObj.Count = count
+
+		playerObj.AddItem(&itemObj)
+	}
+
+	guildObj.AddLog(guild.LogEntry{
+		Player:   playerObj.GetName(),
+		Deposit:  false,
+		Item:     bankItem.Item,
+		ItemType: bankItem.ItemType,
+		Count:    count,
+		Day:      w.Time.DayNumber(),
+	})
+
+	return nil
+}
+
+// Part of member's fight XP goes to the guild
+func (w *World) AddGuildExp(pUuid uuid.UUID, fightExp int) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	guildObj := w.playerGuild(pUuid)
+	value := utils.PercentOf(fightExp, guild.ExpSharePercent)
+
+	if guildObj == nil || value <= 0 {
+		return
+	}
+
+	guildObj.Members[pUuid].Contributed += value
+
+	if !guildObj.AddExp(value) {
+		return
+	}
+
+	w.syncGuildMeta(guildObj)
+
+	if guildObj.ChannelID == "" {
+		return
+	}
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: guildObj.ChannelID,
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.
+					NewEmbedBuilder().
+					SetTitle("Awans gildii!").
+					SetDescriptionf("Gildia %s osiąga poziom %d", guildObj.Name, guildObj.Level).
+					Build(),
+			).
+			Build(),
+	}
+}
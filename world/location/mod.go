@@ -4,6 +4,12 @@ import (
 	"encoding/json"
 	"os"
 	"sao/config"
+	"sao/types"
+	"sao/utils"
+	"sao/world/calendar"
+	"sao/world/gathering"
+
+	"github.com/google/uuid"
 )
 
 type Location struct {
@@ -15,12 +21,57 @@ type Location struct {
 	Enemies  []EnemyMeta
 	Unlocked bool
 	Flags    []string
+	//Clock ticks it takes to get here
+	Travel int
+	//Locked locations can still be found by exploring, nil if not discoverable
+	Discovery *Discovery
+	Nodes     []gathering.Node
+}
+
+// Default travel time when location doesn't set its own
+const DefaultTravel = 2
+
+type Discovery struct {
+	//Locations explored or travelled through to find it
+	From []string
+	//Percent per clock tick
+	Chance int
 }
 
 type EnemyMeta struct {
-	MinNum int
-	MaxNum int
-	Enemy  string
+	MinNum   int
+	MaxNum   int
+	Enemy    string
+	Schedule Schedule
+}
+
+// Optional time window, empty fields mean always active
+type Schedule struct {
+	//[from, to), wraps around midnight
+	Hours   []int
+	Seasons []calendar.Season
+}
+
+func (s Schedule) Active(c *calendar.Calendar) bool {
+	if len(s.Hours) == 2 && !c.InHours(s.Hours[0], s.Hours[1]) {
+		return false
+	}
+
+	if len(s.Seasons) == 0 {
+		return true
+	}
+
+	for _, season := range s.Seasons {
+		if season == c.GetSeason() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s Schedule) Always() bool {
+	return len(s.Hours) != 2 && len(s.Seasons) == 0
 }
 
 type Floor struct {
@@ -34,10 +85,63 @@ type Floor struct {
 	CountsAsUnlocked bool
 }
 
+type EffectTarget int
+
+const (
+	TARGET_ALL EffectTarget = iota
+	TARGET_PLAYERS
+	TARGET_MOBS
+)
+
+var StringToEffectTarget = map[string]EffectTarget{
+	"ALL":     TARGET_ALL,
+	"PLAYERS": TARGET_PLAYERS,
+	"MOBS":    TARGET_MOBS,
+}
+
 type LocationEffect struct {
-	Effect int
-	Value  int
-	Meta   *map[string]interface{}
+	Effect    int
+	Value     int
+	Meta      *map[string]interface{}
+	Stat      types.Stat
+	IsPercent bool
+	Target    EffectTarget
+	Schedule  Schedule
+}
+
+func (l Location) ActiveEnemies(c *calendar.Calendar) []EnemyMeta {
+	enemies := make([]EnemyMeta, 0)
+
+	for _, enemy := range l.Enemies {
+		if enemy.Schedule.Active(c) {
+			enemies = append(enemies, enemy)
+		}
+	}
+
+	return enemies
+}
+
+// Floor wide effects first, then the ones specific to location
+func (f Floor) ActiveEffects(l Location, c *calendar.Calendar) []LocationEffect {
+	effects := make([]LocationEffect, 0)
+
+	for _, effect := range append(append([]LocationEffect{}, f.Effects...), l.Effects...) {
+		if effect.Schedule.Active(c) {
+			effects = append(effects, effect)
+		}
+	}
+
+	return effects
+}
+
+func (l Location) FindNode(nodeId string) *gathering.Node {
+	for _, node := range l.Nodes {
+		if node.Id == nodeId {
+			return &node
+		}
+	}
+
+	return nil
 }
 
 func (f Floor) FindLocation(str string) *Location {
@@ -115,12 +219,7 @@ func GetFloors() map[string]Floor {
 			var Effects = make([]LocationEffect, 0)
 
 			for _, eff := range loc.(map[string]interface{})["Effects"].([]interface{}) {
-				e := eff.(map[string]interface{})
-				Effects = append(Effects, LocationEffect{
-					Effect: int(e["Effect"].(float64)),
-					Value:  int(e["Value"].(float64)),
-					Meta:   nil,
-				})
+				Effects = append(Effects, parseEffect(eff.(map[string]interface{})))
 			}
 
 			var Enemies = make([]EnemyMeta, 0)
@@ -128,9 +227,10 @@ func GetFloors() map[string]Floor {
 			for _, en := range loc.(map[string]interface{})["Enemies"].([]interface{}) {
 				e := en.(map[string]interface{})
 				Enemies = append(Enemies, EnemyMeta{
-					MinNum: int(e["MinNum"].(float64)),
-					MaxNum: int(e["MaxNum"].(float64)),
-					Enemy:  e["Enemy"].(string),
+					MinNum:   int(e["MinNum"].(float64)),
+					MaxNum:   int(e["MaxNum"].(float64)),
+					Enemy:    e["Enemy"].(string),
+					Schedule: parseSchedule(e),
 				})
 			}
 
@@ -140,27 +240,49 @@ func GetFloors() map[string]Floor {
 				locationFlags[i] = flag.(string)
 			}
 
+			lTravel := DefaultTravel
+
+			if rawTravel, ok := loc.(map[string]interface{})["Travel"].(float64); ok {
+				lTravel = int(rawTravel)
+			}
+
+			lNodes := make([]gathering.Node, 0)
+
+			if rawNodes, ok := loc.(map[string]interface{})["Nodes"].([]interface{}); ok {
+				for _, rawNode := range rawNodes {
+					lNodes = append(lNodes, parseNode(rawNode.(map[string]interface{})))
+				}
+			}
+
+			var lDiscovery *Discovery
+
+			if rawDiscovery, ok := loc.(map[string]interface{})["Discovery"].(map[string]interface{}); ok {
+				lDiscovery = &Discovery{From: make([]string, 0), Chance: int(rawDiscovery["Chance"].(float64))}
+
+				for _, from := range rawDiscovery["From"].([]interface{}) {
+					lDiscovery.From = append(lDiscovery.From, from.(string))
+				}
+			}
+
 			Locations = append(Locations, Location{
-				Name:     lName,
-				CID:      lCID,
-				CityPart: lCityPart,
-				Effects:  Effects,
-				TP:       lTP,
-				Enemies:  Enemies,
-				Unlocked: lUnlocked,
-				Flags:    locationFlags,
+				Name:      lName,
+				CID:       lCID,
+				CityPart:  lCityPart,
+				Effects:   Effects,
+				TP:        lTP,
+				Enemies:   Enemies,
+				Unlocked:  lUnlocked,
+				Flags:     locationFlags,
+				Travel:    lTravel,
+				Discovery: lDiscovery,
+				Nodes:     lNodes,
 			})
 		}
 
 		var Effects = make([]LocationEffect, 0)
 
 		for _, eff := range floor["Effects"].([]interface{}) {
-			e := eff.(map[string]interface{})
-			Effects = append(Effects, LocationEffect{
-				Effect: int(e["Effect"].(float64)),
-				Value:  int(e["Value"].(float64)),
-				Meta:   nil,
-			})
+			Effects = append(Effects, parseEffect(eff.(map[string]interface{})))
 		}
 
 		floors[Name] = Floor{
@@ -177,3 +299,84 @@ func GetFloors() map[string]Floor {
 	}
 	return floors
 }
+
+func parseEffect(e map[string]interface{}) LocationEffect {
+	effect := LocationEffect{
+		Effect:   int(e["Effect"].(float64)),
+		Value:    int(e["Value"].(float64)),
+		Meta:     nil,
+		Target:   TARGET_ALL,
+		Schedule: parseSchedule(e),
+	}
+
+	if stat, ok := e["Stat"].(string); ok {
+		effect.Stat = utils.StringToStat[stat]
+	}
+
+	if isPercent, ok := e["IsPercent"].(bool); ok {
+		effect.IsPercent = isPercent
+	}
+
+	if target, ok := e["Target"].(string); ok {
+		effect.Target = StringToEffectTarget[target]
+	}
+
+	return effect
+}
+
+func parseSchedule(e map[string]interface{}) Schedule {
+	schedule := Schedule{}
+
+	if hours, ok := e["Hours"].([]interface{}); ok && len(hours) == 2 {
+		schedule.Hours = []int{int(hours[0].(float64)), int(hours[1].(float64))}
+	}
+
+	if seasons, ok := e["Seasons"].([]interface{}); ok {
+		for _, season := range seasons {
+			parsed, exists := calendar.StringToSeason[season.(string)]
+
+			if !exists {
+				panic("Unknown season " + season.(string))
+			}
+
+			schedule.Seasons = append(schedule.Seasons, parsed)
+		}
+	}
+
+	return schedule
+}
+
+func parseNode(n map[string]interface{}) gathering.Node {
+	profession, ok := gathering.StringToProfession[n["Type"].(string)]
+
+	if !ok {
+		panic("Unknown node type " + n["Type"].(string))
+	}
+
+	node := gathering.Node{
+		Id:          n["Id"].(string),
+		Type:        profession,
+		Ingredients: make([]uuid.UUID, 0),
+		Level:       1,
+		Charges:     1,
+		Respawn:     int(n["Respawn"].(float64)),
+	}
+
+	for _, ingredient := range n["Ingredients"].([]interface{}) {
+		node.Ingredients = append(node.Ingredients, uuid.MustParse(ingredient.(string)))
+	}
+
+	if len(node.Ingredients) == 0 {
+		panic("Node " + node.Id + " has no ingredients")
+	}
+
+	if level, ok := n["Level"].(float64); ok {
+		node.Level = int(level)
+	}
+
+	if charges, ok := n["Charges"].(float64); ok {
+		node.Charges = int(charges)
+	}
+
+	return node
+}
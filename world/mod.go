@@ -12,14 +12,26 @@ import (
 	"sao/player"
 	"sao/types"
 	"sao/utils"
+	"sao/world/auction"
+	"sao/world/bounty"
 	"sao/world/calendar"
+	"sao/world/death"
+	"sao/world/duel"
+	"sao/world/dungeon"
+	"sao/world/event"
+	"sao/world/fury"
+	"sao/world/gathering"
+	"sao/world/guild"
 	"sao/world/location"
 	"sao/world/party"
+	"sao/world/quest"
 	"sao/world/tournament"
 	"sao/world/transaction"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/disgoorg/disgo"
@@ -32,17 +44,43 @@ import (
 type FloorMap map[string]location.Floor
 
 type World struct {
-	Players        map[uuid.UUID]*player.Player
-	Transactions   map[uuid.UUID]*transaction.Transaction
-	Stores         map[uuid.UUID]*types.NPCStore
-	Floors         FloorMap
-	Tournaments    map[uuid.UUID]*tournament.Tournament
-	Fights         map[uuid.UUID]*battle.Fight
-	Entities       map[uuid.UUID]*types.Entity
-	Time           *calendar.Calendar
-	Parties        map[uuid.UUID]*party.Party
+	Players         map[uuid.UUID]*player.Player
+	Transactions    map[uuid.UUID]*transaction.Transaction
+	Stores          map[uuid.UUID]*types.NPCStore
+	Floors          FloorMap
+	Tournaments     map[uuid.UUID]*tournament.Tournament
+	Fights          map[uuid.UUID]*battle.Fight
+	Entities        map[uuid.UUID]*types.Entity
+	Time            *calendar.Calendar
+	Parties         map[uuid.UUID]*party.Party
+	LootRolls       map[uuid.UUID]*party.LootRoll
+	PartyInvites    map[uuid.UUID]*party.Invite
+	PartyListings   map[uuid.UUID]*party.Listing
+	ReadyChecks     map[uuid.UUID]*party.ReadyCheck
+	Auctions        map[uuid.UUID]*auction.Auction
+	DroppedItems    map[uuid.UUID]*death.DroppedItem
+	Fallen          []*death.Fallen
+	Bounties        map[string]*bounty.Board
+	Events          map[uuid.UUID]*event.Event
+	ScheduledEvents []event.Scheduled
+	CalendarHooks   map[calendar.Boundary][]CalendarHook
+	//Node key => state, only depleted or partially gathered nodes
+	Nodes    map[string]*gathering.NodeState
+	Dungeons map[uuid.UUID]*dungeon.Run
+	//Dungeon id => best runs
+	DungeonRecords map[string][]dungeon.Record
+	//Pending and running duels
+	Duels          map[uuid.UUID]*duel.Challenge
+	TeamChallenges map[uuid.UUID]*duel.TeamChallenge
+	//Team key => rating
+	TeamRatings    map[string]*duel.TeamRating
+	TeamResults    []duel.TeamResult
+	Guilds         map[uuid.UUID]*guild.Guild
+	GuildInvites   map[uuid.UUID]*guild.Invite
 	DiscordChannel chan types.DiscordEvent
 	BufferChannel  chan types.DiscordMessageStruct
+	//Guards gold, inventories and feature state shared between commands and the clock
+	StateLock sync.Mutex
 }
 
 func (w *World) MessageHandler() {
@@ -68,59 +106,221 @@ func CreateWorld() World {
 		make(map[uuid.UUID]*types.Entity),
 		calendar.StartCalendar(),
 		make(map[uuid.UUID]*party.Party),
+		make(map[uuid.UUID]*party.LootRoll),
+		make(map[uuid.UUID]*party.Invite),
+		make(map[uuid.UUID]*party.Listing),
+		make(map[uuid.UUID]*party.ReadyCheck),
+		make(map[uuid.UUID]*auction.Auction),
+		make(map[uuid.UUID]*death.DroppedItem),
+		make([]*death.Fallen, 0),
+		make(map[string]*bounty.Board),
+		make(map[uuid.UUID]*event.Event),
+		make([]event.Scheduled, 0),
+		defaultCalendarHooks(),
+		make(map[string]*gathering.NodeState),
+		make(map[uuid.UUID]*dungeon.Run),
+		make(map[string][]dungeon.Record),
+		make(map[uuid.UUID]*duel.Challenge),
+		make(map[uuid.UUID]*duel.TeamChallenge),
+		make(map[string]*duel.TeamRating),
+		make([]duel.TeamResult, 0),
+		make(map[uuid.UUID]*guild.Guild),
+		make(map[uuid.UUID]*guild.Invite),
 		make(chan types.DiscordEvent, 10),
 		make(chan types.DiscordMessageStruct, 10),
+		sync.Mutex{},
 	}
 }
 
 func (w *World) MovePlayer(pUuid uuid.UUID, floorName, locationName, reason string) error {
 	player := w.Players[pUuid]
 
-	floorData, floorExists := w.Floors[floorName]
+	if err := w.checkMove(player, floorName, locationName); err != nil {
+		return err
+	}
 
-	if !floorExists || (!floorData.Unlocked && player.Meta.Location.Floor != floorName) {
-		return errors.New("floor not found or locked")
+	//Arrival is the only move allowed mid-route
+	if player.IsTraveling() && reason != MOVE_TRAVEL {
+		return errors.New("player is traveling")
 	}
 
-	if locationData := w.Floors[floorName].FindLocation(locationName); locationData == nil || !locationData.Unlocked {
-		return errors.New("location not found or locked")
+	player.Meta.Location.Floor = floorName
+	player.Meta.Location.Location = locationName
+
+	w.UpdateQuests(pUuid, quest.OBJECTIVE_REACH, quest.LocationKey(player.Meta.Location), 1)
+
+	w.PartyFollow(pUuid)
+
+	return nil
+}
+
+// Members of leader's party either follow automatically or get a prompt
+func (w *World) PartyFollow(leaderUuid uuid.UUID) {
+	leader := w.Players[leaderUuid]
+
+	if leader.Meta.Party == nil {
+		return
 	}
 
-	if player.Meta.FightInstance != nil {
-		return errors.New("player is in fight")
+	partyData := w.Parties[leader.Meta.Party.UUID]
+
+	if partyData.Leader != leaderUuid {
+		return
 	}
 
-	hasPerPlayerUnlock := false
-	for _, flag := range floorData.Flags {
-		if flag == "per-player" {
-			hasPerPlayerUnlock = true
-			break
+	for _, member := range partyData.Players {
+		if member.PlayerUuid == leaderUuid {
+			continue
 		}
-	}
 
-	if hasPerPlayerUnlock {
-		playerUnlocked := false
+		memberObj := w.Players[member.PlayerUuid]
 
-		for _, floor := range player.Meta.UnlockedFloors {
-			if floor == floorName {
-				playerUnlocked = true
-				break
+		if memberObj.Meta.Location == leader.Meta.Location {
+			continue
+		}
+
+		if partyData.AutoFollow {
+			ticks, err := w.Travel(member.PlayerUuid, leader.Meta.Location.Floor, leader.Meta.Location.Location)
+
+			content := fmt.Sprintf("Podążasz za liderem do %s", leader.Meta.Location.Location)
+
+			if err != nil {
+				content = fmt.Sprintf("Nie możesz podążyć za liderem do %s", leader.Meta.Location.Location)
+			} else if ticks > 0 {
+				content += fmt.Sprintf(", dotrzesz <t:%d:R>", time.Now().Add(time.Duration(ticks)*time.Minute).Unix())
+			}
+
+			w.BufferChannel <- types.DiscordMessageStruct{
+				ChannelID:      memberObj.Meta.UserID,
+				MessageContent: discord.NewMessageCreateBuilder().SetContent(content).Build(),
+				DM:             true,
 			}
+
+			continue
+		}
+
+		w.BufferChannel <- types.DiscordMessageStruct{
+			ChannelID: memberObj.Meta.UserID,
+			MessageContent: discord.NewMessageCreateBuilder().
+				SetContentf("Lider party (%s) przeszedł do %s (%s)", leader.GetName(), leader.Meta.Location.Location, leader.Meta.Location.Floor).
+				AddActionRow(discord.NewPrimaryButton("Podążaj", "party/follow|"+leader.Meta.Party.UUID.String())).
+				Build(),
+			DM: true,
 		}
+	}
+}
 
-		if !playerUnlocked {
-			return errors.New("player has not unlocked this floor")
+// Followers take the road like everyone else, returns ticks until arrival
+func (w *World) FollowLeader(pUuid uuid.UUID) (int, error) {
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.Party == nil {
+		return 0, errors.New("NOT_IN_PARTY")
+	}
+
+	leader := w.Players[w.Parties[playerObj.Meta.Party.UUID].Leader]
+
+	return w.Travel(pUuid, leader.Meta.Location.Floor, leader.Meta.Location.Location)
+}
+
+// Party members that are not standing with player
+func (w *World) PartyMembersElsewhere(pUuid uuid.UUID) []*player.Player {
+	playerObj := w.Players[pUuid]
+
+	elsewhere := make([]*player.Player, 0)
+
+	if playerObj.Meta.Party == nil {
+		return elsewhere
+	}
+
+	for _, member := range w.Parties[playerObj.Meta.Party.UUID].Players {
+		memberObj := w.Players[member.PlayerUuid]
+
+		if memberObj.Meta.Location != playerObj.Meta.Location {
+			elsewhere = append(elsewhere, memberObj)
 		}
+	}
+
+	return elsewhere
+}
 
+func (w *World) StartReadyCheck(pUuid uuid.UUID, threadId string) *party.ReadyCheck {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	playerObj := w.Players[pUuid]
+
+	check := &party.ReadyCheck{
+		Uuid:      uuid.New(),
+		Party:     playerObj.Meta.Party.UUID,
+		Initiator: pUuid,
+		ThreadId:  threadId,
+		Members:   make([]uuid.UUID, 0),
+		Ready:     map[uuid.UUID]bool{pUuid: true},
+		Expires:   time.Now().Add(party.ReadyCheckDuration),
 	}
 
-	player.Meta.Location.Floor = floorName
-	player.Meta.Location.Location = locationName
+	for _, member := range w.Parties[check.Party].Players {
+		check.Members = append(check.Members, member.PlayerUuid)
+	}
 
-	return nil
+	w.ReadyChecks[check.Uuid] = check
+
+	return check
+}
+
+func (w *World) MarkReady(checkUuid, pUuid uuid.UUID, ready bool) (*party.ReadyCheck, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	check, exists := w.ReadyChecks[checkUuid]
+
+	if !exists || check.Expired() {
+		delete(w.ReadyChecks, checkUuid)
+
+		return nil, errors.New("CHECK_NOT_FOUND")
+	}
+
+	if !check.IsMember(pUuid) {
+		return nil, errors.New("NOT_MEMBER")
+	}
+
+	playerObj := w.Players[pUuid]
+
+	if ready && playerObj.Meta.Location != w.Players[check.Initiator].Meta.Location {
+		return nil, errors.New("WRONG_LOCATION")
+	}
+
+	if ready && playerObj.Meta.FightInstance != nil {
+		return nil, errors.New("IN_FIGHT")
+	}
+
+	check.Ready[pUuid] = ready
+
+	if check.State() != party.ReadyPending {
+		delete(w.ReadyChecks, checkUuid)
+	}
+
+	return check, nil
+}
+
+func (w *World) ExpireReadyChecks() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	for checkUuid, check := range w.ReadyChecks {
+		if check.Expired() {
+			delete(w.ReadyChecks, checkUuid)
+		}
+	}
 }
 
 func (w *World) PlayerFight(pUuid uuid.UUID, threadId string, mentionAll bool, mobId string, mobCount int) {
+	w.mobFight(pUuid, true, threadId, mentionAll, mobId, mobCount)
+}
+
+// Party members standing with player join only when withParty is set
+func (w *World) mobFight(pUuid uuid.UUID, withParty bool, threadId string, mentionAll bool, mobId string, mobCount int) {
 	playerObj := w.Players[pUuid]
 
 	floor := w.Floors[playerObj.Meta.Location.Floor]
@@ -135,10 +335,17 @@ func (w *World) PlayerFight(pUuid uuid.UUID, threadId string, mentionAll bool, m
 		}
 	}
 
-	if playerObj.Meta.Party != nil {
+	if withParty && playerObj.Meta.Party != nil {
 		for _, member := range w.Parties[playerObj.Meta.Party.UUID].Players {
-			entityMap[w.Players[member.PlayerUuid].GetUUID()] = battle.EntityEntry{
-				Entity: w.Players[member.PlayerUuid],
+			memberObj := w.Players[member.PlayerUuid]
+
+			//Only members standing with player who aren't busy
+			if memberObj.Meta.Location != playerObj.Meta.Location || (member.PlayerUuid != pUuid && (memberObj.Meta.FightInstance != nil || memberObj.IsTraveling())) {
+				continue
+			}
+
+			entityMap[memberObj.GetUUID()] = battle.EntityEntry{
+				Entity: memberObj,
 				Side:   0,
 			}
 		}
@@ -162,6 +369,8 @@ func (w *World) PlayerFight(pUuid uuid.UUID, threadId string, mentionAll bool, m
 		},
 	}
 
+	applyLocationEffects(entityMap, floor.ActiveEffects(location, w.Time))
+
 	fight.Init()
 
 	fightUUID := w.RegisterFight(&fight)
@@ -185,12 +394,16 @@ func (w *World) PlayerFight(pUuid uuid.UUID, threadId string, mentionAll bool, m
 		}
 	}
 
-	playerObj.Meta.FightInstance = &fightUUID
+	for _, entity := range fight.Entities {
+		if entity.Entity.GetFlags()&types.ENTITY_AUTO == 0 {
+			entity.Entity.(*player.Player).Meta.FightInstance = &fightUUID
+		}
+	}
 
 	go w.ListenForFight(fightUUID)
 }
 
-func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.ApplicationCommandInteractionCreate) {
+func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, reply func(discord.MessageCreate)) {
 	player := w.Players[pUuid]
 	floor := w.Floors[player.Meta.Location.Floor]
 
@@ -198,6 +411,17 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 		return
 	}
 
+	if player.IsTraveling() {
+		reply(
+			discord.
+				NewMessageCreateBuilder().
+				SetContent("Jesteś w drodze, poczekaj aż skończysz!").
+				Build(),
+		)
+
+		return
+	}
+
 	var location location.Location
 
 	for _, loc := range floor.Locations {
@@ -210,6 +434,19 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 		return
 	}
 
+	enemies := location.ActiveEnemies(w.Time)
+
+	if len(enemies) == 0 {
+		reply(
+			discord.
+				NewMessageCreateBuilder().
+				SetContent("O tej porze nikogo tu nie ma, wróć później!").
+				Build(),
+		)
+
+		return
+	}
+
 	isPrivate := false
 	mentionAll := false
 	canChoose := true
@@ -225,12 +462,14 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 
 	if isPrivate {
 		if player.Meta.Party != nil {
-			event.CreateMessage(
+			reply(
 				discord.
 					NewMessageCreateBuilder().
 					SetContent("Nie możesz walczyć z przeciwnikami w prywatnych lokacjach będąc w party!").
 					Build(),
 			)
+
+			return
 		}
 	}
 
@@ -254,9 +493,9 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 	}
 
 	if canChoose {
-		if len(location.Enemies) == 1 {
-			if location.Enemies[0].MinNum == location.Enemies[0].MaxNum {
-				go w.PlayerFight(pUuid, threadId, mentionAll, location.Enemies[0].Enemy, location.Enemies[0].MinNum)
+		if len(enemies) == 1 {
+			if enemies[0].MinNum == enemies[0].MaxNum {
+				go w.PlayerFight(pUuid, threadId, mentionAll, enemies[0].Enemy, enemies[0].MinNum)
 
 				return
 			}
@@ -265,11 +504,11 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 
 			options := make([]discord.StringSelectMenuOption, 0)
 
-			for i := location.Enemies[0].MinNum; i <= location.Enemies[0].MaxNum; i++ {
+			for i := enemies[0].MinNum; i <= enemies[0].MaxNum; i++ {
 				options = append(options, discord.NewStringSelectMenuOption(strconv.Itoa(i), strconv.Itoa(i)))
 			}
 
-			event.CreateMessage(
+			reply(
 				discord.
 					NewMessageCreateBuilder().
 					AddActionRow(
@@ -299,7 +538,7 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 							SetContent("Wybrano " + choiceRaw + " przeciwników!").
 							Build())
 
-						go w.PlayerFight(pUuid, threadId, mentionAll, location.Enemies[0].Enemy, choice)
+						go w.PlayerFight(pUuid, threadId, mentionAll, enemies[0].Enemy, choice)
 					},
 				},
 			}
@@ -308,11 +547,11 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 
 			options := make([]discord.StringSelectMenuOption, 0)
 
-			for idx, enemy := range location.Enemies {
+			for idx, enemy := range enemies {
 				options = append(options, discord.NewStringSelectMenuOption(enemy.Enemy, strconv.Itoa(idx)))
 			}
 
-			event.CreateMessage(
+			reply(
 				discord.
 					NewMessageCreateBuilder().
 					AddActionRow(
@@ -336,11 +575,11 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 							return
 						}
 
-						enemy := location.Enemies[choice]
+						enemy := enemies[choice]
 
 						if enemy.MinNum == enemy.MaxNum {
 							count := enemy.MinNum
-							cic.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Wybrano przeciwnika - " + mobs.Mobs[location.Enemies[choice].Enemy].Name).Build())
+							cic.UpdateMessage(discord.NewMessageUpdateBuilder().ClearContainerComponents().SetContent("Wybrano przeciwnika - " + mobs.Mobs[enemies[choice].Enemy].Name).Build())
 
 							go w.PlayerFight(pUuid, threadId, mentionAll, enemy.Enemy, count)
 						} else {
@@ -390,7 +629,7 @@ func (w *World) PlayerSearch(pUuid uuid.UUID, threadId string, event *events.App
 
 		return
 	} else {
-		enemy := utils.RandomElement(location.Enemies)
+		enemy := utils.RandomElement(enemies)
 		enemyCount := utils.RandomNumber(enemy.MinNum, enemy.MaxNum)
 
 		go w.PlayerFight(pUuid, threadId, mentionAll, enemy.Enemy, enemyCount)
@@ -406,8 +645,21 @@ func (w *World) StartBackupClock() {
 func (w *World) StartClock() {
 	go w.StartBackupClock()
 
+	w.UpdateStores(false)
+	w.CleanupAuctions()
+	w.RefreshBounties()
+
 	for range time.Tick(1 * time.Minute) {
-		w.Time.Tick()
+		for _, boundary := range w.Time.Tick() {
+			w.RunCalendarHooks(boundary)
+		}
+
+		w.AdvanceTravels()
+		w.RespawnNodes()
+
+		w.CleanupPartyFinder()
+		w.ExpireLootRolls()
+		w.ExpireReadyChecks()
 
 		for pUuid, player := range w.Players {
 			//Not in fight
@@ -416,20 +668,11 @@ func (w *World) StartClock() {
 			}
 
 			//Dead
-			//TODO only resurrect if not in hardcore mode
 			if player.GetCurrentHP() <= 0 {
-				location := w.Floors[player.Meta.Location.Floor].FindLocation(player.Meta.Location.Location)
-
-				player.Stats.HP = player.GetStat(types.STAT_HP)
-
-				w.BufferChannel <- types.DiscordMessageStruct{
-					ChannelID: location.CID,
-					MessageContent: discord.
-						NewMessageCreateBuilder().
-						AddEmbeds(
-							discord.NewEmbedBuilder().SetTitle("Wskrzeszenie!").SetDescriptionf("%s zostaje wskrzeszony...", player.GetName()).Build(),
-						).
-						Build(),
+				if player.Meta.Hardcore {
+					w.PermaDeath(pUuid)
+				} else {
+					w.Respawn(pUuid)
 				}
 
 				continue
@@ -520,269 +763,1415 @@ func (w *World) RegisterFight(fight *battle.Fight) uuid.UUID {
 	return uuid
 }
 
-func (w *World) ListenForFight(fightUuid uuid.UUID) {
-	fight, ok := w.Fights[fightUuid]
-
-	if !ok {
-		return
-	}
+func (w *World) UpdateStores(newDay bool) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
 
-	go fight.Run()
+	day := w.Time.DayNumber()
 
-	channelId := fight.GetChannelId()
+	for _, store := range w.Stores {
+		if newDay {
+			store.DriftPrices()
+		}
 
-	for {
-		eventData, ok := <-fight.ExternalChannel
+		if store.NeedsRestock(day) {
+			store.Restock(day)
+		}
+	}
+}
 
-		if !ok {
-			w.DeregisterFight(fightUuid)
-			break
+func (w *World) findStoreByName(name string) (*types.NPCStore, bool) {
+	for _, store := range w.Stores {
+		if store.Name == name {
+			return store, true
 		}
+	}
 
-		switch eventData.GetEvent() {
-		case battle.MSG_FIGHT_END:
+	return nil, false
+}
 
-			if eventData.GetData().(bool) {
-				w.BufferChannel <- types.DiscordMessageStruct{
-					ChannelID: channelId,
-					MessageContent: discord.
-						NewMessageCreateBuilder().
-						AddEmbeds(
-							discord.
-								NewEmbedBuilder().
-								SetTitle("Koniec walki!").
-								SetDescriptionf("Wszyscy gracze uciekli z walki!").
-								Build(),
-						).
-						Build(),
-				}
+func (w *World) BuyFromStore(pUuid uuid.UUID, storeUuid uuid.UUID, stockIdx int, amount int) (player.Purchase, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
 
-				w.DeregisterFight(fightUuid)
+	buyer := w.Players[pUuid]
 
-				return
-			}
+	store, exists := w.Stores[storeUuid]
 
-			wonSideIDX := fight.SidesLeft()[0]
-			wonEntities := fight.FromSide(wonSideIDX)
+	if !exists {
+		return player.Purchase{}, errors.New("STORE_NOT_FOUND")
+	}
 
-			allAuto := true
+	if stockIdx < 0 || stockIdx >= len(store.Stock) {
+		return player.Purchase{}, errors.New("ITEM_NOT_FOUND")
+	}
 
-			for _, entity := range wonEntities {
-				if entity.GetFlags()&types.ENTITY_AUTO != types.ENTITY_AUTO {
-					allAuto = false
-					break
-				}
-			}
+	if amount <= 0 {
+		return player.Purchase{}, errors.New("INVALID_AMOUNT")
+	}
 
-			if allAuto {
-				w.DeregisterFight(fightUuid)
+	if buyer.Meta.Location != store.Location {
+		return player.Purchase{}, errors.New("WRONG_LOCATION")
+	}
 
-				wonSideText := ""
+	if buyer.Meta.FightInstance != nil {
+		return player.Purchase{}, errors.New("IN_FIGHT")
+	}
 
-				for _, entity := range wonEntities {
-					wonSideText += fmt.Sprintf("%v\n", entity.GetName())
-				}
+	stock := store.Stock[stockIdx]
 
-				wonSideText = wonSideText[:len(wonSideText)-1]
+	if !stock.Available(amount) {
+		return player.Purchase{}, errors.New("OUT_OF_STOCK")
+	}
 
-				w.BufferChannel <- types.DiscordMessageStruct{
-					ChannelID: channelId,
-					MessageContent: discord.
-						NewMessageCreateBuilder().
-						AddEmbeds(
-							discord.
-								NewEmbedBuilder().
-								SetTitle("Koniec walki!").
-								SetDescriptionf("Wygrali:\n" + wonSideText).
-								Build(),
-						).
-						Build(),
-				}
+	price := stock.Price * amount
 
-				break
-			}
+	if buyer.Inventory.Gold < price {
+		return player.Purchase{}, errors.New("NOT_ENOUGH_GOLD")
+	}
 
-			enemies := make([]types.Entity, 0)
+	if stock.ItemType == types.ITEM_MATERIAL {
+		ingredient, exists := data.Ingredients[stock.ItemUUID]
 
-			xpMap := make(map[uuid.UUID]int)
-			goldMap := make(map[uuid.UUID]int)
+		if !exists {
+			return player.Purchase{}, errors.New("ITEM_NOT_FOUND")
+		}
 
-			for _, entity := range fight.Entities {
-				if entity.Side == wonSideIDX {
-					continue
-				}
+		buyer.Inventory.Gold -= price
 
-				enemies = append(enemies, entity.Entity)
-			}
+		ingredient.Count = amount
 
-			for _, entity := range enemies {
-				if entity.GetFlags()&types.ENTITY_AUTO == 0 {
-					continue
-				}
+		buyer.Inventory.AddIngredient(&ingredient)
 
-				if entity.HasOnDefeat() {
-					for _, wonEntity := range wonEntities {
-						if wonEntity.GetFlags()&types.ENTITY_AUTO != 0 {
-							continue
-						}
+		w.UpdateQuests(buyer.GetUUID(), quest.OBJECTIVE_COLLECT, "", 0)
+	} else {
+		itemObj, exists := data.Items[stock.ItemUUID]
 
-						entity.(types.DefeatableEntity).OnDefeat(wonEntity.(types.PlayerEntity))
-					}
-				}
-			}
+		if !exists {
+			return player.Purchase{}, errors.New("ITEM_NOT_FOUND")
+		}
 
-			if fight.Meta.Tournament == nil {
-				overallXp := 0
-				overallGold := 0
-				lootedItems := make([]types.Loot, 0)
+		if !buyer.Inventory.CanAddItem(&itemObj, amount) {
+			return player.Purchase{}, errors.New("INVENTORY_FULL")
+		}
 
-				for _, entity := range fight.Entities {
-					if entity.Side == wonSideIDX {
-						continue
-					}
+		buyer.Inventory.Gold -= price
 
-					lootList := entity.Entity.GetLoot()
+		if itemObj.Stacks {
+			itemObj.Count = amount
 
-					for _, loot := range lootList {
-						switch loot.Type {
-						case types.LOOT_EXP:
-							overallXp += loot.Count
-						case types.LOOT_GOLD:
-							overallGold += loot.Count
-						case types.LOOT_ITEM:
-							lootedItems = append(lootedItems, loot)
-						}
-					}
-				}
+			buyer.AddItem(&itemObj)
+		} else {
+			for i := 0; i < amount; i++ {
+				itemCopy := itemObj
+				itemCopy.Count = 1
 
-				partyInfo := wonEntities[0].(*player.Player).Meta.Party
+				buyer.AddItem(&itemCopy)
+			}
+		}
+	}
 
-				unlockedFloors := w.GetUnlockedFloorCount()
+	stock.Take(amount)
 
-				for _, loot := range fight.AdditionalLoot {
-					for _, entity := range wonEntities {
-						if entity.GetUUID() == loot.Target {
-							player := w.Players[entity.GetUUID()]
-
-							switch loot.Value.Type {
-							case types.LOOT_EXP:
-								player.AddEXP(unlockedFloors, loot.Value.Count)
-								if _, ok := xpMap[entity.GetUUID()]; !ok {
-									xpMap[entity.GetUUID()] = loot.Value.Count
-								} else {
-									xpMap[entity.GetUUID()] += loot.Value.Count
-								}
-							case types.LOOT_GOLD:
-								player.AddEXP(unlockedFloors, loot.Value.Count)
-								if _, ok := xpMap[entity.GetUUID()]; !ok {
-									goldMap[entity.GetUUID()] = loot.Value.Count
-								} else {
-									goldMap[entity.GetUUID()] += loot.Value.Count
-								}
-							case types.LOOT_ITEM:
-								itemUuid := loot.Value.Meta.Uuid
-
-								if loot.Value.Meta.Type == types.ITEM_OTHER {
-									itemObj := data.Items[itemUuid]
-
-									itemObj.Count = loot.Value.Count
-
-									player.Inventory.Items = append(player.Inventory.Items, &itemObj)
-								} else {
-									ingredient := data.Ingredients[itemUuid]
-
-									ingredient.Count = loot.Value.Count
-
-									player.Inventory.AddIngredient(&ingredient)
-								}
-							}
-						}
-					}
-				}
+	purchase := player.Purchase{
+		Store:    store.Uuid,
+		Item:     stock.ItemUUID,
+		ItemType: stock.ItemType,
+		Count:    amount,
+		Price:    price,
+		Date:     w.Time.String(),
+	}
 
-				if partyInfo != nil {
-					partyData := w.Parties[partyInfo.UUID]
-					partyLeader := w.Players[partyData.Leader]
+	buyer.Meta.AddPurchase(purchase)
 
-					for _, member := range partyData.Players {
-						player := w.Players[member.PlayerUuid]
+	return purchase, nil
+}
 
-						player.AddEXP(unlockedFloors, overallXp/len(partyData.Players))
+// Store in player location that pays the most for item, nil if none buys it
+func (w *World) bestSellStock(player *player.Player, itemUuid uuid.UUID) *types.Stock {
+	var stock *types.Stock
 
-						if _, ok := xpMap[member.PlayerUuid]; !ok {
-							xpMap[member.PlayerUuid] = overallXp / len(partyData.Players)
-						} else {
-							xpMap[member.PlayerUuid] += overallXp / len(partyData.Players)
-						}
+	for _, store := range w.Stores {
+		if store.Location != player.Meta.Location {
+			continue
+		}
+
+		storeStock := store.FindStock(itemUuid)
+
+		if storeStock == nil {
+			continue
+		}
+
+		if stock == nil || storeStock.SellPrice() > stock.SellPrice() {
+			stock = storeStock
+		}
+	}
+
+	return stock
+}
+
+// Sells materials to the store in player location that pays the most, returns gold earned
+func (w *World) SellToStore(pUuid uuid.UUID, itemUuid uuid.UUID, amount int) (int, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	player := w.Players[pUuid]
+
+	if amount <= 0 {
+		return 0, errors.New("INVALID_AMOUNT")
+	}
+
+	if player.Meta.FightInstance != nil {
+		return 0, errors.New("IN_FIGHT")
+	}
+
+	stock := w.bestSellStock(player, itemUuid)
+
+	if stock == nil || stock.ItemType != types.ITEM_MATERIAL {
+		return 0, errors.New("NOT_BOUGHT")
+	}
+
+	ingredient, exists := player.Inventory.Ingredients[itemUuid]
+
+	if !exists || ingredient.Count < amount {
+		return 0, errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	player.Inventory.RemoveIngredients([]types.Ingredient{{UUID: itemUuid, Count: amount}})
+
+	gold := stock.SellPrice() * amount
+
+	stock.Return(amount)
+	player.AddGold(gold)
+
+	return gold, nil
+}
+
+// Sells items from stack at itemIdx, price depends on quality, enhancement and durability
+func (w *World) SellItemToStore(pUuid uuid.UUID, itemIdx int, amount int) (int, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	player := w.Players[pUuid]
+
+	if amount <= 0 {
+		return 0, errors.New("INVALID_AMOUNT")
+	}
+
+	if player.Meta.FightInstance != nil {
+		return 0, errors.New("IN_FIGHT")
+	}
+
+	if itemIdx < 0 || itemIdx >= len(player.Inventory.Items) {
+		return 0, errors.New("ITEM_NOT_FOUND")
+	}
+
+	item := player.Inventory.Items[itemIdx]
+
+	if item.Equipped {
+		return 0, errors.New("ITEM_EQUIPPED")
+	}
+
+	stock := w.bestSellStock(player, item.UUID)
+
+	if stock == nil || stock.ItemType == types.ITEM_MATERIAL {
+		return 0, errors.New("NOT_BOUGHT")
+	}
+
+	if item.Count < amount {
+		return 0, errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	gold := stock.ItemSellPrice(item) * amount
+
+	player.Inventory.TakeItemAt(itemIdx, amount)
+
+	stock.Return(amount)
+	player.AddGold(gold)
+
+	return gold, nil
+}
+
+// Caller has to hold StateLock
+func (w *World) giveAuctionItem(playerObj *player.Player, auctionObj *auction.Auction) {
+	if auctionObj.Instance != nil {
+		playerObj.AddItem(auctionObj.Instance)
+
+		return
+	}
+
+	w.giveLoot(playerObj, w.auctionLoot(auctionObj))
+}
+
+func (w *World) auctionLoot(auctionObj *auction.Auction) types.Loot {
+	return types.Loot{
+		Type:  types.LOOT_ITEM,
+		Count: auctionObj.Count,
+		Meta: &types.LootMeta{
+			Type: auctionObj.ItemType,
+			Uuid: auctionObj.Item,
+		},
+	}
+}
+
+func (w *World) inCity(player *player.Player) bool {
+	location := w.Floors[player.Meta.Location.Floor].FindLocation(player.Meta.Location.Location)
+
+	return location != nil && location.CityPart
+}
+
+// Caller has to hold StateLock
+func (w *World) checkAuction(seller *player.Player, count, startPrice, buyout, days int) error {
+	if !w.inCity(seller) {
+		return errors.New("NOT_IN_CITY")
+	}
+
+	if seller.Meta.FightInstance != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	if count <= 0 {
+		return errors.New("INVALID_AMOUNT")
+	}
+
+	if startPrice <= 0 || (buyout != 0 && buyout < startPrice) {
+		return errors.New("INVALID_PRICE")
+	}
+
+	if days <= 0 || days > auction.MaxDuration {
+		return errors.New("INVALID_DURATION")
+	}
+
+	if seller.Inventory.Gold < auction.ListingFee(startPrice) {
+		return errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	return nil
+}
+
+// Caller has to hold StateLock and take the item from seller first
+func (w *World) listAuction(seller *player.Player, auctionObj *auction.Auction, days int) {
+	seller.Inventory.Gold -= auction.ListingFee(auctionObj.StartPrice)
+
+	day := w.Time.DayNumber()
+
+	auctionObj.Uuid = uuid.New()
+	auctionObj.Seller = seller.GetUUID()
+	auctionObj.Created = day
+	auctionObj.Ends = day + days
+
+	w.Auctions[auctionObj.Uuid] = auctionObj
+}
+
+// Lists materials, items go through CreateItemAuction
+func (w *World) CreateAuction(pUuid, itemUuid uuid.UUID, count, startPrice, buyout, days int) (*auction.Auction, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	seller := w.Players[pUuid]
+
+	if err := w.checkAuction(seller, count, startPrice, buyout, days); err != nil {
+		return nil, err
+	}
+
+	ingredient, exists := seller.Inventory.Ingredients[itemUuid]
+
+	if !exists || ingredient.Count < count {
+		return nil, errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	seller.Inventory.RemoveIngredients([]types.Ingredient{{UUID: itemUuid, Count: count}})
+
+	auctionObj := &auction.Auction{
+		Item:       itemUuid,
+		ItemType:   types.ITEM_MATERIAL,
+		Count:      count,
+		StartPrice: startPrice,
+		Buyout:     buyout,
+	}
+
+	w.listAuction(seller, auctionObj, days)
+
+	return auctionObj, nil
+}
+
+// Item is picked by inventory index, the same instance is held in escrow
+func (w *World) CreateItemAuction(pUuid uuid.UUID, itemIdx int, count, startPrice, buyout, days int) (*auction.Auction, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	seller := w.Players[pUuid]
+
+	if err := w.checkAuction(seller, count, startPrice, buyout, days); err != nil {
+		return nil, err
+	}
+
+	if itemIdx < 0 || itemIdx >= len(seller.Inventory.Items) {
+		return nil, errors.New("ITEM_NOT_FOUND")
+	}
+
+	if seller.Inventory.Items[itemIdx].Equipped {
+		return nil, errors.New("ITEM_EQUIPPED")
+	}
+
+	if seller.Inventory.Items[itemIdx].Count < count {
+		return nil, errors.New("NOT_ENOUGH_ITEMS")
+	}
+
+	item := seller.Inventory.TakeItemAt(itemIdx, count)
+
+	auctionObj := &auction.Auction{
+		Item:       item.UUID,
+		ItemType:   types.ITEM_OTHER,
+		Count:      count,
+		Instance:   item,
+		StartPrice: startPrice,
+		Buyout:     buyout,
+	}
+
+	w.listAuction(seller, auctionObj, days)
+
+	return auctionObj, nil
+}
+
+// Returns true if bid bought out the auction
+func (w *World) BidAuction(pUuid, auctionUuid uuid.UUID, amount int) (bool, error) {
+	w.StateLock.Lock()
+
+	bidder := w.Players[pUuid]
+
+	auctionObj, exists := w.Auctions[auctionUuid]
+
+	if !exists {
+		w.StateLock.Unlock()
+		return false, errors.New("AUCTION_NOT_FOUND")
+	}
+
+	if !w.inCity(bidder) {
+		w.StateLock.Unlock()
+		return false, errors.New("NOT_IN_CITY")
+	}
+
+	if auctionObj.Seller == pUuid {
+		w.StateLock.Unlock()
+		return false, errors.New("OWN_AUCTION")
+	}
+
+	if auctionObj.Bidder == pUuid {
+		w.StateLock.Unlock()
+		return false, errors.New("ALREADY_HIGHEST")
+	}
+
+	boughtOut := auctionObj.Buyout != 0 && amount >= auctionObj.Buyout
+
+	if boughtOut {
+		amount = auctionObj.Buyout
+	}
+
+	if amount < auctionObj.MinBid() {
+		w.StateLock.Unlock()
+		return false, errors.New("BID_TOO_LOW")
+	}
+
+	if bidder.Inventory.Gold < amount {
+		w.StateLock.Unlock()
+		return false, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	bidder.Inventory.Gold -= amount
+
+	if auctionObj.HasBids() {
+		outbid := w.Players[auctionObj.Bidder]
+
+		outbid.AddGold(auctionObj.Bid)
+
+		w.BufferChannel <- types.DiscordMessageStruct{
+			ChannelID: outbid.Meta.UserID,
+			MessageContent: discord.NewMessageCreateBuilder().
+				SetContentf("Twoja oferta na aukcji %s została przebita (%d golda). Zwrócono %d golda", w.LootName(w.auctionLoot(auctionObj)), amount, auctionObj.Bid).
+				Build(),
+			DM: true,
+		}
+	}
+
+	auctionObj.Bid = amount
+	auctionObj.Bidder = pUuid
+
+	if boughtOut {
+		w.finishAuction(auctionUuid)
+	}
+
+	w.StateLock.Unlock()
+
+	return boughtOut, nil
+}
+
+func (w *World) CancelAuction(pUuid, auctionUuid uuid.UUID) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	auctionObj, exists := w.Auctions[auctionUuid]
+
+	if !exists {
+		return errors.New("AUCTION_NOT_FOUND")
+	}
+
+	if auctionObj.Seller != pUuid {
+		return errors.New("NOT_OWNER")
+	}
+
+	if auctionObj.HasBids() {
+		return errors.New("HAS_BIDS")
+	}
+
+	delete(w.Auctions, auctionUuid)
+
+	//Listing fee is not refunded
+	w.giveAuctionItem(w.Players[pUuid], auctionObj)
+
+	return nil
+}
+
+// Hands items and gold held in escrow to winner and seller
+func (w *World) FinishAuction(auctionUuid uuid.UUID) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	w.finishAuction(auctionUuid)
+}
+
+// Caller has to hold StateLock
+func (w *World) finishAuction(auctionUuid uuid.UUID) {
+	auctionObj, exists := w.Auctions[auctionUuid]
+
+	if !exists {
+		return
+	}
+
+	delete(w.Auctions, auctionUuid)
+
+	seller := w.Players[auctionObj.Seller]
+	itemName := w.LootName(w.auctionLoot(auctionObj))
+
+	if !auctionObj.HasBids() {
+		w.giveAuctionItem(seller, auctionObj)
+
+		w.BufferChannel <- types.DiscordMessageStruct{
+			ChannelID: seller.Meta.UserID,
+			MessageContent: discord.NewMessageCreateBuilder().
+				SetContentf("Aukcja %s zakończyła się bez ofert, przedmioty wróciły do ekwipunku", itemName).
+				Build(),
+			DM: true,
+		}
+
+		return
+	}
+
+	winner := w.Players[auctionObj.Bidder]
+
+	w.giveAuctionItem(winner, auctionObj)
+	seller.AddGold(auctionObj.Bid)
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: seller.Meta.UserID,
+		MessageContent: discord.NewMessageCreateBuilder().
+			SetContentf("Sprzedano %s graczowi %s za %d golda", itemName, winner.GetName(), auctionObj.Bid).
+			Build(),
+		DM: true,
+	}
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: winner.Meta.UserID,
+		MessageContent: discord.NewMessageCreateBuilder().
+			SetContentf("Wygrałeś aukcję %s za %d golda", itemName, auctionObj.Bid).
+			Build(),
+		DM: true,
+	}
+}
+
+func (w *World) CleanupAuctions() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	day := w.Time.DayNumber()
+
+	for auctionUuid, auctionObj := range w.Auctions {
+		if auctionObj.Expired(day) {
+			w.finishAuction(auctionUuid)
+		}
+	}
+}
+
+func (w *World) CraftRecipe(pUuid, recipeUuid uuid.UUID) (player.CraftResult, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	crafter := w.Players[pUuid]
+
+	recipe, exists := data.Recipes[recipeUuid]
+
+	if !exists {
+		return player.CraftResult{}, errors.New("RECIPE_NOT_FOUND")
+	}
+
+	if !crafter.Meta.Crafting.Knows(recipe) {
+		return player.CraftResult{}, errors.New("UNKNOWN_RECIPE")
+	}
+
+	if crafter.Meta.FightInstance != nil {
+		return player.CraftResult{}, errors.New("IN_FIGHT")
+	}
+
+	if recipe.Station.Location != "" && crafter.Meta.Location != recipe.Station {
+		return player.CraftResult{}, errors.New("WRONG_STATION")
+	}
+
+	if crafter.Meta.Crafting.Level < recipe.Level {
+		return player.CraftResult{}, errors.New("LEVEL_TOO_LOW")
+	}
+
+	if len(crafter.Inventory.MissingIngredients(recipe)) > 0 {
+		return player.CraftResult{}, errors.New("MISSING_INGREDIENT")
+	}
+
+	if crafter.Inventory.Gold < recipe.Cost {
+		return player.CraftResult{}, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	var product types.PlayerItem
+
+	if recipe.Product.Type != types.ITEM_MATERIAL {
+		product, exists = data.Items[recipe.Product.UUID]
+
+		if !exists {
+			return player.CraftResult{}, errors.New("RECIPE_NOT_FOUND")
+		}
+
+		if !crafter.Inventory.CanAddItem(&product, recipe.Product.Count) {
+			return player.CraftResult{}, errors.New("INVENTORY_FULL")
+		}
+	}
+
+	crafter.Inventory.Gold -= recipe.Cost
+	crafter.Inventory.ConsumeIngredients(recipe)
+
+	defer w.UpdateQuests(pUuid, quest.OBJECTIVE_COLLECT, "", 0)
+
+	result := player.CraftResult{
+		Success: utils.RandomNumber(1, 100) <= crafter.Meta.Crafting.SuccessChance(recipe),
+		Quality: types.QUALITY_NORMAL,
+		Exp:     recipe.Exp,
+	}
+
+	//Failed attempt still teaches something
+	if !result.Success {
+		result.Exp /= 2
+		result.LevelUp = crafter.Meta.Crafting.AddExp(result.Exp)
+
+		return result, nil
+	}
+
+	result.LevelUp = crafter.Meta.Crafting.AddExp(result.Exp)
+
+	w.UpdateQuests(pUuid, quest.OBJECTIVE_CRAFT, recipe.UUID.String(), 1)
+
+	if recipe.Product.Type == types.ITEM_MATERIAL {
+		ingredient := data.Ingredients[recipe.Product.UUID]
+		ingredient.Count = recipe.Product.Count
+
+		crafter.Inventory.AddIngredient(&ingredient)
+
+		return result, nil
+	}
+
+	result.Quality = crafter.Meta.Crafting.RollQuality(recipe)
+
+	product.Count = recipe.Product.Count
+	product.SetQuality(result.Quality)
+
+	crafter.AddItem(&product)
+
+	return result, nil
+}
+
+// Hidden recipes are discovered by holding all ingredients at recipe station
+func (w *World) DiscoverRecipes(pUuid uuid.UUID) []types.Recipe {
+	crafter := w.Players[pUuid]
+
+	discovered := make([]types.Recipe, 0)
+
+	for _, recipe := range data.Recipes {
+		if crafter.Meta.Crafting.Knows(recipe) {
+			continue
+		}
+
+		if recipe.Station.Location != "" && crafter.Meta.Location != recipe.Station {
+			continue
+		}
+
+		if len(crafter.Inventory.MissingIngredients(recipe)) > 0 {
+			continue
+		}
+
+		crafter.Meta.Crafting.Learn(recipe)
+
+		discovered = append(discovered, recipe)
+	}
+
+	return discovered
+}
+
+// Cheapest store in location offering a service, price is 0 for stores that don't offer it
+func (w *World) findService(location types.EntityLocation, price func(store *types.NPCStore) int) *types.NPCStore {
+	var cheapest *types.NPCStore
+
+	for _, store := range w.Stores {
+		if store.Location != location || price(store) == 0 {
+			continue
+		}
+
+		if cheapest == nil || price(store) < price(cheapest) {
+			cheapest = store
+		}
+	}
+
+	return cheapest
+}
+
+func blacksmithPrice(store *types.NPCStore) int {
+	return store.RepairPrice
+}
+
+func trainerPrice(store *types.NPCStore) int {
+	return store.RespecPrice
+}
+
+func (w *World) RepairItem(pUuid uuid.UUID, itemIdx int) (int, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	owner := w.Players[pUuid]
+
+	if owner.Meta.FightInstance != nil {
+		return 0, errors.New("IN_FIGHT")
+	}
+
+	blacksmith := w.findService(owner.Meta.Location, blacksmithPrice)
+
+	if blacksmith == nil {
+		return 0, errors.New("NO_BLACKSMITH")
+	}
+
+	if itemIdx < 0 || itemIdx >= len(owner.Inventory.Items) {
+		return 0, errors.New("ITEM_NOT_FOUND")
+	}
+
+	item := owner.Inventory.Items[itemIdx]
+
+	if item.MaxDurability == 0 || item.Durability == item.MaxDurability {
+		return 0, errors.New("NOT_DAMAGED")
+	}
+
+	cost := item.RepairCost(blacksmith.RepairPrice)
+
+	if owner.Inventory.Gold < cost {
+		return 0, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	owner.Inventory.Gold -= cost
+	owner.RepairItem(item)
+
+	return cost, nil
+}
+
+// Returns false if enhancement failed, materials are lost either way
+func (w *World) EnhanceItem(pUuid uuid.UUID, itemIdx int) (bool, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	owner := w.Players[pUuid]
+
+	if owner.Meta.FightInstance != nil {
+		return false, errors.New("IN_FIGHT")
+	}
+
+	if w.findService(owner.Meta.Location, blacksmithPrice) == nil {
+		return false, errors.New("NO_BLACKSMITH")
+	}
+
+	if itemIdx < 0 || itemIdx >= len(owner.Inventory.Items) {
+		return false, errors.New("ITEM_NOT_FOUND")
+	}
+
+	item := owner.Inventory.Items[itemIdx]
+
+	if item.Slot == types.SLOT_NONE {
+		return false, errors.New("NOT_ENHANCEABLE")
+	}
+
+	if item.Enhancement >= types.MAX_ENHANCEMENT {
+		return false, errors.New("MAX_ENHANCEMENT")
+	}
+
+	gold, stones := item.EnhancementCost()
+
+	if stone, exists := owner.Inventory.Ingredients[data.EnhancementStone]; !exists || stone.Count < stones {
+		return false, errors.New("MISSING_INGREDIENT")
+	}
+
+	if owner.Inventory.Gold < gold {
+		return false, errors.New("NOT_ENOUGH_GOLD")
+	}
+
+	owner.Inventory.Gold -= gold
+	owner.Inventory.RemoveIngredients([]types.Ingredient{{UUID: data.EnhancementStone, Count: stones}})
+
+	if utils.RandomNumber(1, 100) > item.EnhancementChance() {
+		return false, nil
+	}
+
+	item.SetEnhancement(item.Enhancement + 1)
+
+	return true, nil
+}
+
+func (w *World) RespecCost(pUuid uuid.UUID, fromLvl int) (int, error) {
+	owner := w.Players[pUuid]
+
+	trainer := w.findService(owner.Meta.Location, trainerPrice)
+
+	if trainer == nil {
+		return 0, errors.New("NO_TRAINER")
+	}
+
+	points := owner.RespecPoints(fromLvl)
+
+	if points == 0 {
+		return 0, errors.New("NOTHING_TO_RESET")
+	}
+
+	return points * trainer.RespecPrice, nil
+}
+
+// Refunds skills from given level up, paid with gold or a single scroll. Returns refunded points
+func (w *World) RespecSkills(pUuid uuid.UUID, fromLvl int, useScroll bool) (int, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	owner := w.Players[pUuid]
+
+	if owner.Meta.FightInstance != nil {
+		return 0, errors.New("IN_FIGHT")
+	}
+
+	cost, err := w.RespecCost(pUuid, fromLvl)
+
+	if err != nil {
+		return 0, err
+	}
+
+	if useScroll {
+		if scroll, exists := owner.Inventory.Ingredients[data.RespecScroll]; !exists || scroll.Count < 1 {
+			return 0, errors.New("MISSING_INGREDIENT")
+		}
+
+		owner.Inventory.RemoveIngredients([]types.Ingredient{{UUID: data.RespecScroll, Count: 1}})
+	} else {
+		if owner.Inventory.Gold < cost {
+			return 0, errors.New("NOT_ENOUGH_GOLD")
+		}
+
+		owner.Inventory.Gold -= cost
+	}
+
+	refunded := owner.ResetSkills(fromLvl)
+
+	if owner.Stats.HP > owner.GetStat(types.STAT_HP) {
+		owner.Stats.HP = owner.GetStat(types.STAT_HP)
+	}
+
+	if owner.Stats.CurrentMana > owner.GetStat(types.STAT_MANA) {
+		owner.Stats.CurrentMana = owner.GetStat(types.STAT_MANA)
+	}
+
+	return refunded, nil
+}
+
+func (w *World) GrantFury(pUuid uuid.UUID, furyUuid uuid.UUID) error {
+	owner := w.Players[pUuid]
+
+	definition, exists := data.Furies[furyUuid]
+
+	if !exists {
+		return errors.New("FURY_NOT_FOUND")
+	}
+
+	if owner.Meta.Fury != nil {
+		return errors.New("ALREADY_HAS_FURY")
+	}
+
+	owner.Meta.Fury = fury.New(definition, pUuid)
+	owner.Inventory.FurySkillsCD = make(map[uuid.UUID]int)
+
+	return nil
+}
+
+// Consumes item bound to a fury
+func (w *World) AwakenFury(pUuid uuid.UUID, itemIdx int) (*fury.Fury, error) {
+	owner := w.Players[pUuid]
+
+	if owner.Meta.FightInstance != nil {
+		return nil, errors.New("IN_FIGHT")
+	}
+
+	if itemIdx < 0 || itemIdx >= len(owner.Inventory.Items) {
+		return nil, errors.New("ITEM_NOT_FOUND")
+	}
+
+	item := owner.Inventory.Items[itemIdx]
+
+	if item.Fury == uuid.Nil {
+		return nil, errors.New("NOT_FURY_ITEM")
+	}
+
+	err := w.GrantFury(pUuid, item.Fury)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if item.Count > 1 {
+		item.Count--
+	} else {
+		owner.RemoveItem(itemIdx)
+	}
+
+	return owner.Meta.Fury, nil
+}
+
+func (w *World) GiveLoot(player *player.Player, loot types.Loot) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	w.giveLoot(player, loot)
+}
+
+// Caller has to hold StateLock
+func (w *World) giveLoot(player *player.Player, loot types.Loot) {
+	switch loot.Type {
+	case types.LOOT_EXP:
+		player.AddEXP(w.GetUnlockedFloorCount(), loot.Count)
+	case types.LOOT_GOLD:
+		player.AddGold(loot.Count)
+	case types.LOOT_FURY:
+		w.GrantFury(player.GetUUID(), loot.Meta.Uuid)
+	case types.LOOT_ITEM:
+		if loot.Meta.Type == types.ITEM_MATERIAL {
+			ingredient, ok := data.Ingredients[loot.Meta.Uuid]
+
+			if !ok {
+				return
+			}
+
+			ingredient.Count = loot.Count
+
+			player.Inventory.AddIngredient(&ingredient)
+
+			w.UpdateQuests(player.GetUUID(), quest.OBJECTIVE_COLLECT, "", 0)
+			w.progressBounties(player.GetUUID(), bounty.BOUNTY_GATHER, ingredient.UUID.String(), loot.Count)
+		} else {
+			itemObj, ok := data.Items[loot.Meta.Uuid]
+
+			if !ok {
+				return
+			}
+
+			itemObj.Count = loot.Count
+
+			player.AddItem(&itemObj)
+		}
+	}
+}
+
+func (w *World) LootName(loot types.Loot) string {
+	if loot.Type == types.LOOT_FURY {
+		return "Furia: " + data.GetFuryName(loot.Meta.Uuid)
+	}
+
+	return fmt.Sprintf("[%s] %s x%d", types.RarityToString[loot.Meta.Rarity], data.GetItemName(loot.Meta.Type, loot.Meta.Uuid), loot.Count)
+}
+
+// Hands out items according to party loot mode, need/greed items are resolved later
+func (w *World) DistributePartyLoot(partyUuid uuid.UUID, candidates []uuid.UUID, items []types.Loot, channelId string) map[uuid.UUID][]types.Loot {
+	given := make(map[uuid.UUID][]types.Loot)
+
+	partyData := w.Parties[partyUuid]
+
+	if len(candidates) == 0 || len(items) == 0 {
+		return given
+	}
+
+	for _, loot := range items {
+		var winner uuid.UUID
+		rollText := ""
+
+		switch partyData.LootMode {
+		case party.LootLeader:
+			winner = partyData.Leader
+
+			if !slices.Contains(candidates, winner) {
+				winner = candidates[0]
+			}
+		case party.LootRoundRobin:
+			winner = candidates[partyData.RoundRobin%len(candidates)]
+
+			partyData.RoundRobin = (partyData.RoundRobin + 1) % len(candidates)
+		case party.LootRandom:
+			bestRoll := 0
+
+			for _, candidate := range candidates {
+				roll := utils.RandomNumber(1, 100)
+
+				rollText += fmt.Sprintf("%s - %d\n", w.Players[candidate].GetName(), roll)
+
+				if roll > bestRoll {
+					bestRoll = roll
+					winner = candidate
+				}
+			}
+		case party.LootNeedGreed:
+			w.StartLootRoll(partyUuid, candidates, loot, channelId)
+			continue
+		}
+
+		w.GiveLoot(w.Players[winner], loot)
+
+		given[winner] = append(given[winner], loot)
+
+		w.BufferChannel <- types.DiscordMessageStruct{
+			ChannelID: channelId,
+			MessageContent: discord.NewMessageCreateBuilder().
+				AddEmbeds(
+					discord.NewEmbedBuilder().
+						SetTitle("Łup: "+w.LootName(loot)).
+						SetDescriptionf("%s%s otrzymuje przedmiot (%s)", rollText, w.Players[winner].GetName(), party.LootModeToString[partyData.LootMode]).
+						Build(),
+				).
+				Build(),
+		}
+	}
+
+	return given
+}
+
+func (w *World) StartLootRoll(partyUuid uuid.UUID, candidates []uuid.UUID, loot types.Loot, channelId string) {
+	roll := &party.LootRoll{
+		Uuid:       uuid.New(),
+		Party:      partyUuid,
+		Loot:       loot,
+		ChannelID:  channelId,
+		Candidates: candidates,
+		Choices:    make(map[uuid.UUID]party.LootChoice),
+		Expires:    time.Now().Add(party.LootRollDuration),
+	}
+
+	w.StateLock.Lock()
+
+	w.LootRolls[roll.Uuid] = roll
+
+	w.StateLock.Unlock()
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: channelId,
+		MessageContent: discord.NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.NewEmbedBuilder().
+					SetTitle("Łup: "+w.LootName(loot)).
+					SetDescription("Wybierzcie czy potrzebujecie przedmiotu, masz na to 2 minuty").
+					Build(),
+			).
+			AddActionRow(
+				discord.NewSuccessButton("Potrzeba", "loot/need|"+roll.Uuid.String()),
+				discord.NewPrimaryButton("Chciwość", "loot/greed|"+roll.Uuid.String()),
+				discord.NewSecondaryButton("Pas", "loot/pass|"+roll.Uuid.String()),
+			).
+			Build(),
+	}
+}
 
-						player.AddGold(overallGold / len(partyData.Players))
+func (w *World) ChooseLoot(rollUuid, playerUuid uuid.UUID, choice party.LootChoice) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
 
-						if _, ok := goldMap[member.PlayerUuid]; !ok {
-							goldMap[member.PlayerUuid] = overallGold / len(partyData.Players)
-						} else {
-							goldMap[member.PlayerUuid] += overallGold / len(partyData.Players)
+	roll, exists := w.LootRolls[rollUuid]
+
+	if !exists {
+		return errors.New("ROLL_NOT_FOUND")
+	}
+
+	if !roll.IsCandidate(playerUuid) {
+		return errors.New("NOT_CANDIDATE")
+	}
+
+	if _, chosen := roll.Choices[playerUuid]; chosen {
+		return errors.New("ALREADY_CHOSEN")
+	}
+
+	roll.Choices[playerUuid] = choice
+
+	if roll.AllChosen() {
+		w.finishLootRoll(rollUuid)
+	}
+
+	return nil
+}
+
+// Called on every clock tick, missing choices count as pass
+func (w *World) ExpireLootRolls() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	for rollUuid, roll := range w.LootRolls {
+		if roll.Expired() {
+			w.finishLootRoll(rollUuid)
+		}
+	}
+}
+
+// Caller has to hold StateLock
+func (w *World) finishLootRoll(rollUuid uuid.UUID) {
+	roll, exists := w.LootRolls[rollUuid]
+
+	if !exists {
+		return
+	}
+
+	delete(w.LootRolls, rollUuid)
+
+	winner, rolls, ok := roll.Resolve()
+
+	description := ""
+
+	for _, candidate := range roll.Candidates {
+		choice, chosen := roll.Choices[candidate]
+
+		if !chosen {
+			choice = party.LootPass
+		}
+
+		description += fmt.Sprintf("%s - %s", w.Players[candidate].GetName(), party.LootChoiceToString[choice])
+
+		if value, rolled := rolls[candidate]; rolled {
+			description += fmt.Sprintf(" (%d)", value)
+		}
+
+		description += "\n"
+	}
+
+	if ok {
+		w.giveLoot(w.Players[winner], roll.Loot)
+
+		description += fmt.Sprintf("\n%s otrzymuje przedmiot", w.Players[winner].GetName())
+	} else {
+		description += "\nWszyscy spasowali, przedmiot przepada"
+	}
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: roll.ChannelID,
+		MessageContent: discord.NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.NewEmbedBuilder().
+					SetTitle("Łup: " + w.LootName(roll.Loot)).
+					SetDescription(description).
+					Build(),
+			).
+			Build(),
+	}
+}
+
+func (w *World) ListenForFight(fightUuid uuid.UUID) {
+	fight, ok := w.Fights[fightUuid]
+
+	if !ok {
+		return
+	}
+
+	go fight.Run()
+
+	channelId := fight.GetChannelId()
+
+	for {
+		eventData, ok := <-fight.ExternalChannel
+
+		if !ok {
+			w.DeregisterFight(fightUuid)
+			break
+		}
+
+		switch eventData.GetEvent() {
+		case battle.MSG_FIGHT_END:
+			if fight.Meta.Event != nil {
+				w.FinishEvent(fight.Meta.Event.Event, fight)
+				w.DeregisterFight(fightUuid)
+
+				return
+			}
+
+			//HP is restored before players leave the fight, clock kills anyone outside of one with no HP
+			if fight.Meta.Duel != nil {
+				w.FinishDuel(fight.Meta.Duel.Challenge, fight)
+				w.DeregisterFight(fightUuid)
+
+				return
+			}
+
+			//All sides are players, there is no loot to hand out. Same order as duels, HP first
+			if fight.Meta.TeamBattle != nil {
+				w.FinishTeamBattle(fight.Meta.TeamBattle.Challenge, fight)
+				w.DeregisterFight(fightUuid)
+
+				return
+			}
+
+			if eventData.GetData().(bool) {
+				w.BufferChannel <- types.DiscordMessageStruct{
+					ChannelID: channelId,
+					MessageContent: discord.
+						NewMessageCreateBuilder().
+						AddEmbeds(
+							discord.
+								NewEmbedBuilder().
+								SetTitle("Koniec walki!").
+								SetDescriptionf("Wszyscy gracze uciekli z walki!").
+								Build(),
+						).
+						Build(),
+				}
+
+				w.DeregisterFight(fightUuid)
+
+				if fight.Meta.Dungeon != nil {
+					w.FinishDungeonFight(fight.Meta.Dungeon.Run, fight, false)
+				}
+
+				return
+			}
+
+			wonSideIDX := fight.SidesLeft()[0]
+			wonEntities := fight.FromSide(wonSideIDX)
+
+			allAuto := true
+
+			for _, entity := range wonEntities {
+				if entity.GetFlags()&types.ENTITY_AUTO != types.ENTITY_AUTO {
+					allAuto = false
+					break
+				}
+			}
+
+			if allAuto {
+				w.DeregisterFight(fightUuid)
+
+				wonSideText := ""
+
+				for _, entity := range wonEntities {
+					wonSideText += fmt.Sprintf("%v\n", entity.GetName())
+				}
+
+				wonSideText = wonSideText[:len(wonSideText)-1]
+
+				w.BufferChannel <- types.DiscordMessageStruct{
+					ChannelID: channelId,
+					MessageContent: discord.
+						NewMessageCreateBuilder().
+						AddEmbeds(
+							discord.
+								NewEmbedBuilder().
+								SetTitle("Koniec walki!").
+								SetDescriptionf("Wygrali:\n" + wonSideText).
+								Build(),
+						).
+						Build(),
+				}
+
+				if fight.Meta.Dungeon != nil {
+					w.FinishDungeonFight(fight.Meta.Dungeon.Run, fight, false)
+
+					return
+				}
+
+				break
+			}
+
+			enemies := make([]types.Entity, 0)
+
+			xpMap := make(map[uuid.UUID]int)
+			goldMap := make(map[uuid.UUID]int)
+
+			for _, entity := range fight.Entities {
+				if entity.Side == wonSideIDX {
+					continue
+				}
+
+				enemies = append(enemies, entity.Entity)
+			}
+
+			for _, entity := range enemies {
+				if entity.GetFlags()&types.ENTITY_AUTO == 0 {
+					continue
+				}
+
+				if entity.HasOnDefeat() {
+					for _, wonEntity := range wonEntities {
+						if wonEntity.GetFlags()&types.ENTITY_AUTO != 0 {
+							continue
 						}
+
+						entity.(types.DefeatableEntity).OnDefeat(wonEntity.(types.PlayerEntity))
 					}
+				}
+			}
 
-					for _, loot := range lootedItems {
-						itemUuid := loot.Meta.Uuid
+			itemsMap := make(map[uuid.UUID][]types.Loot)
 
-						if loot.Meta.Type == types.ITEM_OTHER {
-							itemObj := data.Items[itemUuid]
+			grantLoot := func(playerUuid uuid.UUID, loot types.Loot) {
+				w.GiveLoot(w.Players[playerUuid], loot)
 
-							itemObj.Count = loot.Count
+				switch loot.Type {
+				case types.LOOT_EXP:
+					xpMap[playerUuid] += loot.Count
+				case types.LOOT_GOLD:
+					goldMap[playerUuid] += loot.Count
+				case types.LOOT_ITEM, types.LOOT_FURY:
+					itemsMap[playerUuid] = append(itemsMap[playerUuid], loot)
+				}
+			}
 
-							partyLeader.Inventory.Items = append(partyLeader.Inventory.Items, &itemObj)
-						} else {
-							ingredient := data.Ingredients[itemUuid]
+			if fight.Meta.Tournament == nil {
+				overallXp := 0
+				overallGold := 0
+				lootedItems := make([]types.Loot, 0)
+				//Every winner gets a chance to awaken dropped fury
+				lootedFuries := make([]types.Loot, 0)
+
+				for _, entity := range fight.Entities {
+					if entity.Side == wonSideIDX {
+						continue
+					}
+
+					lootList := entity.Entity.GetLoot()
+
+					for _, loot := range lootList {
+						switch loot.Type {
+						case types.LOOT_EXP:
+							overallXp += loot.Count
+						case types.LOOT_GOLD:
+							overallGold += loot.Count
+						case types.LOOT_ITEM:
+							lootedItems = append(lootedItems, loot)
+						case types.LOOT_FURY:
+							lootedFuries = append(lootedFuries, loot)
+						}
+					}
+				}
+
+				var partyInfo *player.PartialParty
+
+				for _, entity := range wonEntities {
+					if entity.GetFlags()&types.ENTITY_AUTO == 0 {
+						partyInfo = entity.(*player.Player).Meta.Party
+						break
+					}
+				}
 
-							ingredient.Count = loot.Count
+				for _, loot := range lootedFuries {
+					for _, entity := range wonEntities {
+						if entity.GetFlags()&types.ENTITY_AUTO != 0 {
+							continue
+						}
 
-							partyLeader.Inventory.AddIngredient(&ingredient)
+						if w.Players[entity.GetUUID()].Meta.Fury == nil {
+							grantLoot(entity.GetUUID(), loot)
 						}
 					}
-				} else {
+				}
+
+				for _, loot := range fight.AdditionalLoot {
 					for _, entity := range wonEntities {
-						entityUuid := entity.GetUUID()
+						if entity.GetUUID() == loot.Target {
+							grantLoot(entity.GetUUID(), loot.Value)
+						}
+					}
+				}
 
-						if entity.GetFlags()&types.ENTITY_AUTO != 0 {
+				for _, entity := range fight.Entities {
+					if entity.Side == wonSideIDX {
+						continue
+					}
+
+					mob, ok := entity.Entity.(*mobs.MobEntity)
+
+					if !ok {
+						continue
+					}
+
+					for _, wonEntity := range wonEntities {
+						if wonEntity.GetFlags()&types.ENTITY_AUTO != 0 {
 							continue
 						}
 
-						player := w.Players[entityUuid]
+						player := w.Players[wonEntity.GetUUID()]
 
-						player.AddEXP(unlockedFloors, overallXp)
+						if player.Meta.Kills == nil {
+							player.Meta.Kills = make(map[string]int)
+						}
 
-						if _, ok := xpMap[entityUuid]; !ok {
-							xpMap[entityUuid] = overallXp
-						} else {
-							xpMap[entityUuid] += overallXp
+						if player.Meta.Kills[mob.Id] == 0 {
+							for _, loot := range mob.GetFirstKillLoot() {
+								grantLoot(player.GetUUID(), loot)
+							}
 						}
 
-						player.AddGold(overallGold)
+						player.Meta.Kills[mob.Id]++
 
-						if _, ok := goldMap[entityUuid]; !ok {
-							goldMap[entityUuid] = overallGold
-						} else {
-							goldMap[entityUuid] += overallGold
+						w.UpdateQuests(player.GetUUID(), quest.OBJECTIVE_KILL, mob.Id, 1)
+						w.ProgressBounties(player.GetUUID(), bounty.BOUNTY_KILL, mob.Id, 1)
+					}
+				}
+
+				if partyInfo != nil {
+					//Runaways are already removed from fight and summons don't take loot
+					candidates := make([]uuid.UUID, 0)
+
+					for _, entity := range wonEntities {
+						if entity.GetFlags()&types.ENTITY_AUTO != 0 {
+							continue
 						}
 
-						for _, loot := range lootedItems {
-							itemUuid := loot.Meta.Uuid
+						candidates = append(candidates, entity.GetUUID())
+					}
 
-							if loot.Meta.Type == types.ITEM_OTHER {
-								itemObj := data.Items[itemUuid]
+					for _, member := range candidates {
+						grantLoot(member, types.Loot{Type: types.LOOT_EXP, Count: overallXp / len(candidates)})
+						grantLoot(member, types.Loot{Type: types.LOOT_GOLD, Count: overallGold / len(candidates)})
+					}
 
-								itemObj.Count = loot.Count
+					for winner, lootList := range w.DistributePartyLoot(partyInfo.UUID, candidates, lootedItems, channelId) {
+						itemsMap[winner] = append(itemsMap[winner], lootList...)
+					}
+				} else {
+					for _, entity := range wonEntities {
+						entityUuid := entity.GetUUID()
 
-								player.Inventory.Items = append(player.Inventory.Items, &itemObj)
-							} else {
-								ingredient := data.Ingredients[itemUuid]
+						if entity.GetFlags()&types.ENTITY_AUTO != 0 {
+							continue
+						}
 
-								ingredient.Count = loot.Count
+						grantLoot(entityUuid, types.Loot{Type: types.LOOT_EXP, Count: overallXp})
+						grantLoot(entityUuid, types.Loot{Type: types.LOOT_GOLD, Count: overallGold})
 
-								player.Inventory.AddIngredient(&ingredient)
-							}
+						for _, loot := range lootedItems {
+							grantLoot(entityUuid, loot)
 						}
 					}
 				}
@@ -804,6 +2193,10 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 
 			wonSideText = wonSideText[:len(wonSideText)-1]
 
+			for playerUuid, exp := range xpMap {
+				w.AddGuildExp(playerUuid, exp)
+			}
+
 			lootSummaryText := ""
 
 			for _, entity := range wonEntities {
@@ -811,19 +2204,11 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 					continue
 				}
 
-				xpGotten, exists := xpMap[entity.GetUUID()]
+				lootSummaryText += fmt.Sprintf("%v - XP: %d, Złoto: %d\n", entity.GetName(), xpMap[entity.GetUUID()], goldMap[entity.GetUUID()])
 
-				if !exists {
-					xpGotten = 0
+				for _, loot := range itemsMap[entity.GetUUID()] {
+					lootSummaryText += fmt.Sprintf("- %s\n", w.LootName(loot))
 				}
-
-				goldGotten, exists := goldMap[entity.GetUUID()]
-
-				if !exists {
-					goldGotten = 0
-				}
-
-				lootSummaryText += fmt.Sprintf("%v - XP: %d, Złoto: %d\n", entity.GetName(), xpGotten, goldGotten)
 			}
 
 			lootSummaryText = lootSummaryText[:len(lootSummaryText)-1]
@@ -844,9 +2229,24 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 			}
 
 			if fight.Meta.Tournament != nil {
+				w.UpdateQuests(wonEntities[0].GetUUID(), quest.OBJECTIVE_TOURNAMENT, "", 1)
+
 				w.Tournaments[fight.Meta.Tournament.Tournament].ExternalChannel <- tournament.MatchFinishedData{Winner: wonEntities[0].GetUUID()}
 			}
+
+			//Next room can't start before players are released from this fight
+			if fight.Meta.Dungeon != nil {
+				w.DeregisterFight(fightUuid)
+				w.FinishDungeonFight(fight.Meta.Dungeon.Run, fight, true)
+
+				return
+			}
 		case battle.MSG_FIGHT_START:
+			//Event fights start empty, announced by StartEvent
+			if fight.Meta.Event != nil {
+				break
+			}
+
 			oneSide := fight.FromSide(0)
 			otherSide := fight.FromSide(1)
 
@@ -895,7 +2295,7 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 
 			canUseActionWhileCC := false
 
-			for _, item := range player.Inventory.Items {
+			for _, item := range player.Inventory.ActiveItems() {
 				for _, effect := range item.Effects {
 					effectTrigger := effect.GetTrigger()
 
@@ -969,7 +2369,9 @@ func (w *World) ListenForFight(fightUuid uuid.UUID) {
 
 			if player.Meta.Fury != nil {
 				for _, skill := range player.Meta.Fury.GetSkills() {
-					player.CanUseSkill(skill)
+					if player.CanUseSkill(skill) {
+						filteredSkillsCount++
+					}
 				}
 			}
 
@@ -1053,7 +2455,7 @@ func (w *World) DeregisterFight(uuid uuid.UUID) {
 
 	for _, entity := range tmp.Entities {
 		if entity.Entity.GetFlags()&types.ENTITY_AUTO == 0 {
-			entity.Entity.(*player.Player).Meta.FightInstance = nil
+			entity.Entity.(*player.Player).ClearFight()
 		} else {
 			delete(w.Entities, entity.Entity.GetUUID())
 		}
@@ -1540,16 +2942,18 @@ func (w *World) Serialize() map[string]interface{} {
 
 		for _, stock := range store.Stock {
 			stocks = append(stocks, map[string]interface{}{
-				"type":  stock.ItemType,
-				"uuid":  stock.ItemUUID,
-				"price": stock.Price,
+				"type":     stock.ItemType,
+				"uuid":     stock.ItemUUID,
+				"price":    stock.Price,
+				"quantity": stock.Quantity,
 			})
 		}
 
 		storeData = append(storeData, map[string]interface{}{
-			"uuid":  store.Uuid,
-			"name":  store.Name,
-			"stock": stocks,
+			"uuid":          store.Uuid,
+			"name":          store.Name,
+			"stock":         stocks,
+			"last_delivery": store.LastDelivery,
 		})
 	}
 
@@ -1565,9 +2969,94 @@ func (w *World) Serialize() map[string]interface{} {
 		partyData[key] = party.Serialize()
 	}
 
+	inviteData := make([]map[string]interface{}, 0)
+
+	for _, invite := range w.PartyInvites {
+		inviteData = append(inviteData, invite.Serialize())
+	}
+
+	listingData := make([]map[string]interface{}, 0)
+
+	for _, listing := range w.PartyListings {
+		listingData = append(listingData, listing.Serialize())
+	}
+
+	auctionData := make([]map[string]interface{}, 0)
+
+	for _, auctionObj := range w.Auctions {
+		auctionData = append(auctionData, auctionObj.Serialize())
+	}
+
+	droppedData := make([]map[string]interface{}, 0)
+
+	for _, dropped := range w.DroppedItems {
+		droppedData = append(droppedData, dropped.Serialize())
+	}
+
+	bountyData := make([]map[string]interface{}, 0)
+
+	for _, board := range w.Bounties {
+		bountyData = append(bountyData, board.Serialize())
+	}
+
+	fallenData := make([]map[string]interface{}, 0)
+
+	for _, fallen := range w.Fallen {
+		fallenData = append(fallenData, fallen.Serialize())
+	}
+
+	recordData := make(map[string]interface{})
+
+	for dungeonId, records := range w.DungeonRecords {
+		serialized := make([]map[string]interface{}, 0)
+
+		for _, record := range records {
+			serialized = append(serialized, record.Serialize())
+		}
+
+		recordData[dungeonId] = serialized
+	}
+
+	guildData := make([]map[string]interface{}, 0)
+
+	for _, guildObj := range w.Guilds {
+		guildData = append(guildData, guildObj.Serialize())
+	}
+
+	teamRatingData := make(map[string]interface{})
+
+	for key, rating := range w.TeamRatings {
+		teamRatingData[key] = rating.Serialize()
+	}
+
+	teamResultData := make([]map[string]interface{}, 0)
+
+	for _, result := range w.TeamResults {
+		teamResultData = append(teamResultData, result.Serialize())
+	}
+
+	scheduledData := make([]map[string]interface{}, 0)
+
+	for _, scheduled := range w.ScheduledEvents {
+		scheduledData = append(scheduledData, scheduled.Serialize())
+	}
+
 	return map[string]interface{}{
-		"players":     playerData,
-		"parties":     partyData,
+		"players":        playerData,
+		"parties":        partyData,
+		"party_invites":  inviteData,
+		"party_listings": listingData,
+		"auctions":       auctionData,
+		"dropped_items":  droppedData,
+		"fallen":         fallenData,
+		"bounties":       bountyData,
+		"events":         scheduledData,
+		"dungeons":       recordData,
+		"guilds":         guildData,
+		"team_pvp": map[string]interface{}{
+			"ratings": teamRatingData,
+			"results": teamResultData,
+		},
 		"stores":      storeData,
 		"time":        w.Time.Serialize(),
 		"tournaments": tournamentData,
@@ -1706,6 +3195,148 @@ func (w *World) LoadBackup() {
 		w.Parties[uuid.MustParse(key)] = deserializedParty
 	}
 
+	if rawInvites, exists := backupData["party_invites"].([]interface{}); exists {
+		for _, inviteData := range rawInvites {
+			invite := party.DeserializeInvite(inviteData.(map[string]interface{}))
+
+			if _, partyExists := w.Parties[invite.Party]; !partyExists || invite.Expired() {
+				continue
+			}
+
+			w.PartyInvites[invite.Uuid] = invite
+		}
+	}
+
+	if rawListings, exists := backupData["party_listings"].([]interface{}); exists {
+		for _, listingData := range rawListings {
+			listing := party.DeserializeListing(listingData.(map[string]interface{}))
+
+			if _, partyExists := w.Parties[listing.Party]; !partyExists || listing.Expired() {
+				continue
+			}
+
+			w.PartyListings[listing.Uuid] = listing
+		}
+	}
+
+	if rawAuctions, exists := backupData["auctions"].([]interface{}); exists {
+		for _, auctionData := range rawAuctions {
+			auctionObj := auction.Deserialize(auctionData.(map[string]interface{}))
+
+			w.Auctions[auctionObj.Uuid] = auctionObj
+		}
+	}
+
+	if rawDropped, exists := backupData["dropped_items"].([]interface{}); exists {
+		for _, droppedData := range rawDropped {
+			dropped := death.DeserializeDroppedItem(droppedData.(map[string]interface{}))
+
+			if dropped == nil {
+				continue
+			}
+
+			w.DroppedItems[dropped.Uuid] = dropped
+		}
+	}
+
+	if rawBoards, exists := backupData["bounties"].([]interface{}); exists {
+		for _, boardData := range rawBoards {
+			board := bounty.DeserializeBoard(boardData.(map[string]interface{}))
+
+			w.Bounties[board.Floor] = board
+		}
+	}
+
+	if rawScheduled, exists := backupData["events"].([]interface{}); exists {
+		for _, scheduledData := range rawScheduled {
+			w.ScheduledEvents = append(w.ScheduledEvents, event.DeserializeScheduled(scheduledData.(map[string]interface{})))
+		}
+	}
+
+	if rawRecords, exists := backupData["dungeons"].(map[string]interface{}); exists {
+		for dungeonId, records := range rawRecords {
+			for _, recordData := range records.([]interface{}) {
+				w.DungeonRecords[dungeonId] = append(w.DungeonRecords[dungeonId], dungeon.DeserializeRecord(recordData.(map[string]interface{})))
+			}
+		}
+	}
+
+	if rawGuilds, exists := backupData["guilds"].([]interface{}); exists {
+		for _, rawGuild := range rawGuilds {
+			guildObj := guild.Deserialize(rawGuild.(map[string]interface{}))
+
+			w.Guilds[guildObj.Uuid] = guildObj
+
+			//Characters removed by permadeath before it left their guild
+			for memberUuid := range guildObj.Members {
+				if _, exists := w.Players[memberUuid]; !exists {
+					w.removeGuildMember(guildObj, memberUuid)
+				}
+			}
+
+			w.syncGuildMeta(guildObj)
+		}
+	}
+
+	if rawTeamPvP, exists := backupData["team_pvp"].(map[string]interface{}); exists {
+		for key, ratingData := range rawTeamPvP["ratings"].(map[string]interface{}) {
+			w.TeamRatings[key] = duel.DeserializeTeamRating(ratingData.(map[string]interface{}))
+		}
+
+		for _, resultData := range rawTeamPvP["results"].([]interface{}) {
+			w.TeamResults = append(w.TeamResults, duel.DeserializeTeamResult(resultData.(map[string]interface{})))
+		}
+	}
+
+	if rawFallen, exists := backupData["fallen"].([]interface{}); exists {
+		for _, fallenData := range rawFallen {
+			w.Fallen = append(w.Fallen, death.DeserializeFallen(fallenData.(map[string]interface{})))
+		}
+	}
+
+	if rawStores, exists := backupData["stores"].([]interface{}); exists {
+		for _, rawStore := range rawStores {
+			storeData := rawStore.(map[string]interface{})
+
+			store, storeExists := w.Stores[uuid.MustParse(storeData["uuid"].(string))]
+
+			//Warrior and mage shops used to share a uuid, warrior was moved to its own
+			if name, ok := storeData["name"].(string); ok && (!storeExists || store.Name != name) {
+				store, storeExists = w.findStoreByName(name)
+			}
+
+			if !storeExists {
+				continue
+			}
+
+			if lastDelivery, ok := storeData["last_delivery"]; ok {
+				store.LastDelivery = int(lastDelivery.(float64))
+			}
+
+			for _, rawStock := range storeData["stock"].([]interface{}) {
+				stockData := rawStock.(map[string]interface{})
+
+				stock := store.FindStock(uuid.MustParse(stockData["uuid"].(string)))
+
+				//Removed from game data
+				if stock == nil {
+					continue
+				}
+
+				stock.Price = int(stockData["price"].(float64))
+
+				if quantity, ok := stockData["quantity"]; ok && stock.MaxQuantity != -1 {
+					stock.Quantity = int(quantity.(float64))
+
+					//Limit changed in game data
+					if stock.Quantity == -1 || stock.Quantity > stock.MaxQuantity {
+						stock.Quantity = stock.MaxQuantity
+					}
+				}
+			}
+		}
+	}
+
 	for _, tData := range backupData["tournaments"].([]interface{}) {
 		tempData := tData.(map[string]interface{})
 		parsedData := tournament.Deserialize(tempData)
@@ -1741,3 +3372,279 @@ func (w *World) RegisterParty(party party.Party) {
 
 	w.Parties[partyUuid] = &party
 }
+
+// Caller has to hold StateLock
+func (w *World) addPartyMember(partyUuid, playerUuid uuid.UUID, role party.PartyRole) error {
+	partyData, exists := w.Parties[partyUuid]
+
+	if !exists {
+		return errors.New("PARTY_NOT_FOUND")
+	}
+
+	playerData := w.Players[playerUuid]
+
+	if playerData.Meta.Party != nil {
+		return errors.New("ALREADY_IN_PARTY")
+	}
+
+	if len(partyData.Players) >= party.MaxMembers {
+		return errors.New("PARTY_FULL")
+	}
+
+	partyData.Players = append(partyData.Players, &party.PartyEntry{
+		PlayerUuid: playerUuid,
+		Role:       role,
+	})
+
+	playerData.Meta.Party = &player.PartialParty{
+		UUID: partyUuid,
+		Role: role,
+	}
+
+	for _, member := range partyData.Players {
+		w.Players[member.PlayerUuid].Meta.Party.MembersCount = len(partyData.Players)
+	}
+
+	if len(partyData.Players) >= party.MaxMembers {
+		w.removePartyListing(partyUuid)
+	}
+
+	return nil
+}
+
+func (w *World) CreatePartyInvite(partyUuid, playerUuid uuid.UUID) *party.Invite {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	return w.createPartyInvite(partyUuid, playerUuid)
+}
+
+// Caller has to hold StateLock
+func (w *World) createPartyInvite(partyUuid, playerUuid uuid.UUID) *party.Invite {
+	invite := &party.Invite{
+		Uuid:    uuid.New(),
+		Party:   partyUuid,
+		Player:  playerUuid,
+		Role:    party.None,
+		Expires: time.Now().Add(party.InviteDuration),
+	}
+
+	w.PartyInvites[invite.Uuid] = invite
+
+	return invite
+}
+
+// Accepting party is the invited player for invites and party leader for applications
+func (w *World) AcceptPartyInvite(inviteUuid, acceptingPlayer uuid.UUID) (*party.Invite, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	invite, exists := w.PartyInvites[inviteUuid]
+
+	if !exists {
+		return nil, errors.New("INVITE_NOT_FOUND")
+	}
+
+	if invite.Expired() {
+		delete(w.PartyInvites, inviteUuid)
+
+		return nil, errors.New("INVITE_EXPIRED")
+	}
+
+	partyData, partyExists := w.Parties[invite.Party]
+
+	if !partyExists {
+		delete(w.PartyInvites, inviteUuid)
+
+		return nil, errors.New("PARTY_NOT_FOUND")
+	}
+
+	if (invite.Application && acceptingPlayer != partyData.Leader) || (!invite.Application && acceptingPlayer != invite.Player) {
+		return nil, errors.New("NOT_ALLOWED")
+	}
+
+	err := w.addPartyMember(invite.Party, invite.Player, invite.Role)
+
+	if err != nil {
+		return nil, err
+	}
+
+	delete(w.PartyInvites, inviteUuid)
+
+	return invite, nil
+}
+
+func (w *World) RejectPartyInvite(inviteUuid, rejectingPlayer uuid.UUID) (*party.Invite, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	invite, exists := w.PartyInvites[inviteUuid]
+
+	if !exists {
+		return nil, errors.New("INVITE_NOT_FOUND")
+	}
+
+	if partyData, partyExists := w.Parties[invite.Party]; partyExists {
+		if (invite.Application && rejectingPlayer != partyData.Leader) || (!invite.Application && rejectingPlayer != invite.Player) {
+			return nil, errors.New("NOT_ALLOWED")
+		}
+	}
+
+	delete(w.PartyInvites, inviteUuid)
+
+	return invite, nil
+}
+
+func (w *World) CreatePartyListing(leaderUuid uuid.UUID, locationName string, roles []party.PartyRole, minLevel int) (*party.Listing, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	leader := w.Players[leaderUuid]
+
+	if locationName == "" {
+		locationName = leader.Meta.Location.Location
+	}
+
+	floor, exists := w.Floors[leader.Meta.Location.Floor]
+
+	if !exists {
+		return nil, errors.New("LOCATION_NOT_FOUND")
+	}
+
+	if locationData := floor.FindLocation(locationName); locationData == nil || !locationData.Unlocked {
+		return nil, errors.New("LOCATION_NOT_FOUND")
+	}
+
+	if leader.Meta.Party != nil {
+		partyData := w.Parties[leader.Meta.Party.UUID]
+
+		if partyData.Leader != leaderUuid {
+			return nil, errors.New("NOT_LEADER")
+		}
+
+		if len(partyData.Players) >= party.MaxMembers {
+			return nil, errors.New("PARTY_FULL")
+		}
+	} else {
+		//Solo player gets a party only once the listing is sure to be created
+		w.RegisterParty(party.Party{
+			Leader: leaderUuid,
+			Players: []*party.PartyEntry{
+				{
+					PlayerUuid: leaderUuid,
+					Role:       party.None,
+				},
+			},
+		})
+	}
+
+	partyUuid := leader.Meta.Party.UUID
+
+	w.removePartyListing(partyUuid)
+
+	listing := &party.Listing{
+		Uuid:     uuid.New(),
+		Party:    partyUuid,
+		Floor:    leader.Meta.Location.Floor,
+		Location: locationName,
+		Roles:    roles,
+		MinLevel: minLevel,
+		Expires:  time.Now().Add(party.ListingDuration),
+	}
+
+	w.PartyListings[listing.Uuid] = listing
+
+	return listing, nil
+}
+
+func (w *World) RemovePartyListing(partyUuid uuid.UUID) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	w.removePartyListing(partyUuid)
+}
+
+// Caller has to hold StateLock
+func (w *World) removePartyListing(partyUuid uuid.UUID) {
+	for listingUuid, listing := range w.PartyListings {
+		if listing.Party == partyUuid {
+			delete(w.PartyListings, listingUuid)
+		}
+	}
+}
+
+func (w *World) ApplyToPartyListing(listingUuid, playerUuid uuid.UUID, role party.PartyRole) (*party.Invite, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	listing, exists := w.PartyListings[listingUuid]
+
+	if !exists || listing.Expired() {
+		return nil, errors.New("LISTING_NOT_FOUND")
+	}
+
+	playerData := w.Players[playerUuid]
+
+	if playerData.Meta.Party != nil {
+		return nil, errors.New("ALREADY_IN_PARTY")
+	}
+
+	if playerData.XP.Level < listing.MinLevel {
+		return nil, errors.New("LEVEL_TOO_LOW")
+	}
+
+	if !listing.WantsRole(role) {
+		return nil, errors.New("ROLE_NOT_WANTED")
+	}
+
+	if len(w.Parties[listing.Party].Players) >= party.MaxMembers {
+		return nil, errors.New("PARTY_FULL")
+	}
+
+	for _, invite := range w.PartyInvites {
+		if invite.Application && invite.Listing == listingUuid && invite.Player == playerUuid && !invite.Expired() {
+			return nil, errors.New("ALREADY_APPLIED")
+		}
+	}
+
+	invite := w.createPartyInvite(listing.Party, playerUuid)
+
+	invite.Application = true
+	invite.Listing = listingUuid
+	invite.Role = role
+
+	return invite, nil
+}
+
+// Listings that can still be applied to
+func (w *World) ActivePartyListings() []*party.Listing {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	listings := make([]*party.Listing, 0)
+
+	for _, listing := range w.PartyListings {
+		if !listing.Expired() {
+			listings = append(listings, listing)
+		}
+	}
+
+	return listings
+}
+
+func (w *World) CleanupPartyFinder() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	for inviteUuid, invite := range w.PartyInvites {
+		if _, partyExists := w.Parties[invite.Party]; !partyExists || invite.Expired() {
+			delete(w.PartyInvites, inviteUuid)
+		}
+	}
+
+	for listingUuid, listing := range w.PartyListings {
+		if _, partyExists := w.Parties[listing.Party]; !partyExists || listing.Expired() {
+			delete(w.PartyListings, listingUuid)
+		}
+	}
+}
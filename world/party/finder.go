@@ -0,0 +1,106 @@
+package party
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const MaxMembers = 6
+const InviteDuration = 15 * time.Minute
+const ListingDuration = 2 * time.Hour
+
+// Invitation sent by leader or application sent through party finder
+type Invite struct {
+	Uuid   uuid.UUID
+	Party  uuid.UUID
+	Player uuid.UUID
+	//Application needs to be accepted by leader, invite by invited player
+	Application bool
+	Listing     uuid.UUID
+	Role        PartyRole
+	Expires     time.Time
+}
+
+func (i *Invite) Expired() bool {
+	return time.Now().After(i.Expires)
+}
+
+func (i *Invite) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"uuid":        i.Uuid.String(),
+		"party":       i.Party.String(),
+		"player":      i.Player.String(),
+		"application": i.Application,
+		"listing":     i.Listing.String(),
+		"role":        i.Role,
+		"expires":     i.Expires.Unix(),
+	}
+}
+
+func DeserializeInvite(data map[string]interface{}) *Invite {
+	return &Invite{
+		Uuid:        uuid.MustParse(data["uuid"].(string)),
+		Party:       uuid.MustParse(data["party"].(string)),
+		Player:      uuid.MustParse(data["player"].(string)),
+		Application: data["application"].(bool),
+		Listing:     uuid.MustParse(data["listing"].(string)),
+		Role:        PartyRole(data["role"].(float64)),
+		Expires:     time.Unix(int64(data["expires"].(float64)), 0),
+	}
+}
+
+// Party finder board entry
+type Listing struct {
+	Uuid     uuid.UUID
+	Party    uuid.UUID
+	Floor    string
+	Location string
+	Roles    []PartyRole
+	MinLevel int
+	Expires  time.Time
+}
+
+func (l *Listing) Expired() bool {
+	return time.Now().After(l.Expires)
+}
+
+func (l *Listing) WantsRole(role PartyRole) bool {
+	for _, wanted := range l.Roles {
+		if wanted == role {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *Listing) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"uuid":      l.Uuid.String(),
+		"party":     l.Party.String(),
+		"floor":     l.Floor,
+		"location":  l.Location,
+		"roles":     l.Roles,
+		"min_level": l.MinLevel,
+		"expires":   l.Expires.Unix(),
+	}
+}
+
+func DeserializeListing(data map[string]interface{}) *Listing {
+	roles := make([]PartyRole, 0)
+
+	for _, role := range data["roles"].([]interface{}) {
+		roles = append(roles, PartyRole(role.(float64)))
+	}
+
+	return &Listing{
+		Uuid:     uuid.MustParse(data["uuid"].(string)),
+		Party:    uuid.MustParse(data["party"].(string)),
+		Floor:    data["floor"].(string),
+		Location: data["location"].(string),
+		Roles:    roles,
+		MinLevel: int(data["min_level"].(float64)),
+		Expires:  time.Unix(int64(data["expires"].(float64)), 0),
+	}
+}
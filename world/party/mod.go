@@ -1,10 +1,24 @@
 package party
 
-import "github.com/google/uuid"
+import (
+	"sao/types"
+	"sao/utils"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Time members have to choose need, greed or pass
+const LootRollDuration = 2 * time.Minute
 
 type Party struct {
-	Players []*PartyEntry
-	Leader  uuid.UUID
+	Players  []*PartyEntry
+	Leader   uuid.UUID
+	LootMode LootMode
+	//Index of member who gets next item in round-robin mode
+	RoundRobin int
+	//Members move with leader instead of getting follow prompt
+	AutoFollow bool
 }
 
 type PartyEntry struct {
@@ -21,6 +35,22 @@ const (
 	None
 )
 
+type LootMode int
+
+const (
+	LootLeader LootMode = iota
+	LootRoundRobin
+	LootNeedGreed
+	LootRandom
+)
+
+var LootModeToString = map[LootMode]string{
+	LootLeader:     "Lider bierze wszystko",
+	LootRoundRobin: "Po kolei",
+	LootNeedGreed:  "Potrzeba/Chciwość",
+	LootRandom:     "Losowo",
+}
+
 func (p *Party) Serialize() map[string]interface{} {
 	members := make([]map[string]interface{}, 0)
 
@@ -32,8 +62,11 @@ func (p *Party) Serialize() map[string]interface{} {
 	}
 
 	return map[string]interface{}{
-		"players": members,
-		"leader":  p.Leader.String(),
+		"players":     members,
+		"leader":      p.Leader.String(),
+		"loot_mode":   p.LootMode,
+		"round_robin": p.RoundRobin,
+		"auto_follow": p.AutoFollow,
 	}
 }
 
@@ -42,6 +75,18 @@ func Deserialize(data map[string]interface{}) *Party {
 		Leader: uuid.MustParse(data["leader"].(string)),
 	}
 
+	if lootMode, ok := data["loot_mode"].(float64); ok {
+		party.LootMode = LootMode(lootMode)
+	}
+
+	if roundRobin, ok := data["round_robin"].(float64); ok {
+		party.RoundRobin = int(roundRobin)
+	}
+
+	if autoFollow, ok := data["auto_follow"].(bool); ok {
+		party.AutoFollow = autoFollow
+	}
+
 	for _, player := range data["players"].([]interface{}) {
 
 		plr := player.(map[string]interface{})
@@ -54,3 +99,81 @@ func Deserialize(data map[string]interface{}) *Party {
 
 	return party
 }
+
+type LootChoice int
+
+const (
+	LootPass LootChoice = iota
+	LootGreed
+	LootNeed
+)
+
+var LootChoiceToString = map[LootChoice]string{
+	LootPass:  "Pas",
+	LootGreed: "Chciwość",
+	LootNeed:  "Potrzeba",
+}
+
+// Pending need/greed roll for single looted item
+type LootRoll struct {
+	Uuid       uuid.UUID
+	Party      uuid.UUID
+	Loot       types.Loot
+	ChannelID  string
+	Candidates []uuid.UUID
+	Choices    map[uuid.UUID]LootChoice
+	Expires    time.Time
+}
+
+func (r *LootRoll) Expired() bool {
+	return time.Now().After(r.Expires)
+}
+
+func (r *LootRoll) IsCandidate(player uuid.UUID) bool {
+	for _, candidate := range r.Candidates {
+		if candidate == player {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *LootRoll) AllChosen() bool {
+	return len(r.Choices) == len(r.Candidates)
+}
+
+// Highest choice wins, ties are settled by 1-100 roll. Missing choice counts as pass
+func (r *LootRoll) Resolve() (uuid.UUID, map[uuid.UUID]int, bool) {
+	bestChoice := LootPass
+
+	for _, choice := range r.Choices {
+		if choice > bestChoice {
+			bestChoice = choice
+		}
+	}
+
+	rolls := make(map[uuid.UUID]int)
+
+	if bestChoice == LootPass {
+		return uuid.Nil, rolls, false
+	}
+
+	winner := uuid.Nil
+	bestRoll := 0
+
+	for _, candidate := range r.Candidates {
+		if choice, ok := r.Choices[candidate]; !ok || choice != bestChoice {
+			continue
+		}
+
+		rolls[candidate] = utils.RandomNumber(1, 100)
+
+		if rolls[candidate] > bestRoll {
+			bestRoll = rolls[candidate]
+			winner = candidate
+		}
+	}
+
+	return winner, rolls, true
+}
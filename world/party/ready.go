@@ -0,0 +1,56 @@
+package party
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const ReadyCheckDuration = 1 * time.Minute
+
+type ReadyState int
+
+const (
+	ReadyPending ReadyState = iota
+	ReadyAll
+	ReadyCancelled
+)
+
+// Asked before party fight, every member must confirm
+type ReadyCheck struct {
+	Uuid      uuid.UUID
+	Party     uuid.UUID
+	Initiator uuid.UUID
+	ThreadId  string
+	Members   []uuid.UUID
+	Ready     map[uuid.UUID]bool
+	Expires   time.Time
+}
+
+func (r *ReadyCheck) Expired() bool {
+	return time.Now().After(r.Expires)
+}
+
+func (r *ReadyCheck) IsMember(player uuid.UUID) bool {
+	for _, member := range r.Members {
+		if member == player {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ReadyCheck) State() ReadyState {
+	for _, ready := range r.Ready {
+		if !ready {
+			return ReadyCancelled
+		}
+	}
+
+	if len(r.Ready) < len(r.Members) {
+		return ReadyPending
+	}
+
+	return ReadyAll
+}
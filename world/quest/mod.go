@@ -0,0 +1,143 @@
+package quest
+
+import (
+	"fmt"
+	"sao/types"
+
+	"github.com/google/uuid"
+)
+
+// Max quests player can have in journal at once
+const MaxActive = 10
+
+type ObjectiveType int
+
+const (
+	OBJECTIVE_KILL ObjectiveType = iota
+	OBJECTIVE_COLLECT
+	OBJECTIVE_REACH
+	OBJECTIVE_CRAFT
+	OBJECTIVE_TOURNAMENT
+)
+
+var StringToObjective = map[string]ObjectiveType{
+	"Kill":       OBJECTIVE_KILL,
+	"Collect":    OBJECTIVE_COLLECT,
+	"Reach":      OBJECTIVE_REACH,
+	"Craft":      OBJECTIVE_CRAFT,
+	"Tournament": OBJECTIVE_TOURNAMENT,
+}
+
+type Objective struct {
+	Type ObjectiveType
+	//Mob id, ingredient uuid, "floor,location" or recipe uuid, empty for tournament wins
+	Target string
+	Count  int
+}
+
+// NPC handing out and accepting quest
+type Giver struct {
+	Name     string
+	Location types.EntityLocation
+}
+
+type Quest struct {
+	UUID        uuid.UUID
+	Name        string
+	Description string
+	Giver       Giver
+	MinLevel    int
+	//Quests that have to be completed first
+	Prerequisites []uuid.UUID
+	Objectives    []Objective
+	Rewards       []types.Loot
+	//Per-player floors unlocked on completion
+	UnlockFloors []string
+}
+
+// Player progress of accepted quest, one entry per objective
+type Progress struct {
+	Quest      uuid.UUID
+	Objectives []int
+}
+
+func LocationKey(location types.EntityLocation) string {
+	return fmt.Sprintf("%s,%s", location.Floor, location.Location)
+}
+
+func NewProgress(quest Quest) *Progress {
+	return &Progress{
+		Quest:      quest.UUID,
+		Objectives: make([]int, len(quest.Objectives)),
+	}
+}
+
+func (p *Progress) Done(quest Quest) bool {
+	for idx, objective := range quest.Objectives {
+		if idx >= len(p.Objectives) || p.Objectives[idx] < objective.Count {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Adds amount to matching objectives, returns true if anything changed
+func (p *Progress) Advance(quest Quest, objectiveType ObjectiveType, target string, amount int) bool {
+	changed := false
+
+	for idx, objective := range quest.Objectives {
+		if objective.Type != objectiveType || objective.Target != target {
+			continue
+		}
+
+		newValue := min(p.Objectives[idx]+amount, objective.Count)
+
+		if newValue != p.Objectives[idx] {
+			p.Objectives[idx] = newValue
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// Collect objectives mirror current inventory, so count is set instead of added
+func (p *Progress) SetCollected(quest Quest, target string, count int) bool {
+	changed := false
+
+	for idx, objective := range quest.Objectives {
+		if objective.Type != OBJECTIVE_COLLECT || objective.Target != target {
+			continue
+		}
+
+		newValue := min(count, objective.Count)
+
+		if newValue != p.Objectives[idx] {
+			p.Objectives[idx] = newValue
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+func (p *Progress) Serialize() map[string]interface{} {
+	return map[string]interface{}{
+		"quest":      p.Quest.String(),
+		"objectives": p.Objectives,
+	}
+}
+
+func DeserializeProgress(data map[string]interface{}) *Progress {
+	progress := &Progress{
+		Quest:      uuid.MustParse(data["quest"].(string)),
+		Objectives: make([]int, 0),
+	}
+
+	for _, value := range data["objectives"].([]interface{}) {
+		progress.Objectives = append(progress.Objectives, int(value.(float64)))
+	}
+
+	return progress
+}
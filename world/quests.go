@@ -0,0 +1,127 @@
+package world
+
+import (
+	"errors"
+	"sao/data"
+	"sao/types"
+	"sao/world/quest"
+	"sort"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Collect objectives ignore target and amount, they are synced with inventory
+func (w *World) UpdateQuests(pUuid uuid.UUID, objectiveType quest.ObjectiveType, target string, amount int) {
+	playerObj, exists := w.Players[pUuid]
+
+	if !exists {
+		return
+	}
+
+	var ready []quest.Quest
+
+	if objectiveType == quest.OBJECTIVE_COLLECT {
+		ready = playerObj.RefreshCollectQuests()
+	} else {
+		ready = playerObj.ProgressQuests(objectiveType, target, amount)
+	}
+
+	for _, questData := range ready {
+		w.BufferChannel <- types.DiscordMessageStruct{
+			ChannelID: playerObj.Meta.UserID,
+			MessageContent: discord.NewMessageCreateBuilder().
+				SetContentf("Zadanie %s gotowe do oddania u %s (%s)", questData.Name, questData.Giver.Name, questData.Giver.Location.Location).
+				Build(),
+			DM: true,
+		}
+	}
+}
+
+// Quests offered by givers in player's location that player can take
+func (w *World) AvailableQuests(pUuid uuid.UUID) []quest.Quest {
+	playerObj := w.Players[pUuid]
+
+	available := make([]quest.Quest, 0)
+
+	for _, questData := range data.Quests {
+		if questData.Giver.Location != playerObj.Meta.Location {
+			continue
+		}
+
+		if playerObj.CanAcceptQuest(questData) != nil {
+			continue
+		}
+
+		available = append(available, questData)
+	}
+
+	sort.Slice(available, func(i, j int) bool { return available[i].Name < available[j].Name })
+
+	return available
+}
+
+func (w *World) AcceptQuest(pUuid, questUuid uuid.UUID) error {
+	playerObj := w.Players[pUuid]
+
+	questData, exists := data.Quests[questUuid]
+
+	if !exists {
+		return errors.New("QUEST_NOT_FOUND")
+	}
+
+	if questData.Giver.Location != playerObj.Meta.Location {
+		return errors.New("WRONG_LOCATION")
+	}
+
+	return playerObj.AcceptQuest(questData)
+}
+
+// Quest is turned in at the giver, returns granted rewards
+func (w *World) CompleteQuest(pUuid, questUuid uuid.UUID) ([]types.Loot, error) {
+	playerObj := w.Players[pUuid]
+
+	questData, exists := data.Quests[questUuid]
+
+	if !exists {
+		return nil, errors.New("QUEST_NOT_FOUND")
+	}
+
+	if playerObj.Meta.FightInstance != nil {
+		return nil, errors.New("IN_FIGHT")
+	}
+
+	if questData.Giver.Location != playerObj.Meta.Location {
+		return nil, errors.New("WRONG_LOCATION")
+	}
+
+	for _, reward := range questData.Rewards {
+		if reward.Type != types.LOOT_ITEM || reward.Meta.Type == types.ITEM_MATERIAL {
+			continue
+		}
+
+		itemObj, ok := data.Items[reward.Meta.Uuid]
+
+		if ok && !playerObj.Inventory.CanAddItem(&itemObj, reward.Count) {
+			return nil, errors.New("INVENTORY_FULL")
+		}
+	}
+
+	if err := playerObj.TurnInQuest(questData); err != nil {
+		return nil, err
+	}
+
+	for _, reward := range questData.Rewards {
+		w.GiveLoot(playerObj, reward)
+	}
+
+	for _, floor := range questData.UnlockFloors {
+		playerObj.UnlockFloor(floor)
+	}
+
+	return questData.Rewards, nil
+}
+
+func (w *World) AbandonQuest(pUuid, questUuid uuid.UUID) error {
+	return w.Players[pUuid].AbandonQuest(questUuid)
+}
@@ -0,0 +1,366 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/player"
+	"sao/types"
+	"sao/world/death"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+type LeaderboardEntry struct {
+	Name   string
+	UserID string
+	Level  int
+	Exp    int
+	Kills  int
+	Fallen bool
+}
+
+// First city location on floor, floor default if there is none
+func (w *World) respawnLocation(floorName string) string {
+	floor := w.Floors[floorName]
+
+	for _, location := range floor.Locations {
+		if location.CityPart {
+			return location.Name
+		}
+	}
+
+	return floor.Default
+}
+
+func (w *World) Respawn(pUuid uuid.UUID) {
+	playerObj := w.Players[pUuid]
+
+	deathLocation := playerObj.Meta.Location
+	location := w.Floors[deathLocation.Floor].FindLocation(deathLocation.Location)
+
+	w.StateLock.Lock()
+
+	result := playerObj.ApplyDeathPenalty()
+
+	if result.Dropped != nil {
+		dropped := &death.DroppedItem{
+			Uuid:     uuid.New(),
+			Owner:    pUuid,
+			Item:     result.Dropped,
+			Location: deathLocation,
+			Expires:  w.Time.DayNumber() + player.Default.DeathPenalty.DropDays,
+		}
+
+		w.DroppedItems[dropped.Uuid] = dropped
+	}
+
+	w.StateLock.Unlock()
+
+	playerObj.Meta.Location.Location = w.respawnLocation(deathLocation.Floor)
+	playerObj.Stats.HP = playerObj.GetStat(types.STAT_HP)
+
+	description := fmt.Sprintf("%s zostaje wskrzeszony w %s...", playerObj.GetName(), playerObj.Meta.Location.Location)
+
+	if result.ExpLost > 0 {
+		description += fmt.Sprintf("\nStracono %d XP", result.ExpLost)
+	}
+
+	if result.GoldLost > 0 {
+		description += fmt.Sprintf("\nStracono %d golda", result.GoldLost)
+	}
+
+	if result.Dropped != nil {
+		description += fmt.Sprintf("\nUpuszczono %s w %s", result.Dropped.Name, deathLocation.Location)
+	}
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: location.CID,
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.NewEmbedBuilder().SetTitle("Wskrzeszenie!").SetDescription(description).Build(),
+			).
+			Build(),
+	}
+}
+
+// Hardcore death, character is removed from the world
+func (w *World) PermaDeath(pUuid uuid.UUID) {
+	playerObj := w.Players[pUuid]
+
+	location := w.Floors[playerObj.Meta.Location.Floor].FindLocation(playerObj.Meta.Location.Location)
+
+	fallen := &death.Fallen{
+		Name:     playerObj.GetName(),
+		UserID:   playerObj.Meta.UserID,
+		Level:    playerObj.XP.Level,
+		Kills:    playerObj.TotalKills(),
+		Location: playerObj.Meta.Location,
+		Day:      w.Time.DayNumber(),
+	}
+
+	w.leaveWorld(pUuid)
+
+	w.Fallen = append(w.Fallen, fallen)
+
+	delete(w.Players, pUuid)
+
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID: location.CID,
+		MessageContent: discord.
+			NewMessageCreateBuilder().
+			AddEmbeds(
+				discord.NewEmbedBuilder().
+					SetTitle("Ku pamięci").
+					SetDescriptionf(
+						"%s (<@%s>) poległ na zawsze w %s.\nPoziom: %d\nPokonani przeciwnicy: %d",
+						fallen.Name,
+						fallen.UserID,
+						fallen.Location.Location,
+						fallen.Level,
+						fallen.Kills,
+					).
+					Build(),
+			).
+			Build(),
+	}
+}
+
+// Cleans up everything that references player before removing them
+func (w *World) leaveWorld(pUuid uuid.UUID) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.Party != nil {
+		partyUuid := playerObj.Meta.Party.UUID
+
+		//Pending team challenges were made with this member in the party
+		for challengeUuid, challenge := range w.TeamChallenges {
+			if challenge.Fight == nil && challenge.Involves(partyUuid) {
+				delete(w.TeamChallenges, challengeUuid)
+			}
+		}
+
+		partyObj := w.Parties[partyUuid]
+
+		for i, member := range partyObj.Players {
+			if member.PlayerUuid == pUuid {
+				partyObj.Players = append(partyObj.Players[:i], partyObj.Players[i+1:]...)
+				break
+			}
+		}
+
+		if len(partyObj.Players) <= 1 {
+			for _, member := range partyObj.Players {
+				w.Players[member.PlayerUuid].Meta.Party = nil
+			}
+
+			w.removePartyListing(partyUuid)
+
+			delete(w.Parties, partyUuid)
+		} else {
+			if partyObj.Leader == pUuid {
+				partyObj.Leader = partyObj.Players[0].PlayerUuid
+			}
+
+			for _, member := range partyObj.Players {
+				w.Players[member.PlayerUuid].Meta.Party.MembersCount = len(partyObj.Players)
+			}
+		}
+	}
+
+	if playerObj.Meta.Transaction != nil {
+		transactionObj, exists := w.Transactions[*playerObj.Meta.Transaction]
+
+		if exists {
+			for _, side := range []uuid.UUID{transactionObj.LeftSide.Who, transactionObj.RightSide.Who} {
+				if other, ok := w.Players[side]; ok {
+					other.Meta.Transaction = nil
+				}
+			}
+
+			delete(w.Transactions, transactionObj.Uuid)
+		}
+	}
+
+	for inviteUuid, invite := range w.PartyInvites {
+		if invite.Player == pUuid {
+			delete(w.PartyInvites, inviteUuid)
+		}
+	}
+
+	//Check can't pass without every member
+	for checkUuid, check := range w.ReadyChecks {
+		if check.IsMember(pUuid) {
+			delete(w.ReadyChecks, checkUuid)
+		}
+	}
+
+	for challengeUuid, challenge := range w.Duels {
+		if challenge.Fight == nil && challenge.Involves(pUuid) {
+			delete(w.Duels, challengeUuid)
+		}
+	}
+
+	for inviteUuid, invite := range w.GuildInvites {
+		if invite.Player == pUuid {
+			delete(w.GuildInvites, inviteUuid)
+		}
+	}
+
+	if guildObj := w.playerGuild(pUuid); guildObj != nil {
+		w.removeGuildMember(guildObj, pUuid)
+	}
+
+	for rollUuid, roll := range w.LootRolls {
+		if !roll.IsCandidate(pUuid) {
+			continue
+		}
+
+		roll.Candidates = slices.DeleteFunc(roll.Candidates, func(candidate uuid.UUID) bool {
+			return candidate == pUuid
+		})
+
+		delete(roll.Choices, pUuid)
+
+		if len(roll.Candidates) == 0 {
+			delete(w.LootRolls, rollUuid)
+		} else if roll.AllChosen() {
+			w.finishLootRoll(rollUuid)
+		}
+	}
+
+	for auctionUuid, auctionObj := range w.Auctions {
+		if auctionObj.Seller == pUuid {
+			if auctionObj.HasBids() {
+				w.Players[auctionObj.Bidder].AddGold(auctionObj.Bid)
+			}
+
+			delete(w.Auctions, auctionUuid)
+
+			continue
+		}
+
+		//Gold in escrow is lost with the character
+		if auctionObj.Bidder == pUuid {
+			auctionObj.Bid = 0
+			auctionObj.Bidder = uuid.Nil
+		}
+	}
+}
+
+func (w *World) DroppedItemsAt(location types.EntityLocation) []*death.DroppedItem {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	items := make([]*death.DroppedItem, 0)
+
+	for _, dropped := range w.DroppedItems {
+		if dropped.Location == location {
+			items = append(items, dropped)
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return strings.Compare(items[i].Item.Name, items[j].Item.Name) < 0
+	})
+
+	return items
+}
+
+func (w *World) PickUpItem(pUuid, dropUuid uuid.UUID) (*types.PlayerItem, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.FightInstance != nil {
+		return nil, errors.New("IN_FIGHT")
+	}
+
+	dropped, exists := w.DroppedItems[dropUuid]
+
+	if !exists {
+		return nil, errors.New("DROP_NOT_FOUND")
+	}
+
+	if dropped.Location != playerObj.Meta.Location {
+		return nil, errors.New("NOT_HERE")
+	}
+
+	if !playerObj.Inventory.CanAddItem(dropped.Item, dropped.Item.Count) {
+		return nil, errors.New("INVENTORY_FULL")
+	}
+
+	delete(w.DroppedItems, dropUuid)
+
+	playerObj.AddItem(dropped.Item)
+
+	return dropped.Item, nil
+}
+
+func (w *World) CleanupDroppedItems() {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	day := w.Time.DayNumber()
+
+	for dropUuid, dropped := range w.DroppedItems {
+		if dropped.Expired(day) {
+			delete(w.DroppedItems, dropUuid)
+		}
+	}
+}
+
+// Normal leaderboard lists living characters, hardcore one also the fallen
+func (w *World) Leaderboard(hardcore bool) []LeaderboardEntry {
+	entries := make([]LeaderboardEntry, 0)
+
+	for _, playerObj := range w.Players {
+		if playerObj.Meta.Hardcore != hardcore {
+			continue
+		}
+
+		entries = append(entries, LeaderboardEntry{
+			Name:   playerObj.GetName(),
+			UserID: playerObj.Meta.UserID,
+			Level:  playerObj.XP.Level,
+			Exp:    playerObj.XP.Exp,
+			Kills:  playerObj.TotalKills(),
+			Fallen: false,
+		})
+	}
+
+	if hardcore {
+		for _, fallen := range w.Fallen {
+			entries = append(entries, LeaderboardEntry{
+				Name:   fallen.Name,
+				UserID: fallen.UserID,
+				Level:  fallen.Level,
+				Exp:    0,
+				Kills:  fallen.Kills,
+				Fallen: true,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Level != entries[j].Level {
+			return entries[i].Level > entries[j].Level
+		}
+
+		if entries[i].Exp != entries[j].Exp {
+			return entries[i].Exp > entries[j].Exp
+		}
+
+		return entries[i].Kills > entries[j].Kills
+	})
+
+	return entries
+}
@@ -0,0 +1,326 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/battle"
+	"sao/player"
+	"sao/types"
+	"sao/world/duel"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Every member has to stand at the arena and be free to fight, caller has to hold StateLock
+func (w *World) checkTeam(partyUuid uuid.UUID, location types.EntityLocation) ([]*player.Player, error) {
+	partyData, exists := w.Parties[partyUuid]
+
+	if !exists {
+		return nil, errors.New("PARTY_NOT_FOUND")
+	}
+
+	members := make([]*player.Player, 0)
+
+	for _, member := range partyData.Players {
+		memberObj := w.Players[member.PlayerUuid]
+
+		if memberObj.Meta.FightInstance != nil {
+			return nil, errors.New("IN_FIGHT")
+		}
+
+		if w.playerRun(memberObj.GetUUID()) != nil {
+			return nil, errors.New("IN_DUNGEON")
+		}
+
+		if memberObj.IsTraveling() || memberObj.Meta.Location != location || memberObj.GetCurrentHP() <= 0 {
+			return nil, errors.New("PARTY_NOT_READY")
+		}
+
+		members = append(members, memberObj)
+	}
+
+	return members, nil
+}
+
+func (w *World) ChallengeTeam(leaderUuid, opponentUuid uuid.UUID) (*duel.TeamChallenge, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	leader := w.Players[leaderUuid]
+	opponent := w.Players[opponentUuid]
+
+	if leader.Meta.Party == nil {
+		return nil, errors.New("NOT_IN_PARTY")
+	}
+
+	if w.Parties[leader.Meta.Party.UUID].Leader != leaderUuid {
+		return nil, errors.New("NOT_LEADER")
+	}
+
+	if opponent.Meta.Party == nil {
+		return nil, errors.New("OPPONENT_NOT_IN_PARTY")
+	}
+
+	if opponent.Meta.Party.UUID == leader.Meta.Party.UUID {
+		return nil, errors.New("SAME_PARTY")
+	}
+
+	if w.Parties[opponent.Meta.Party.UUID].Leader != opponentUuid {
+		return nil, errors.New("OPPONENT_NOT_LEADER")
+	}
+
+	if !w.canDuelAt(leader.Meta.Location) {
+		return nil, errors.New("LOCATION_NOT_ALLOWED")
+	}
+
+	if _, err := w.checkTeam(leader.Meta.Party.UUID, leader.Meta.Location); err != nil {
+		return nil, err
+	}
+
+	if _, err := w.checkTeam(opponent.Meta.Party.UUID, leader.Meta.Location); err != nil {
+		return nil, errors.New("OPPONENT_" + err.Error())
+	}
+
+	for challengeUuid, challenge := range w.TeamChallenges {
+		if challenge.Expired() {
+			delete(w.TeamChallenges, challengeUuid)
+			continue
+		}
+
+		if challenge.Involves(leader.Meta.Party.UUID) && challenge.Involves(opponent.Meta.Party.UUID) {
+			return nil, errors.New("ALREADY_CHALLENGED")
+		}
+	}
+
+	challenge := &duel.TeamChallenge{
+		Uuid:       uuid.New(),
+		Challenger: leader.Meta.Party.UUID,
+		Opponent:   opponent.Meta.Party.UUID,
+		Location:   leader.Meta.Location,
+		Expires:    time.Now().Add(duel.ChallengeDuration),
+		Fight:      nil,
+	}
+
+	w.TeamChallenges[challenge.Uuid] = challenge
+
+	return challenge, nil
+}
+
+// Any member of either party can call off a challenge before it starts
+func (w *World) DeclineTeamBattle(challengeUuid, pUuid uuid.UUID) (*duel.TeamChallenge, error) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	challenge, exists := w.TeamChallenges[challengeUuid]
+
+	if !exists || challenge.Fight != nil {
+		return nil, errors.New("CHALLENGE_NOT_FOUND")
+	}
+
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.Party == nil || !challenge.Involves(playerObj.Meta.Party.UUID) {
+		return nil, errors.New("NOT_ALLOWED")
+	}
+
+	delete(w.TeamChallenges, challengeUuid)
+
+	return challenge, nil
+}
+
+// Only the opponent leader accepts, challenging party fights on side 0
+func (w *World) AcceptTeamBattle(challengeUuid, pUuid uuid.UUID) error {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	challenge, exists := w.TeamChallenges[challengeUuid]
+
+	if !exists || challenge.Fight != nil {
+		return errors.New("CHALLENGE_NOT_FOUND")
+	}
+
+	if challenge.Expired() {
+		delete(w.TeamChallenges, challengeUuid)
+
+		return errors.New("CHALLENGE_EXPIRED")
+	}
+
+	opponentParty, exists := w.Parties[challenge.Opponent]
+
+	if !exists || opponentParty.Leader != pUuid {
+		return errors.New("NOT_ALLOWED")
+	}
+
+	opponents, err := w.checkTeam(challenge.Opponent, challenge.Location)
+
+	if err != nil {
+		return err
+	}
+
+	challengers, err := w.checkTeam(challenge.Challenger, challenge.Location)
+
+	if err != nil {
+		return errors.New("OPPONENT_" + err.Error())
+	}
+
+	entities := make(battle.EntityMap)
+	challenge.Snapshots = make(map[uuid.UUID]duel.Snapshot)
+
+	for side, members := range [][]*player.Player{challengers, opponents} {
+		challenge.Teams[side] = make([]uuid.UUID, 0)
+
+		for _, member := range members {
+			entities[member.GetUUID()] = battle.EntityEntry{Entity: member, Side: side}
+
+			challenge.Snapshots[member.GetUUID()] = duel.Snapshot{HP: member.GetCurrentHP(), Mana: member.GetCurrentMana()}
+			challenge.Teams[side] = append(challenge.Teams[side], member.GetUUID())
+		}
+	}
+
+	arena := w.Floors[challenge.Location.Floor].FindLocation(challenge.Location.Location)
+
+	fight := battle.Fight{
+		Entities:       entities,
+		DiscordChannel: w.BufferChannel,
+		Location:       arena,
+		Meta: &battle.FightMeta{
+			TeamBattle: &battle.TeamBattleData{Challenge: challenge.Uuid},
+		},
+	}
+
+	fight.Init()
+
+	fightUuid := w.RegisterFight(&fight)
+
+	for _, members := range [][]*player.Player{challengers, opponents} {
+		for _, member := range members {
+			member.Meta.FightInstance = &fightUuid
+		}
+	}
+
+	challenge.Fight = &fightUuid
+
+	go w.ListenForFight(fightUuid)
+
+	return nil
+}
+
+func (w *World) teamNames(team []uuid.UUID) []string {
+	names := make([]string, 0)
+
+	for _, member := range team {
+		if playerObj, exists := w.Players[member]; exists {
+			names = append(names, playerObj.GetName())
+		}
+	}
+
+	return names
+}
+
+func (w *World) teamRating(team []uuid.UUID) *duel.TeamRating {
+	key := duel.TeamKey(team)
+	rating, exists := w.TeamRatings[key]
+
+	if !exists {
+		rating = duel.NewTeamRating(w.teamNames(team))
+		w.TeamRatings[key] = rating
+	}
+
+	rating.Players = w.teamNames(team)
+
+	return rating
+}
+
+// Every side is made of players, the one with a member still standing wins
+func (w *World) FinishTeamBattle(challengeUuid uuid.UUID, fight *battle.Fight) {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	challenge, exists := w.TeamChallenges[challengeUuid]
+
+	delete(w.TeamChallenges, challengeUuid)
+
+	if !exists {
+		return
+	}
+
+	//Has to be checked before HP is restored
+	winnerSide := -1
+
+	if sides := fight.SidesLeft(); len(sides) == 1 {
+		winnerSide = sides[0]
+	}
+
+	for playerUuid, snapshot := range challenge.Snapshots {
+		playerObj, exists := w.Players[playerUuid]
+
+		if !exists {
+			continue
+		}
+
+		playerObj.Stats.HP = max(snapshot.HP, 1)
+		playerObj.Stats.CurrentMana = snapshot.Mana
+	}
+
+	if winnerSide == -1 {
+		w.sendDuelMessage(fight, "Bitwa drużyn nierozstrzygnięta", fmt.Sprintf(
+			"%s\nkontra\n%s",
+			strings.Join(w.teamNames(challenge.Teams[0]), ", "),
+			strings.Join(w.teamNames(challenge.Teams[1]), ", "),
+		))
+
+		return
+	}
+
+	winners := challenge.Teams[winnerSide]
+	losers := challenge.Teams[1-winnerSide]
+
+	winnerRating := w.teamRating(winners)
+	loserRating := w.teamRating(losers)
+
+	change := duel.RatingChange(winnerRating.Rating, loserRating.Rating)
+
+	winnerRating.Rating += change
+	winnerRating.Wins++
+	loserRating.Rating -= change
+	loserRating.Losses++
+
+	w.TeamResults = append(w.TeamResults, duel.TeamResult{
+		Winners:      winnerRating.Players,
+		Losers:       loserRating.Players,
+		RatingChange: change,
+		Day:          w.Time.DayNumber(),
+	})
+
+	if len(w.TeamResults) > duel.MaxTeamResults {
+		w.TeamResults = w.TeamResults[len(w.TeamResults)-duel.MaxTeamResults:]
+	}
+
+	w.sendDuelMessage(fight, "Bitwa drużyn zakończona", fmt.Sprintf(
+		"Zwycięzcy: %s\nRanking: %d (+%d), przegrani %d (-%d)",
+		strings.Join(winnerRating.Players, ", "),
+		winnerRating.Rating, change,
+		loserRating.Rating, change,
+	))
+}
+
+// Highest rated compositions first
+func (w *World) TeamRanking() []*duel.TeamRating {
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	ranking := make([]*duel.TeamRating, 0)
+
+	for _, rating := range w.TeamRatings {
+		ranking = append(ranking, rating)
+	}
+
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].Rating > ranking[j].Rating
+	})
+
+	return ranking
+}
@@ -0,0 +1,249 @@
+package world
+
+import (
+	"errors"
+	"fmt"
+	"sao/player"
+	"sao/types"
+	"sao/utils"
+	"sao/world/location"
+	"slices"
+
+	"github.com/disgoorg/disgo/discord"
+	"github.com/google/uuid"
+)
+
+// Reason passed to MovePlayer on arrival
+const MOVE_TRAVEL = "travel"
+
+// Percent per clock tick spent on the road or exploring
+const EncounterChance = 15
+
+func (w *World) checkMove(playerObj *player.Player, floorName, locationName string) error {
+	floorData, floorExists := w.Floors[floorName]
+
+	if !floorExists || (!floorData.Unlocked && playerObj.Meta.Location.Floor != floorName) {
+		return errors.New("floor not found or locked")
+	}
+
+	locationData := floorData.FindLocation(locationName)
+
+	if locationData == nil || !w.CanEnter(playerObj, floorName, *locationData) {
+		return errors.New("location not found or locked")
+	}
+
+	if playerObj.Meta.FightInstance != nil {
+		return errors.New("player is in fight")
+	}
+
+	if slices.Contains(floorData.Flags, "per-player") && !slices.Contains(playerObj.Meta.UnlockedFloors, floorName) {
+		return errors.New("player has not unlocked this floor")
+	}
+
+	return nil
+}
+
+// Hidden locations are open only to players who discovered them
+func (w *World) CanEnter(playerObj *player.Player, floorName string, loc location.Location) bool {
+	return loc.Unlocked || playerObj.HasDiscovered(types.EntityLocation{Floor: floorName, Location: loc.Name})
+}
+
+// Returns ticks until arrival, 0 if player was moved right away
+func (w *World) Travel(pUuid uuid.UUID, floorName, locationName string) (int, error) {
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.FightInstance != nil {
+		return 0, errors.New("IN_FIGHT")
+	}
+
+	if playerObj.IsTraveling() {
+		return 0, errors.New("TRAVELING")
+	}
+
+	if w.PlayerDungeon(pUuid) != nil {
+		return 0, errors.New("IN_DUNGEON")
+	}
+
+	if playerObj.Meta.Location.Floor == floorName && playerObj.Meta.Location.Location == locationName {
+		return 0, errors.New("ALREADY_HERE")
+	}
+
+	if err := w.checkMove(playerObj, floorName, locationName); err != nil {
+		return 0, errors.New("LOCATION_LOCKED")
+	}
+
+	current := w.Floors[playerObj.Meta.Location.Floor].FindLocation(playerObj.Meta.Location.Location)
+	destination := w.Floors[floorName].FindLocation(locationName)
+
+	//Walking around the city takes no time
+	if destination.Travel <= 0 || (current != nil && current.CityPart && destination.CityPart) {
+		return 0, w.MovePlayer(pUuid, floorName, locationName, "")
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	playerObj.Meta.Travel = &player.Travel{
+		Destination: types.EntityLocation{Floor: floorName, Location: locationName},
+		TicksLeft:   destination.Travel,
+		Explore:     false,
+	}
+
+	return destination.Travel, nil
+}
+
+func (w *World) Explore(pUuid uuid.UUID) error {
+	playerObj := w.Players[pUuid]
+
+	if playerObj.Meta.FightInstance != nil {
+		return errors.New("IN_FIGHT")
+	}
+
+	if playerObj.IsTraveling() {
+		return errors.New("TRAVELING")
+	}
+
+	current := w.Floors[playerObj.Meta.Location.Floor].FindLocation(playerObj.Meta.Location.Location)
+
+	if current == nil || current.CityPart {
+		return errors.New("NOTHING_TO_EXPLORE")
+	}
+
+	w.StateLock.Lock()
+	defer w.StateLock.Unlock()
+
+	playerObj.Meta.Travel = &player.Travel{
+		Destination: playerObj.Meta.Location,
+		TicksLeft:   player.ExploreTicks,
+		Explore:     true,
+	}
+
+	return nil
+}
+
+func (w *World) sendTravelDM(playerObj *player.Player, content string) {
+	w.BufferChannel <- types.DiscordMessageStruct{
+		ChannelID:      playerObj.Meta.UserID,
+		MessageContent: discord.NewMessageCreateBuilder().SetContent(content).Build(),
+		DM:             true,
+	}
+}
+
+// Journey that ended on this tick
+type travelStop struct {
+	pUuid       uuid.UUID
+	travel      *player.Travel
+	destination location.Location
+	//Set when enemies were met on the road
+	enemy *location.EnemyMeta
+}
+
+// Called on every clock tick, fights pause the road
+func (w *World) AdvanceTravels() {
+	stops := make([]travelStop, 0)
+
+	w.StateLock.Lock()
+
+	for pUuid, playerObj := range w.Players {
+		travel := playerObj.Meta.Travel
+
+		if travel == nil || playerObj.Meta.FightInstance != nil {
+			continue
+		}
+
+		floor := w.Floors[travel.Destination.Floor]
+		destination := floor.FindLocation(travel.Destination.Location)
+
+		//Saved journey can lead to location removed from game data
+		if destination == nil {
+			playerObj.Meta.Travel = nil
+
+			continue
+		}
+
+		travel.TicksLeft--
+
+		if travel.Explore {
+			w.rollDiscoveries(playerObj, floor, *destination)
+		}
+
+		enemy := w.rollEncounter(*destination)
+
+		if enemy == nil && travel.TicksLeft > 0 {
+			continue
+		}
+
+		playerObj.Meta.Travel = nil
+
+		stops = append(stops, travelStop{pUuid: pUuid, travel: travel, destination: *destination, enemy: enemy})
+	}
+
+	w.StateLock.Unlock()
+
+	//Moving and fighting take the lock on their own
+	for _, stop := range stops {
+		w.finishTravel(stop)
+	}
+}
+
+func (w *World) finishTravel(stop travelStop) {
+	playerObj := w.Players[stop.pUuid]
+
+	if !stop.travel.Explore {
+		if err := w.MovePlayer(stop.pUuid, stop.travel.Destination.Floor, stop.travel.Destination.Location, MOVE_TRAVEL); err != nil {
+			w.sendTravelDM(playerObj, fmt.Sprintf("Nie udało się dotrzeć do %s, zawracasz", stop.destination.Name))
+
+			return
+		}
+	}
+
+	if stop.enemy != nil {
+		w.sendTravelDM(playerObj, fmt.Sprintf("Natrafiłeś na przeciwników w lokacji %s! Walka czeka na <#%s>", stop.destination.Name, stop.destination.CID))
+
+		//Party didn't agree to this fight, only the traveller takes it
+		go w.mobFight(stop.pUuid, false, "", false, stop.enemy.Enemy, utils.RandomNumber(stop.enemy.MinNum, stop.enemy.MaxNum))
+
+		return
+	}
+
+	if stop.travel.Explore {
+		w.sendTravelDM(playerObj, fmt.Sprintf("Zakończono eksplorację lokacji %s", stop.destination.Name))
+
+		return
+	}
+
+	w.sendTravelDM(playerObj, fmt.Sprintf("Dotarłeś do %s (<#%s>)", stop.destination.Name, stop.destination.CID))
+}
+
+func (w *World) rollDiscoveries(playerObj *player.Player, floor location.Floor, current location.Location) {
+	for _, loc := range floor.Locations {
+		if loc.Unlocked || loc.Discovery == nil || !slices.Contains(loc.Discovery.From, current.Name) {
+			continue
+		}
+
+		if utils.RandomNumber(1, 100) > loc.Discovery.Chance {
+			continue
+		}
+
+		if playerObj.Discover(types.EntityLocation{Floor: floor.Name, Location: loc.Name}) {
+			w.sendTravelDM(playerObj, fmt.Sprintf("Odkryłeś ukrytą lokację %s! Możesz tam przejść przez /ruch", loc.Name))
+		}
+	}
+}
+
+// Enemies come from destination, player arrives there for the fight
+func (w *World) rollEncounter(destination location.Location) *location.EnemyMeta {
+	if slices.Contains(destination.Flags, "private") {
+		return nil
+	}
+
+	enemies := destination.ActiveEnemies(w.Time)
+
+	if len(enemies) == 0 || utils.RandomNumber(1, 100) > EncounterChance {
+		return nil
+	}
+
+	enemy := utils.RandomElement(enemies)
+
+	return &enemy
+}